@@ -130,6 +130,39 @@ func (r *ProtocolIncus) UpdateClusterMember(name string, member api.ClusterMembe
 	return nil
 }
 
+// UpdateClusterMembers updates the configuration of several cluster members at once.
+// Either all the updates are applied or none of them is.
+func (r *ProtocolIncus) UpdateClusterMembers(members map[string]api.ClusterMemberPut) error {
+	if !r.HasExtension("clustering_members_bulk_update") {
+		return fmt.Errorf("The server is missing the required \"clustering_members_bulk_update\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("PUT", "/cluster/members", members, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PreviewClusterMemberUpdate returns the changes updating the member with the given configuration would make, without applying them.
+func (r *ProtocolIncus) PreviewClusterMemberUpdate(name string, member api.ClusterMemberPut, ETag string) (*api.ClusterMemberPutPreview, error) {
+	if !r.HasExtension("cluster_member_update_preview") {
+		return nil, fmt.Errorf("The server is missing the required \"cluster_member_update_preview\" API extension")
+	}
+
+	preview := api.ClusterMemberPutPreview{}
+
+	// Send the request
+	_, err := r.queryStruct("PUT", fmt.Sprintf("/cluster/members/%s?dry-run=1", name), member, ETag, &preview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &preview, nil
+}
+
 // RenameClusterMember changes the name of an existing member.
 func (r *ProtocolIncus) RenameClusterMember(name string, member api.ClusterMemberPost) error {
 	if !r.HasExtension("clustering") {
@@ -150,6 +183,10 @@ func (r *ProtocolIncus) CreateClusterMember(member api.ClusterMembersPost) (Oper
 		return nil, fmt.Errorf("The server is missing the required \"clustering_join_token\" API extension")
 	}
 
+	if member.ServerNamePattern != "" && !r.HasExtension("clustering_join_token_pattern") {
+		return nil, fmt.Errorf("The server is missing the required \"clustering_join_token_pattern\" API extension")
+	}
+
 	op, _, err := r.queryOperation("POST", "/cluster/members", member, "")
 	if err != nil {
 		return nil, err
@@ -195,6 +232,22 @@ func (r *ProtocolIncus) UpdateClusterMemberState(name string, state api.ClusterM
 		return nil, fmt.Errorf("The server is missing the required \"clustering_evacuation\" API extension")
 	}
 
+	if (state.Action == "drain" || state.Action == "uncordon") && !r.HasExtension("clustering_drain") {
+		return nil, fmt.Errorf("The server is missing the required \"clustering_drain\" API extension")
+	}
+
+	if state.DryRun && !r.HasExtension("clustering_evacuation_dry_run") {
+		return nil, fmt.Errorf("The server is missing the required \"clustering_evacuation_dry_run\" API extension")
+	}
+
+	if (state.Parallel > 1 || state.StopOnError) && !r.HasExtension("clustering_evacuation_parallel") {
+		return nil, fmt.Errorf("The server is missing the required \"clustering_evacuation_parallel\" API extension")
+	}
+
+	if len(state.Targets) > 0 && !r.HasExtension("clustering_evacuation_targets") {
+		return nil, fmt.Errorf("The server is missing the required \"clustering_evacuation_targets\" API extension")
+	}
+
 	op, _, err := r.queryOperation("POST", fmt.Sprintf("/cluster/members/%s/state", name), state, "")
 	if err != nil {
 		return nil, err
@@ -256,6 +309,10 @@ func (r *ProtocolIncus) CreateClusterGroup(group api.ClusterGroupsPost) error {
 		return fmt.Errorf("The server is missing the required \"clustering_groups\" API extension")
 	}
 
+	if len(group.Config) > 0 && !r.HasExtension("cluster_group_config") {
+		return fmt.Errorf("The server is missing the required \"cluster_group_config\" API extension")
+	}
+
 	_, _, err := r.query("POST", "/cluster/groups", group, "")
 	if err != nil {
 		return err
@@ -284,6 +341,10 @@ func (r *ProtocolIncus) UpdateClusterGroup(name string, group api.ClusterGroupPu
 		return fmt.Errorf("The server is missing the required \"clustering_groups\" API extension")
 	}
 
+	if len(group.Config) > 0 && !r.HasExtension("cluster_group_config") {
+		return fmt.Errorf("The server is missing the required \"cluster_group_config\" API extension")
+	}
+
 	// Send the request
 	_, _, err := r.query("PUT", fmt.Sprintf("/cluster/groups/%s", name), group, ETag)
 	if err != nil {
@@ -3,6 +3,7 @@ package incus
 import (
 	"bufio"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/websocket"
@@ -22,6 +24,7 @@ import (
 	"github.com/lxc/incus/v6/shared/tcp"
 	localtls "github.com/lxc/incus/v6/shared/tls"
 	"github.com/lxc/incus/v6/shared/units"
+	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/ws"
 )
 
@@ -203,6 +206,13 @@ func (r *ProtocolIncus) UpdateInstances(state api.InstancesPut, ETag string) (Op
 
 // rebuildInstance initiates a rebuild of a given instance on the Incus Protocol server and returns the corresponding operation or an error.
 func (r *ProtocolIncus) rebuildInstance(instanceName string, instance api.InstanceRebuildPost) (Operation, error) {
+	if instance.ResetIdentity {
+		err := r.CheckExtension("instances_rebuild_reset_identity")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
 	if err != nil {
 		return nil, err
@@ -521,7 +531,7 @@ func (r *ProtocolIncus) CreateInstanceFromBackup(args InstanceBackupArgs) (Opera
 		return nil, err
 	}
 
-	if args.PoolName == "" && args.Name == "" {
+	if args.PoolName == "" && args.Name == "" && !args.RestorePlacement {
 		// Send the request
 		op, _, err := r.queryOperation("POST", path, args.BackupFile, "")
 		if err != nil {
@@ -539,6 +549,10 @@ func (r *ProtocolIncus) CreateInstanceFromBackup(args InstanceBackupArgs) (Opera
 		return nil, fmt.Errorf(`The server is missing the required "backup_override_name" API extension`)
 	}
 
+	if args.RestorePlacement && !r.HasExtension("backup_placement") {
+		return nil, fmt.Errorf(`The server is missing the required "backup_placement" API extension`)
+	}
+
 	// Prepare the HTTP request
 	reqURL, err := r.setQueryAttributes(fmt.Sprintf("%s/1.0%s", r.httpBaseURL.String(), path))
 	if err != nil {
@@ -560,6 +574,10 @@ func (r *ProtocolIncus) CreateInstanceFromBackup(args InstanceBackupArgs) (Opera
 		req.Header.Set("X-Incus-name", args.Name)
 	}
 
+	if args.RestorePlacement {
+		req.Header.Set("X-Incus-restore-placement", "true")
+	}
+
 	// Send the request
 	resp, err := r.DoHTTP(req)
 	if err != nil {
@@ -1103,6 +1121,12 @@ func (r *ProtocolIncus) ExecInstance(instanceName string, exec api.InstanceExecP
 		}
 	}
 
+	if exec.ExtraFDs > 0 {
+		if !r.HasExtension("instance_exec_extra_fds") {
+			return nil, fmt.Errorf("The server is missing the required \"instance_exec_extra_fds\" API extension")
+		}
+	}
+
 	var uri string
 
 	if r.IsAgent() {
@@ -1290,6 +1314,33 @@ func (r *ProtocolIncus) ExecInstance(instanceName string, exec api.InstanceExecP
 			waitConns++
 		}
 
+		// Handle additional file descriptors
+		extraDone := make(chan error)
+		for i := 0; i < exec.ExtraFDs; i++ {
+			secret := fds[strconv.Itoa(3+i)]
+			if secret == "" {
+				continue
+			}
+
+			conn, err := r.GetOperationWebsocket(opAPI.ID, secret)
+			if err != nil {
+				return nil, err
+			}
+
+			// Discard output from remote command if no writer supplied.
+			var writer io.Writer = io.Discard
+			if i < len(args.ExtraFDs) && args.ExtraFDs[i] != nil {
+				writer = args.ExtraFDs[i]
+			}
+
+			waitConns++
+			go func() {
+				err := <-ws.MirrorWrite(conn, writer)
+				_ = conn.Close()
+				extraDone <- err
+			}()
+		}
+
 		// Wait for everything to be done
 		go func() {
 			for {
@@ -1307,6 +1358,8 @@ func (r *ProtocolIncus) ExecInstance(instanceName string, exec api.InstanceExecP
 					dones[2] = nil
 					_ = conns[2].Close()
 					waitConns--
+				case <-extraDone:
+					waitConns--
 				}
 
 				if waitConns <= 0 {
@@ -1330,6 +1383,20 @@ func (r *ProtocolIncus) ExecInstance(instanceName string, exec api.InstanceExecP
 
 // GetInstanceFile retrieves the provided path from the instance.
 func (r *ProtocolIncus) GetInstanceFile(instanceName string, filePath string) (io.ReadCloser, *InstanceFileResponse, error) {
+	return r.getInstanceFile(instanceName, filePath, false)
+}
+
+// GetInstanceFileSparse retrieves the provided path from the instance, returning files as a sparse stream
+// to be written with util.SparseCopy so that their holes are preserved.
+func (r *ProtocolIncus) GetInstanceFileSparse(instanceName string, filePath string) (io.ReadCloser, *InstanceFileResponse, error) {
+	if !r.HasExtension("instance_file_sparse") {
+		return nil, nil, fmt.Errorf("The server is missing the required \"instance_file_sparse\" API extension")
+	}
+
+	return r.getInstanceFile(instanceName, filePath, true)
+}
+
+func (r *ProtocolIncus) getInstanceFile(instanceName string, filePath string, sparse bool) (io.ReadCloser, *InstanceFileResponse, error) {
 	var err error
 	var requestURL string
 
@@ -1380,6 +1447,10 @@ func (r *ProtocolIncus) GetInstanceFile(instanceName string, filePath string) (i
 		return nil, nil, err
 	}
 
+	if sparse {
+		req.Header.Set("X-Incus-sparse", "true")
+	}
+
 	// Send the request
 	resp, err := r.DoHTTP(req)
 	if err != nil {
@@ -1448,6 +1519,18 @@ func (r *ProtocolIncus) CreateInstanceFile(instanceName string, filePath string,
 		}
 	}
 
+	if len(args.Xattrs) > 0 {
+		if !r.HasExtension("instance_file_xattrs") {
+			return fmt.Errorf("The server is missing the required \"instance_file_xattrs\" API extension")
+		}
+	}
+
+	if args.Sparse {
+		if !r.HasExtension("instance_file_sparse") {
+			return fmt.Errorf("The server is missing the required \"instance_file_sparse\" API extension")
+		}
+	}
+
 	var requestURL string
 
 	if r.IsAgent() {
@@ -1467,7 +1550,12 @@ func (r *ProtocolIncus) CreateInstanceFile(instanceName string, filePath string,
 		return err
 	}
 
-	req, err := http.NewRequest("POST", requestURL, args.Content)
+	var content io.Reader = args.Content
+	if args.Sparse {
+		content = util.NewSparseReader(args.Content)
+	}
+
+	req, err := http.NewRequest("POST", requestURL, content)
 	if err != nil {
 		return err
 	}
@@ -1493,6 +1581,14 @@ func (r *ProtocolIncus) CreateInstanceFile(instanceName string, filePath string,
 		req.Header.Set("X-Incus-write", args.WriteMode)
 	}
 
+	for name, value := range args.Xattrs {
+		req.Header.Add("X-Incus-xattr", fmt.Sprintf("%s=%s", name, base64.StdEncoding.EncodeToString([]byte(value))))
+	}
+
+	if args.Sparse {
+		req.Header.Set("X-Incus-sparse", "true")
+	}
+
 	// Send the request
 	resp, err := r.DoHTTP(req)
 	if err != nil {
@@ -1957,6 +2053,10 @@ func (r *ProtocolIncus) RenameInstanceSnapshot(instanceName string, name string,
 		return nil, fmt.Errorf("Can't ask for a migration through RenameInstanceSnapshot")
 	}
 
+	if instance.Promote {
+		return nil, fmt.Errorf("Can't ask for a promotion through RenameInstanceSnapshot")
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/snapshots/%s", path, url.PathEscape(instanceName), url.PathEscape(name)), instance, "")
 	if err != nil {
@@ -1966,6 +2066,32 @@ func (r *ProtocolIncus) RenameInstanceSnapshot(instanceName string, name string,
 	return op, nil
 }
 
+// PromoteInstanceSnapshot creates a new standalone instance from the snapshot.
+// The instance is created on the member holding the snapshot unless a target is set with UseTarget.
+func (r *ProtocolIncus) PromoteInstanceSnapshot(instanceName string, name string, newName string) (Operation, error) {
+	if !r.HasExtension("instance_snapshot_promote") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_snapshot_promote\" API extension")
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	req := api.InstanceSnapshotPost{
+		Name:    newName,
+		Promote: true,
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/snapshots/%s", path, url.PathEscape(instanceName), url.PathEscape(name)), req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 func (r *ProtocolIncus) tryMigrateInstanceSnapshot(source InstanceServer, instanceName string, name string, req api.InstanceSnapshotPost, urls []string) (RemoteOperation, error) {
 	if len(urls) == 0 {
 		return nil, fmt.Errorf("The target server isn't listening on the network")
@@ -2434,6 +2560,10 @@ func (r *ProtocolIncus) ConsoleInstance(instanceName string, console api.Instanc
 		return nil, fmt.Errorf("The server is missing the required \"console_vga_type\" API extension")
 	}
 
+	if console.ReadOnly && !r.HasExtension("console_read_only") {
+		return nil, fmt.Errorf("The server is missing the required \"console_read_only\" API extension")
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/console", path, url.PathEscape(instanceName)), console, "")
 	if err != nil {
@@ -2757,6 +2887,46 @@ func (r *ProtocolIncus) RenameInstanceBackup(instanceName string, name string, b
 	return op, nil
 }
 
+// VerifyInstanceBackup requests that Incus verifies the integrity of the instance backup.
+func (r *ProtocolIncus) VerifyInstanceBackup(instanceName string, name string) (Operation, error) {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.HasExtension("instance_backup_verify") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_backup_verify\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/backups/%s/verify", path, url.PathEscape(instanceName), url.PathEscape(name)), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// RestoreInstanceBackup requests that Incus restores the configuration and/or data of an instance from one of its backups.
+func (r *ProtocolIncus) RestoreInstanceBackup(instanceName string, name string, restore api.InstanceBackupRestorePost) (Operation, error) {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.HasExtension("instance_backup_partial_restore") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_backup_partial_restore\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/backups/%s/restore", path, url.PathEscape(instanceName), url.PathEscape(name)), restore, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 // DeleteInstanceBackup requests that Incus deletes the instance backup.
 func (r *ProtocolIncus) DeleteInstanceBackup(instanceName string, name string) (Operation, error) {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
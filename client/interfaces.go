@@ -126,6 +126,7 @@ type InstanceServer interface {
 	DeleteInstanceConsoleLog(instanceName string, args *InstanceConsoleLogArgs) (err error)
 
 	GetInstanceFile(instanceName string, path string) (content io.ReadCloser, resp *InstanceFileResponse, err error)
+	GetInstanceFileSparse(instanceName string, path string) (content io.ReadCloser, resp *InstanceFileResponse, err error)
 	CreateInstanceFile(instanceName string, path string, args InstanceFileArgs) (err error)
 	DeleteInstanceFile(instanceName string, path string) (err error)
 
@@ -139,6 +140,7 @@ type InstanceServer interface {
 	CopyInstanceSnapshot(source InstanceServer, instanceName string, snapshot api.InstanceSnapshot, args *InstanceSnapshotCopyArgs) (op RemoteOperation, err error)
 	RenameInstanceSnapshot(instanceName string, name string, instance api.InstanceSnapshotPost) (op Operation, err error)
 	MigrateInstanceSnapshot(instanceName string, name string, instance api.InstanceSnapshotPost) (op Operation, err error)
+	PromoteInstanceSnapshot(instanceName string, name string, newName string) (op Operation, err error)
 	DeleteInstanceSnapshot(instanceName string, name string) (op Operation, err error)
 	UpdateInstanceSnapshot(instanceName string, name string, instance api.InstanceSnapshotPut, ETag string) (op Operation, err error)
 
@@ -148,6 +150,8 @@ type InstanceServer interface {
 	CreateInstanceBackup(instanceName string, backup api.InstanceBackupsPost) (op Operation, err error)
 	RenameInstanceBackup(instanceName string, name string, backup api.InstanceBackupPost) (op Operation, err error)
 	DeleteInstanceBackup(instanceName string, name string) (op Operation, err error)
+	VerifyInstanceBackup(instanceName string, name string) (op Operation, err error)
+	RestoreInstanceBackup(instanceName string, name string, restore api.InstanceBackupRestorePost) (op Operation, err error)
 	GetInstanceBackupFile(instanceName string, name string, req *BackupFileRequest) (resp *BackupFileResponse, err error)
 	CreateInstanceFromBackup(args InstanceBackupArgs) (op Operation, err error)
 
@@ -212,6 +216,7 @@ type InstanceServer interface {
 	CreateNetworkLoadBalancer(networkName string, forward api.NetworkLoadBalancersPost) error
 	UpdateNetworkLoadBalancer(networkName string, listenAddress string, forward api.NetworkLoadBalancerPut, ETag string) (err error)
 	DeleteNetworkLoadBalancer(networkName string, listenAddress string) (err error)
+	GetNetworkLoadBalancerState(networkName string, listenAddress string) (*api.NetworkLoadBalancerState, error)
 
 	// Network peer functions ("network_peer" API extension)
 	GetNetworkPeerNames(networkName string) ([]string, error)
@@ -363,6 +368,8 @@ type InstanceServer interface {
 	GetClusterMembers() (members []api.ClusterMember, err error)
 	GetClusterMember(name string) (member *api.ClusterMember, ETag string, err error)
 	UpdateClusterMember(name string, member api.ClusterMemberPut, ETag string) (err error)
+	UpdateClusterMembers(members map[string]api.ClusterMemberPut) (err error)
+	PreviewClusterMemberUpdate(name string, member api.ClusterMemberPut, ETag string) (preview *api.ClusterMemberPutPreview, err error)
 	RenameClusterMember(name string, member api.ClusterMemberPost) (err error)
 	CreateClusterMember(member api.ClusterMembersPost) (op Operation, err error)
 	UpdateClusterCertificate(certs api.ClusterCertificatePut, ETag string) (err error)
@@ -542,6 +549,9 @@ type InstanceBackupArgs struct {
 
 	// Name to import backup as
 	Name string
+
+	// Whether to restore the instance on a cluster member equivalent to the one it was backed up from
+	RestorePlacement bool
 }
 
 // The InstanceCopyArgs struct is used to pass additional options during instance copy.
@@ -608,6 +618,9 @@ type InstanceExecArgs struct {
 	// Standard error
 	Stderr io.Writer
 
+	// Additional file descriptors requested through ExtraFDs (starting at fd 3)
+	ExtraFDs []io.Writer
+
 	// Control message handler (window resize, signals, ...)
 	Control func(conn *websocket.Conn)
 
@@ -634,6 +647,16 @@ type InstanceFileArgs struct {
 
 	// File write mode (overwrite or append)
 	WriteMode string
+
+	// Extended attributes to set on the file
+	//
+	// API extension: instance_file_xattrs
+	Xattrs map[string]string
+
+	// Only transfer the non-empty parts of the file, preserving its holes (requires the overwrite write mode)
+	//
+	// API extension: instance_file_sparse
+	Sparse bool
 }
 
 // The InstanceFileResponse struct is used as part of the response for a instance file download.
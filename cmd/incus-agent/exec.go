@@ -288,7 +288,7 @@ func (s *execWs) Do(op *operations.Operation) error {
 	waitAttachedChildIsDead, markAttachedChildIsDead := context.WithCancel(context.Background())
 	var wgEOF sync.WaitGroup
 
-	finisher := func(cmdResult int, cmdErr error) error {
+	finisher := func(cmdResult int, cmdSignal unix.Signal, cmdErr error) error {
 		// Cancel this before closing the control connection so control handler can detect command ending.
 		markAttachedChildIsDead()
 
@@ -310,7 +310,7 @@ func (s *execWs) Do(op *operations.Operation) error {
 			_ = pty.Close()
 		}
 
-		metadata := jmap.Map{"return": cmdResult}
+		metadata := jmap.Map{"return": cmdResult, "signal": int(cmdSignal)}
 		err = op.UpdateMetadata(metadata)
 		if err != nil {
 			return err
@@ -366,7 +366,7 @@ func (s *execWs) Do(op *operations.Operation) error {
 			exitStatus = 126
 		}
 
-		return finisher(exitStatus, err)
+		return finisher(exitStatus, 0, err)
 	}
 
 	l := logger.AddContext(logger.Ctx{"PID": cmd.Process.Pid, "interactive": s.interactive})
@@ -501,8 +501,10 @@ func (s *execWs) Do(op *operations.Operation) error {
 		}
 	}
 
-	exitStatus, err := linux.ExitStatus(cmd.Wait())
+	waitErr := cmd.Wait()
+	exitStatus, err := linux.ExitStatus(waitErr)
+	exitSignal := linux.ExitSignal(waitErr)
 
-	l.Debug("Instance process stopped", logger.Ctx{"err": err, "exitStatus": exitStatus})
-	return finisher(exitStatus, nil)
+	l.Debug("Instance process stopped", logger.Ctx{"err": err, "exitStatus": exitStatus, "signal": exitSignal})
+	return finisher(exitStatus, exitSignal, nil)
 }
@@ -164,8 +164,19 @@ func (c *cmdAdminInit) Run(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf(i18n.G("Invalid cluster join token: %w"), err)
 		}
 
-		// Set server name from join token
-		config.Cluster.ServerName = joinToken.ServerName
+		// Set server name from join token, tokens bound to a name pattern need it to be provided instead.
+		if joinToken.ServerName != "" {
+			config.Cluster.ServerName = joinToken.ServerName
+		} else {
+			match, err := internalUtil.JoinTokenServerNameMatch(joinToken.ServerNamePattern, config.Cluster.ServerName)
+			if err != nil {
+				return err
+			}
+
+			if !match {
+				return fmt.Errorf(i18n.G("Member name %q doesn't match the join token's pattern %q"), config.Cluster.ServerName, joinToken.ServerNamePattern)
+			}
+		}
 
 		// Attempt to find a working cluster member to use for joining by retrieving the
 		// cluster certificate from each address in the join token until we succeed.
@@ -187,8 +187,24 @@ func (c *cmdAdminInit) askClustering(config *api.InitPreseed, d incus.InstanceSe
 				return err
 			}
 
-			// Set server name from join token
-			config.Cluster.ServerName = joinToken.ServerName
+			// Set server name from join token, or ask for one matching the token's pattern.
+			if joinToken.ServerName != "" {
+				config.Cluster.ServerName = joinToken.ServerName
+			} else {
+				err = askForServerName()
+				if err != nil {
+					return err
+				}
+
+				match, err := internalUtil.JoinTokenServerNameMatch(joinToken.ServerNamePattern, config.Cluster.ServerName)
+				if err != nil {
+					return err
+				}
+
+				if !match {
+					return fmt.Errorf(i18n.G("Member name %q doesn't match the join token's pattern %q"), config.Cluster.ServerName, joinToken.ServerNamePattern)
+				}
+			}
 
 			// Attempt to find a working cluster member to use for joining by retrieving the
 			// cluster certificate from each address in the join token until we succeed.
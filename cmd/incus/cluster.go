@@ -94,6 +94,14 @@ func (c *cmdCluster) Command() *cobra.Command {
 	cmdClusterRestore := cmdClusterRestore{global: c.global, cluster: c}
 	cmd.AddCommand(cmdClusterRestore.Command())
 
+	// Drain
+	cmdClusterDrain := cmdClusterDrain{global: c.global, cluster: c}
+	cmd.AddCommand(cmdClusterDrain.Command())
+
+	// Uncordon
+	cmdClusterUncordon := cmdClusterUncordon{global: c.global, cluster: c}
+	cmd.AddCommand(cmdClusterUncordon.Command())
+
 	clusterGroupCmd := cmdClusterGroup{global: c.global, cluster: c}
 	cmd.AddCommand(clusterGroupCmd.Command())
 
@@ -882,13 +890,25 @@ func (c *cmdClusterEdit) Run(cmd *cobra.Command, args []string) error {
 type cmdClusterAdd struct {
 	global  *cmdGlobal
 	cluster *cmdCluster
+
+	flagNamePattern string
 }
 
 func (c *cmdClusterAdd) Command() *cobra.Command {
 	cmd := &cobra.Command{}
 	cmd.Use = usage("add", i18n.G("[[<remote>:]<member>]"))
 	cmd.Short = i18n.G("Request a join token for adding a cluster member")
-	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Request a join token for adding a cluster member`))
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Request a join token for adding a cluster member
+
+Instead of a member name, a regular expression can be provided with --name-pattern.
+The token can then be used by a member whose name matches that pattern.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus cluster add server02
+    Request a join token for the member server02.
+
+incus cluster add --name-pattern "web-[0-9]+"
+    Request a join token for any member named web- followed by a number.`))
+
+	cmd.Flags().StringVar(&c.flagNamePattern, "name-pattern", "", i18n.G("Regular expression the member name must match")+"``")
 
 	cmd.RunE = c.Run
 
@@ -919,13 +939,18 @@ func (c *cmdClusterAdd) Run(cmd *cobra.Command, args []string) error {
 	resource := resources[0]
 
 	// Determine the machine name.
-	if resource.name == "" {
+	if c.flagNamePattern != "" {
+		if resource.name != "" {
+			return fmt.Errorf(i18n.G("A cluster member name can't be provided along with a name pattern"))
+		}
+	} else if resource.name == "" {
 		return fmt.Errorf(i18n.G("A cluster member name must be provided"))
 	}
 
 	// Request the join token.
 	member := api.ClusterMembersPost{
-		ServerName: resource.name,
+		ServerName:        resource.name,
+		ServerNamePattern: c.flagNamePattern,
 	}
 
 	op, err := resource.server.CreateClusterMember(member)
@@ -940,7 +965,11 @@ func (c *cmdClusterAdd) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if !c.global.flagQuiet {
-		fmt.Printf(i18n.G("Member %s join token:")+"\n", resource.name)
+		if c.flagNamePattern != "" {
+			fmt.Printf(i18n.G("Join token for members matching %q:")+"\n", c.flagNamePattern)
+		} else {
+			fmt.Printf(i18n.G("Member %s join token:")+"\n", resource.name)
+		}
 	}
 
 	fmt.Println(joinToken.String())
@@ -1035,8 +1064,14 @@ func (c *cmdClusterListTokens) Run(cmd *cobra.Command, args []string) error {
 			continue // Operation is not a valid cluster member join token operation.
 		}
 
+		// Show the pattern for tokens which aren't bound to a single name.
+		serverName := joinToken.ServerName
+		if joinToken.ServerNamePattern != "" {
+			serverName = "~" + joinToken.ServerNamePattern
+		}
+
 		displayTokens = append(displayTokens, displayToken{
-			ServerName: joinToken.ServerName,
+			ServerName: serverName,
 			Token:      joinToken.String(),
 			ExpiresAt:  joinToken.ExpiresAt.Local().Format(dateLayout),
 		})
@@ -1263,8 +1298,12 @@ func (c *cmdClusterUpdateCertificate) Run(cmd *cobra.Command, args []string) err
 type cmdClusterEvacuateAction struct {
 	global *cmdGlobal
 
-	flagAction string
-	flagForce  bool
+	flagAction      string
+	flagForce       bool
+	flagDryRun      bool
+	flagParallel    int
+	flagStopOnError bool
+	flagTarget      []string
 }
 
 // Cluster member evacuation.
@@ -1285,6 +1324,10 @@ func (c *cmdClusterEvacuate) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Evacuate cluster member`))
 
 	cmd.Flags().StringVar(&c.action.flagAction, "action", "", i18n.G(`Force a particular evacuation action`)+"``")
+	cmd.Flags().BoolVar(&c.action.flagDryRun, "dry-run", false, i18n.G("Only show where the instances would be moved to"))
+	cmd.Flags().IntVar(&c.action.flagParallel, "parallel", 1, i18n.G("Number of instances to evacuate at the same time")+"``")
+	cmd.Flags().BoolVar(&c.action.flagStopOnError, "stop-on-error", false, i18n.G("Stop evacuating further instances as soon as one fails"))
+	cmd.Flags().StringArrayVar(&c.action.flagTarget, "target", nil, i18n.G("Cluster member to move the instances to (can be repeated)")+"``")
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
@@ -1324,6 +1367,64 @@ func (c *cmdClusterRestore) Command() *cobra.Command {
 	return cmd
 }
 
+// Cluster member drain.
+type cmdClusterDrain struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+	action  *cmdClusterEvacuateAction
+}
+
+func (c *cmdClusterDrain) Command() *cobra.Command {
+	cmdAction := cmdClusterEvacuateAction{global: c.global}
+	c.action = &cmdAction
+
+	cmd := c.action.Command("drain")
+	cmd.Use = usage("drain", i18n.G("[<remote>:]<member>"))
+	cmd.Short = i18n.G("Drain cluster member")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Drain cluster member
+
+No new instances are placed on a drained cluster member, its existing instances are left running.`))
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpClusterMembers(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Cluster member uncordon.
+type cmdClusterUncordon struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+	action  *cmdClusterEvacuateAction
+}
+
+func (c *cmdClusterUncordon) Command() *cobra.Command {
+	cmdAction := cmdClusterEvacuateAction{global: c.global}
+	c.action = &cmdAction
+
+	cmd := c.action.Command("uncordon")
+	cmd.Use = usage("uncordon", i18n.G("[<remote>:]<member>"))
+	cmd.Short = i18n.G("Uncordon cluster member")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Uncordon cluster member
+
+Allow new instances to be placed on a drained cluster member again.`))
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpClusterMembers(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
 func (c *cmdClusterEvacuateAction) Command(action string) *cobra.Command {
 	cmd := &cobra.Command{}
 	cmd.RunE = c.Run
@@ -1351,7 +1452,7 @@ func (c *cmdClusterEvacuateAction) Run(cmd *cobra.Command, args []string) error
 		return fmt.Errorf(i18n.G("Missing cluster member name"))
 	}
 
-	if !c.flagForce {
+	if !c.flagForce && !c.flagDryRun {
 		evacuate, err := c.global.asker.AskBool(fmt.Sprintf(i18n.G("Are you sure you want to %s cluster member %q? (yes/no) [default=no]: "), cmd.Name(), resource.name), "no")
 		if err != nil {
 			return err
@@ -1363,8 +1464,12 @@ func (c *cmdClusterEvacuateAction) Run(cmd *cobra.Command, args []string) error
 	}
 
 	state := api.ClusterMemberStatePost{
-		Action: cmd.Name(),
-		Mode:   c.flagAction,
+		Action:      cmd.Name(),
+		Mode:        c.flagAction,
+		DryRun:      c.flagDryRun,
+		Parallel:    c.flagParallel,
+		StopOnError: c.flagStopOnError,
+		Targets:     c.flagTarget,
 	}
 
 	op, err := resource.server.UpdateClusterMemberState(resource.name, state)
@@ -1376,6 +1481,12 @@ func (c *cmdClusterEvacuateAction) Run(cmd *cobra.Command, args []string) error
 
 	if cmd.Name() == "restore" {
 		format = i18n.G("Restoring cluster member: %s")
+	} else if cmd.Name() == "drain" {
+		format = i18n.G("Draining cluster member: %s")
+	} else if cmd.Name() == "uncordon" {
+		format = i18n.G("Uncordoning cluster member: %s")
+	} else if c.flagDryRun {
+		format = i18n.G("Planning cluster member evacuation: %s")
 	} else {
 		format = i18n.G("Evacuating cluster member: %s")
 	}
@@ -1398,5 +1509,43 @@ func (c *cmdClusterEvacuateAction) Run(cmd *cobra.Command, args []string) error
 	}
 
 	progress.Done("")
+
+	if c.flagDryRun {
+		return c.renderPlan(op.Get().Metadata)
+	}
+
 	return nil
 }
+
+// renderPlan shows what an evacuation would do to each instance.
+func (c *cmdClusterEvacuateAction) renderPlan(metadata map[string]any) error {
+	entries, _ := metadata["evacuation_plan"].([]any)
+
+	data := [][]string{}
+	for _, entry := range entries {
+		fields, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		row := []string{}
+		for _, key := range []string{"project", "name", "action", "target", "reason"} {
+			value, _ := fields[key].(string)
+			row = append(row, value)
+		}
+
+		data = append(data, row)
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("PROJECT"),
+		i18n.G("NAME"),
+		i18n.G("ACTION"),
+		i18n.G("TARGET"),
+		i18n.G("REASON"),
+	}
+
+	return cli.RenderTable(cli.TableFormatTable, header, data, entries)
+}
@@ -26,8 +26,9 @@ import (
 type cmdConsole struct {
 	global *cmdGlobal
 
-	flagShowLog bool
-	flagType    string
+	flagShowLog  bool
+	flagType     string
+	flagReadOnly bool
 }
 
 func (c *cmdConsole) Command() *cobra.Command {
@@ -42,6 +43,7 @@ as well as retrieve past log entries from it.`))
 
 	cmd.RunE = c.Run
 	cmd.Flags().BoolVar(&c.flagShowLog, "show-log", false, i18n.G("Retrieve the instance's console log"))
+	cmd.Flags().BoolVar(&c.flagReadOnly, "read-only", false, i18n.G("Watch the console without sending any input"))
 	cmd.Flags().StringVarP(&c.flagType, "type", "t", "console", i18n.G("Type of connection to establish: 'console' for serial console, 'vga' for SPICE graphical output")+"``")
 
 	return cmd
@@ -55,7 +57,7 @@ func (c *cmdConsole) sendTermSize(control *websocket.Conn) error {
 
 	logger.Debugf("Window size is now: %dx%d", width, height)
 
-	msg := api.InstanceExecControl{}
+	msg := api.InstanceConsoleControl{}
 	msg.Command = "window-resize"
 	msg.Args = make(map[string]string)
 	msg.Args["width"] = strconv.Itoa(width)
@@ -109,6 +111,10 @@ func (c *cmdConsole) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf(i18n.G("Unknown output type %q"), c.flagType)
 	}
 
+	if c.flagReadOnly && c.flagType != "console" {
+		return fmt.Errorf(i18n.G("The --read-only flag is only supported by the 'console' output type"))
+	}
+
 	// Connect to the daemon.
 	remote, name, err := conf.ParseRemote(args[0])
 	if err != nil {
@@ -180,9 +186,10 @@ func (c *cmdConsole) console(d incus.InstanceServer, name string) error {
 
 	// Prepare the remote console
 	req := api.InstanceConsolePost{
-		Width:  width,
-		Height: height,
-		Type:   "console",
+		Width:    width,
+		Height:   height,
+		Type:     "console",
+		ReadOnly: c.flagReadOnly,
 	}
 
 	consoleDisconnect := make(chan bool)
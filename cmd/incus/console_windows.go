@@ -8,10 +8,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
 
 	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/termios"
 )
 
 func (c *cmdConsole) getTERM() (string, bool) {
@@ -19,13 +22,35 @@ func (c *cmdConsole) getTERM() (string, bool) {
 }
 
 func (c *cmdConsole) controlSocketHandler(control *websocket.Conn) {
-	// TODO: figure out what the equivalent of signal.SIGWINCH is on
-	// windows and use that; for now if you resize your terminal it just
-	// won't work quite correctly.
-	err := c.sendTermSize(control)
-	if err != nil {
-		fmt.Printf(i18n.G("Error setting term size %s")+"\n", err)
+	// Windows doesn't have an equivalent of SIGWINCH, so poll the terminal
+	// size instead and send it whenever it changes.
+	width, height, _ := termios.GetSize(int(os.Stdout.Fd()))
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		newWidth, newHeight, err := termios.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			fmt.Printf(i18n.G("Error setting term size %s")+"\n", err)
+			break
+		}
+
+		if newWidth == width && newHeight == height {
+			continue
+		}
+
+		width, height = newWidth, newHeight
+
+		err = c.sendTermSize(control)
+		if err != nil {
+			logger.Debugf("error setting term size %s", err)
+			break
+		}
 	}
+
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	_ = control.WriteMessage(websocket.CloseMessage, closeMsg)
 }
 
 func (c *cmdConsole) findCommand(name string) string {
@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/pkg/xattr"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh"
 
@@ -47,9 +48,31 @@ type cmdFile struct {
 
 	flagMkdir     bool
 	flagRecursive bool
+	flagXattrs    bool
+	flagSparse    bool
 }
 
-func fileGetWrapper(server incus.InstanceServer, inst string, path string) (buf io.ReadCloser, resp *incus.InstanceFileResponse, err error) {
+// fileGet retrieves a file from the instance, as a sparse stream if requested.
+func fileGet(server incus.InstanceServer, inst string, path string, sparse bool) (io.ReadCloser, *incus.InstanceFileResponse, error) {
+	if sparse {
+		return server.GetInstanceFileSparse(inst, path)
+	}
+
+	return server.GetInstanceFile(inst, path)
+}
+
+// fileWriteContent writes the retrieved file content to the local file, keeping the holes of sparse streams.
+func fileWriteContent(f *os.File, content io.ReadCloser, sparse bool, tracker *ioprogress.ProgressTracker) error {
+	if sparse {
+		_, err := util.SparseCopy(f, &ioprogress.ProgressReader{ReadCloser: content, Tracker: tracker})
+		return err
+	}
+
+	_, err := io.Copy(&ioprogress.ProgressWriter{WriteCloser: f, Tracker: tracker}, content)
+	return err
+}
+
+func fileGetWrapper(server incus.InstanceServer, inst string, path string, sparse bool) (buf io.ReadCloser, resp *incus.InstanceFileResponse, err error) {
 	// Signal handling
 	chSignal := make(chan os.Signal, 1)
 	signal.Notify(chSignal, os.Interrupt)
@@ -57,7 +80,7 @@ func fileGetWrapper(server incus.InstanceServer, inst string, path string) (buf
 	// Operation handling
 	chDone := make(chan bool)
 	go func() {
-		buf, resp, err = server.GetInstanceFile(inst, path)
+		buf, resp, err = fileGet(server, inst, path, sparse)
 		close(chDone)
 	}()
 
@@ -434,6 +457,7 @@ func (c *cmdFilePull) Command() *cobra.Command {
 
 	cmd.Flags().BoolVarP(&c.file.flagMkdir, "create-dirs", "p", false, i18n.G("Create any directories necessary"))
 	cmd.Flags().BoolVarP(&c.file.flagRecursive, "recursive", "r", false, i18n.G("Recursively transfer files"))
+	cmd.Flags().BoolVar(&c.file.flagSparse, "sparse", false, i18n.G("Only transfer the non-empty parts of the files, keeping them sparse"))
 	cmd.RunE = c.Run
 
 	return cmd
@@ -449,6 +473,9 @@ func (c *cmdFilePull) Run(cmd *cobra.Command, args []string) error {
 	// Determine the target
 	target := filepath.Clean(args[len(args)-1])
 
+	// Sparse streams can't be written to the standard output.
+	sparse := c.file.flagSparse && target != "-"
+
 	targetIsDir := false
 	sb, err := os.Stat(target)
 	if err != nil && !os.IsNotExist(err) {
@@ -494,7 +521,7 @@ func (c *cmdFilePull) Run(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf(i18n.G("Invalid source %s"), resource.name)
 		}
 
-		buf, resp, err := fileGetWrapper(resource.server, pathSpec[0], pathSpec[1])
+		buf, resp, err := fileGetWrapper(resource.server, pathSpec[0], pathSpec[1], sparse)
 		if err != nil {
 			return err
 		}
@@ -546,7 +573,7 @@ func (c *cmdFilePull) Run(cmd *cobra.Command, args []string) error {
 						newPath = filepath.Clean(filepath.Join(filepath.Dir(pathSpec[1]), newPath))
 					}
 
-					buf, resp, err = resource.server.GetInstanceFile(pathSpec[0], newPath)
+					buf, resp, err = fileGet(resource.server, pathSpec[0], newPath, sparse)
 					if err != nil {
 						return err
 					}
@@ -598,23 +625,20 @@ func (c *cmdFilePull) Run(cmd *cobra.Command, args []string) error {
 			Quiet:  c.global.flagQuiet,
 		}
 
-		writer := &ioprogress.ProgressWriter{
-			WriteCloser: f,
-			Tracker: &ioprogress.ProgressTracker{
-				Handler: func(bytesReceived int64, speed int64) {
-					if targetPath == "-" {
-						return
-					}
+		tracker := &ioprogress.ProgressTracker{
+			Handler: func(bytesReceived int64, speed int64) {
+				if targetPath == "-" {
+					return
+				}
 
-					progress.UpdateProgress(ioprogress.ProgressData{
-						Text: fmt.Sprintf("%s (%s/s)",
-							units.GetByteSizeString(bytesReceived, 2),
-							units.GetByteSizeString(speed, 2))})
-				},
+				progress.UpdateProgress(ioprogress.ProgressData{
+					Text: fmt.Sprintf("%s (%s/s)",
+						units.GetByteSizeString(bytesReceived, 2),
+						units.GetByteSizeString(speed, 2))})
 			},
 		}
 
-		_, err = io.Copy(writer, buf)
+		err = fileWriteContent(f, buf, sparse, tracker)
 		if err != nil {
 			progress.Done("")
 			return err
@@ -656,6 +680,8 @@ func (c *cmdFilePush) Command() *cobra.Command {
 	cmd.Flags().IntVar(&c.file.flagUID, "uid", -1, i18n.G("Set the file's uid on push")+"``")
 	cmd.Flags().IntVar(&c.file.flagGID, "gid", -1, i18n.G("Set the file's gid on push")+"``")
 	cmd.Flags().StringVar(&c.file.flagMode, "mode", "", i18n.G("Set the file's perms on push")+"``")
+	cmd.Flags().BoolVar(&c.file.flagXattrs, "xattrs", false, i18n.G("Preserve the files' extended attributes (only for running containers)"))
+	cmd.Flags().BoolVar(&c.file.flagSparse, "sparse", false, i18n.G("Only transfer the non-empty parts of the files, keeping them sparse"))
 	cmd.RunE = c.Run
 
 	return cmd
@@ -858,6 +884,14 @@ func (c *cmdFilePush) Run(cmd *cobra.Command, args []string) error {
 		}
 
 		args.Type = "file"
+		args.Sparse = c.file.flagSparse
+
+		if c.file.flagXattrs && f != os.Stdin {
+			args.Xattrs, err = fileXattrs(f)
+			if err != nil {
+				return fmt.Errorf(i18n.G("Failed reading extended attributes of %s: %w"), f.Name(), err)
+			}
+		}
 
 		fstat, err := f.Stat()
 		if err != nil {
@@ -895,7 +929,7 @@ func (c *cmdFilePush) Run(cmd *cobra.Command, args []string) error {
 }
 
 func (c *cmdFile) recursivePullFile(d incus.InstanceServer, inst string, p string, targetDir string) error {
-	buf, resp, err := d.GetInstanceFile(inst, p)
+	buf, resp, err := fileGet(d, inst, p, c.flagSparse)
 	if err != nil {
 		return err
 	}
@@ -935,19 +969,16 @@ func (c *cmdFile) recursivePullFile(d incus.InstanceServer, inst string, p strin
 			Quiet:  c.global.flagQuiet,
 		}
 
-		writer := &ioprogress.ProgressWriter{
-			WriteCloser: f,
-			Tracker: &ioprogress.ProgressTracker{
-				Handler: func(bytesReceived int64, speed int64) {
-					progress.UpdateProgress(ioprogress.ProgressData{
-						Text: fmt.Sprintf("%s (%s/s)",
-							units.GetByteSizeString(bytesReceived, 2),
-							units.GetByteSizeString(speed, 2))})
-				},
+		tracker := &ioprogress.ProgressTracker{
+			Handler: func(bytesReceived int64, speed int64) {
+				progress.UpdateProgress(ioprogress.ProgressData{
+					Text: fmt.Sprintf("%s (%s/s)",
+						units.GetByteSizeString(bytesReceived, 2),
+						units.GetByteSizeString(speed, 2))})
 			},
 		}
 
-		_, err = io.Copy(writer, buf)
+		err = fileWriteContent(f, buf, c.flagSparse, tracker)
 		if err != nil {
 			progress.Done("")
 			return err
@@ -977,6 +1008,31 @@ func (c *cmdFile) recursivePullFile(d incus.InstanceServer, inst string, p strin
 	return nil
 }
 
+// fileXattrs returns the extended attributes of the local file which can be set in the container.
+// Only user attributes and file capabilities are kept, the server rejects the others.
+func fileXattrs(f *os.File) (map[string]string, error) {
+	names, err := xattr.FList(f)
+	if err != nil {
+		return nil, err
+	}
+
+	xattrs := make(map[string]string, len(names))
+	for _, name := range names {
+		if name != "security.capability" && !strings.HasPrefix(name, "user.") {
+			continue
+		}
+
+		value, err := xattr.FGet(f, name)
+		if err != nil {
+			return nil, err
+		}
+
+		xattrs[name] = string(value)
+	}
+
+	return xattrs, nil
+}
+
 func (c *cmdFile) recursivePushFile(d incus.InstanceServer, inst string, source string, target string) error {
 	source = filepath.Clean(source)
 	sourceDir, _ := filepath.Split(source)
@@ -1027,7 +1083,15 @@ func (c *cmdFile) recursivePushFile(d incus.InstanceServer, inst string, source
 
 			args.Type = "file"
 			args.Content = f
+			args.Sparse = c.flagSparse
 			readCloser = f
+
+			if c.flagXattrs {
+				args.Xattrs, err = fileXattrs(f)
+				if err != nil {
+					return fmt.Errorf(i18n.G("Failed reading extended attributes of %s: %w"), p, err)
+				}
+			}
 		}
 
 		progress := cli.ProgressRenderer{
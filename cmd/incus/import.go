@@ -17,7 +17,8 @@ import (
 type cmdImport struct {
 	global *cmdGlobal
 
-	flagStorage string
+	flagStorage          string
+	flagRestorePlacement bool
 }
 
 func (c *cmdImport) Command() *cobra.Command {
@@ -32,6 +33,7 @@ func (c *cmdImport) Command() *cobra.Command {
 
 	cmd.RunE = c.Run
 	cmd.Flags().StringVarP(&c.flagStorage, "storage", "s", "", i18n.G("Storage pool name")+"``")
+	cmd.Flags().BoolVar(&c.flagRestorePlacement, "restore-placement", false, i18n.G("Restore the instance on a cluster member equivalent to the one it was backed up from"))
 
 	return cmd
 }
@@ -104,8 +106,9 @@ func (c *cmdImport) Run(cmd *cobra.Command, args []string) error {
 				},
 			},
 		},
-		PoolName: c.flagStorage,
-		Name:     instanceName,
+		PoolName:         c.flagStorage,
+		Name:             instanceName,
+		RestorePlacement: c.flagRestorePlacement,
 	}
 
 	op, err := resource.server.CreateInstanceFromBackup(createArgs)
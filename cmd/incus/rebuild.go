@@ -11,25 +11,34 @@ import (
 	"github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/shared/api"
 	config "github.com/lxc/incus/v6/shared/cliconfig"
+	"github.com/lxc/incus/v6/shared/termios"
 )
 
 // Rebuild.
 type cmdRebuild struct {
-	global    *cmdGlobal
-	flagEmpty bool
-	flagForce bool
+	global            *cmdGlobal
+	flagEmpty         bool
+	flagForce         bool
+	flagResetIdentity bool
+	flagYes           bool
 }
 
 func (c *cmdRebuild) Command() *cobra.Command {
 	cmd := &cobra.Command{}
-	cmd.Use = usage("rebuild", i18n.G("[<remote>:]<image> [<remote>:]<instance>"))
+	cmd.Use = usage("rebuild", i18n.G("[[<remote>:]<image>] [<remote>:]<instance>"))
 	cmd.Short = i18n.G("Rebuild instances")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Wipe the instance root disk and re-initialize. The original image is used to re-initialize the instance if a different image or --empty is not specified.`))
+		`Wipe the instance root disk and re-initialize. The original image is used to re-initialize the instance if a different image or --empty is not specified.
+
+The instance identity (UUID and MAC addresses) is preserved unless --reset-identity is specified.
+
+As rebuilding can't be undone, confirmation is asked for when running interactively, unless --yes or --force is specified.`))
 
 	cmd.RunE = c.Run
 	cmd.Flags().BoolVar(&c.flagEmpty, "empty", false, i18n.G("Rebuild as an empty instance"))
 	cmd.Flags().BoolVarP(&c.flagForce, "force", "f", false, i18n.G("If an instance is running, stop it and then rebuild it"))
+	cmd.Flags().BoolVar(&c.flagYes, "yes", false, i18n.G("Don't ask for confirmation before rebuilding"))
+	cmd.Flags().BoolVar(&c.flagResetIdentity, "reset-identity", false, i18n.G("Give the instance a new UUID and MAC addresses instead of preserving them"))
 
 	return cmd
 }
@@ -80,6 +89,18 @@ func (c *cmdRebuild) rebuild(conf *config.Config, args []string) error {
 		return err
 	}
 
+	// Ask for confirmation as the root disk can't be recovered.
+	if !c.flagYes && !c.flagForce && termios.IsTerminal(getStdinFd()) {
+		confirm, err := c.global.asker.AskBool(fmt.Sprintf(i18n.G("Rebuilding %s will wipe its root disk. Continue?")+" (yes/no) [default=no]: ", name), "no")
+		if err != nil {
+			return err
+		}
+
+		if !confirm {
+			return fmt.Errorf(i18n.G("User aborted rebuild operation"))
+		}
+	}
+
 	// If the instance is running, stop it first.
 	if c.flagForce && current.StatusCode == api.Running {
 		req := api.InstanceStatePut{
@@ -112,12 +133,19 @@ func (c *cmdRebuild) rebuild(conf *config.Config, args []string) error {
 
 	// Base request
 	req := api.InstanceRebuildPost{
-		Source: api.InstanceSource{},
+		Source:        api.InstanceSource{},
+		ResetIdentity: c.flagResetIdentity,
 	}
 
 	if !c.flagEmpty {
+		// Fall back to the image the instance was created from.
 		if image == "" && iremote == "" {
-			return fmt.Errorf(i18n.G("You need to specify an image name or use --empty"))
+			image = current.Config["volatile.base_image"]
+			if image == "" {
+				return fmt.Errorf(i18n.G("The instance has no recorded image, you need to specify an image name or use --empty"))
+			}
+
+			iremote = remote
 		}
 
 		iremote, image := guessImage(conf, d, remote, iremote, image)
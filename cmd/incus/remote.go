@@ -853,6 +853,7 @@ func (c *cmdRemoteRename) Run(cmd *cobra.Command, args []string) error {
 	rc.Global = false
 	conf.Remotes[args[1]] = rc
 	delete(conf.Remotes, args[0])
+	conf.RenameRemoteInGroups(args[0], args[1])
 
 	if conf.DefaultRemote == args[0] {
 		conf.DefaultRemote = args[1]
@@ -916,6 +917,7 @@ func (c *cmdRemoteRemove) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	delete(conf.Remotes, args[0])
+	conf.RemoveRemoteFromGroups(args[0])
 
 	_ = os.Remove(conf.ServerCertPath(args[0]))
 	_ = os.Remove(conf.CookiesPath(args[0]))
@@ -41,6 +41,10 @@ func (c *cmdSnapshot) Command() *cobra.Command {
 	snapshotListCmd := cmdSnapshotList{global: c.global, snapshot: c}
 	cmd.AddCommand(snapshotListCmd.Command())
 
+	// Promote.
+	snapshotPromoteCmd := cmdSnapshotPromote{global: c.global, snapshot: c}
+	cmd.AddCommand(snapshotPromoteCmd.Command())
+
 	// Rename.
 	snapshotRenameCmd := cmdSnapshotRename{global: c.global, snapshot: c}
 	cmd.AddCommand(snapshotRenameCmd.Command())
@@ -66,6 +70,7 @@ type cmdSnapshotCreate struct {
 
 	flagStateful bool
 	flagNoExpiry bool
+	flagExpiry   string
 	flagReuse    bool
 }
 
@@ -80,10 +85,14 @@ When --stateful is used, attempt to checkpoint the instance's
 running state, including process memory state, TCP connections, ...`))
 	cmd.Example = cli.FormatSection("", i18n.G(
 		`incus snapshot create u1 snap0
-    Create a snapshot of "u1" called "snap0".`))
+    Create a snapshot of "u1" called "snap0".
+
+incus snapshot create u1 pre-upgrade --expiry 7d
+    Create a snapshot of "u1" called "pre-upgrade" which expires in 7 days.`))
 
 	cmd.Flags().BoolVar(&c.flagStateful, "stateful", false, i18n.G("Whether or not to snapshot the instance's running state"))
 	cmd.Flags().BoolVar(&c.flagNoExpiry, "no-expiry", false, i18n.G("Ignore any configured auto-expiry for the instance"))
+	cmd.Flags().StringVar(&c.flagExpiry, "expiry", "", i18n.G("Expiry of the snapshot, overriding the instance's configured auto-expiry (e.g. 7d)")+"``")
 	cmd.Flags().BoolVar(&c.flagReuse, "reuse", false, i18n.G("If the snapshot name already exists, delete and create a new one"))
 
 	cmd.RunE = c.Run
@@ -108,6 +117,10 @@ func (c *cmdSnapshotCreate) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.flagNoExpiry && c.flagExpiry != "" {
+		return fmt.Errorf(i18n.G("--no-expiry can't be used with --expiry"))
+	}
+
 	var snapname string
 	if len(args) < 2 {
 		snapname = ""
@@ -157,6 +170,17 @@ func (c *cmdSnapshotCreate) Run(cmd *cobra.Command, args []string) error {
 
 	if c.flagNoExpiry {
 		req.ExpiresAt = &time.Time{}
+	} else if c.flagExpiry != "" {
+		expiry, err := instance.GetExpiry(time.Now(), c.flagExpiry)
+		if err != nil {
+			return err
+		}
+
+		if expiry.IsZero() {
+			return fmt.Errorf(i18n.G("Invalid expiry: %s"), c.flagExpiry)
+		}
+
+		req.ExpiresAt = &expiry
 	}
 
 	op, err := d.CreateInstanceSnapshot(name, req)
@@ -411,6 +435,78 @@ func (c *cmdSnapshotRename) Run(cmd *cobra.Command, args []string) error {
 	return op.Wait()
 }
 
+// Promote.
+type cmdSnapshotPromote struct {
+	global   *cmdGlobal
+	snapshot *cmdSnapshot
+
+	flagTarget string
+}
+
+func (c *cmdSnapshotPromote) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("promote", i18n.G("[<remote>:]<instance> <snapshot name> <new instance name>"))
+	cmd.Short = i18n.G("Create a new instance from an instance snapshot")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Create a new instance from an instance snapshot
+
+The new instance is independent from the one the snapshot belongs to and has no snapshots.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus snapshot promote u1 snap0 u2
+    Create a new instance "u2" from the snapshot "snap0" of "u1".`))
+
+	cmd.Flags().StringVar(&c.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpInstanceSnapshots(args[0])
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdSnapshotPromote) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 3, 3)
+	if exit {
+		return err
+	}
+
+	// Check the remotes
+	remote, instanceName, err := conf.ParseRemote(args[0])
+	if err != nil {
+		return err
+	}
+
+	d, err := conf.GetInstanceServer(remote)
+	if err != nil {
+		return err
+	}
+
+	if c.flagTarget != "" {
+		d = d.UseTarget(c.flagTarget)
+	}
+
+	// Snapshot promote
+	op, err := d.PromoteInstanceSnapshot(instanceName, args[1], args[2])
+	if err != nil {
+		return err
+	}
+
+	return op.Wait()
+}
+
 // Restore.
 type cmdSnapshotRestore struct {
 	global   *cmdGlobal
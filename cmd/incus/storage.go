@@ -515,6 +515,7 @@ func (c *cmdStorageInfo) Run(cmd *cobra.Command, args []string) error {
 	descriptionstring := i18n.G("description")
 	totalspacestring := i18n.G("total space")
 	spaceusedstring := i18n.G("space used")
+	overprovisioningstring := i18n.G("over-provisioning ratio")
 
 	// Initialize the usedby map
 	poolusedby[usedbystring] = make(map[string][]string)
@@ -597,6 +598,10 @@ func (c *cmdStorageInfo) Run(cmd *cobra.Command, args []string) error {
 		poolinfo[infostring][spaceusedstring] = units.GetByteSizeStringIEC(int64(res.Space.Used), 2)
 	}
 
+	if res.Space.OverProvisioningRatio > 0 {
+		poolinfo[infostring][overprovisioningstring] = strconv.FormatFloat(res.Space.OverProvisioningRatio, 'f', 2, 64)
+	}
+
 	poolinfodata, err := yaml.Marshal(poolinfo)
 	if err != nil {
 		return err
@@ -50,6 +50,8 @@ var api10 = []APIEndpoint{
 	clusterCertificateCmd,
 	instanceBackupCmd,
 	instanceBackupExportCmd,
+	instanceBackupVerifyCmd,
+	instanceBackupRestoreCmd,
 	instanceBackupsCmd,
 	instanceCmd,
 	instanceConsoleCmd,
@@ -90,6 +92,7 @@ var api10 = []APIEndpoint{
 	networkIntegrationsCmd,
 	networkLoadBalancerCmd,
 	networkLoadBalancersCmd,
+	networkLoadBalancerStateCmd,
 	networkPeerCmd,
 	networkPeersCmd,
 	networkZoneCmd,
@@ -783,6 +786,7 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 	acmeChanged := false
 	bgpChanged := false
 	dnsChanged := false
+	logCollectorChanged := false
 	lokiChanged := false
 	oidcChanged := false
 	openFGAChanged := false
@@ -795,7 +799,7 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 			acmeChanged = true
 
 		case "cluster.images_minimal_replica":
-			err := autoSyncImages(s.ShutdownCtx, s)
+			err := autoSyncImages(s.ShutdownCtx, s, nil)
 			if err != nil {
 				logger.Warn("Could not auto-sync images", logger.Ctx{"err": err})
 			}
@@ -818,6 +822,9 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 				d.taskPruneImages.Reset()
 			}
 
+		case "logging.collector.url", "logging.collector.loglevel":
+			logCollectorChanged = true
+
 		case "loki.api.url", "loki.auth.username", "loki.auth.password", "loki.api.ca_cert", "loki.instance", "loki.labels", "loki.loglevel", "loki.types":
 			lokiChanged = true
 
@@ -937,6 +944,15 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	if logCollectorChanged {
+		logCollectorURL, logCollectorLoglevel := clusterConfig.LoggingCollector()
+
+		err := d.setupLogCollector(logCollectorURL, logCollectorLoglevel)
+		if err != nil {
+			return err
+		}
+	}
+
 	if lokiChanged {
 		lokiURL, lokiUsername, lokiPassword, lokiCACert, lokiInstance, lokiLoglevel, lokiLabels, lokiTypes := clusterConfig.LokiServer()
 
@@ -37,6 +37,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/node"
 	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/scriptlet"
@@ -52,6 +53,7 @@ import (
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/osarch"
 	localtls "github.com/lxc/incus/v6/shared/tls"
+	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/validate"
 )
@@ -69,6 +71,12 @@ type evacuateOpts struct {
 	stopInstance    evacuateStopFunc
 	migrateInstance evacuateMigrateFunc
 	op              *operations.Operation
+	dryRun          bool
+	parallel        int
+	stopOnError     bool
+	targets         []string
+	targetResources map[string]*api.ClusterMemberResources
+	memberResources map[string]*api.Resources
 }
 
 var targetGroupPrefix = "@"
@@ -85,6 +93,7 @@ var clusterNodesCmd = APIEndpoint{
 
 	Get:  APIEndpointAction{Handler: clusterNodesGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanView)},
 	Post: APIEndpointAction{Handler: clusterNodesPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Put:  APIEndpointAction{Handler: clusterNodesPut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
 var clusterNodeCmd = APIEndpoint{
@@ -151,10 +160,17 @@ var internalClusterHandoverCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: internalClusterPostHandover, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+var internalClusterRaftNodesCmd = APIEndpoint{
+	Path: "cluster/raft-nodes",
+
+	Get: APIEndpointAction{Handler: internalClusterRaftNodesGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanView)},
+}
+
 var internalClusterRaftNodeCmd = APIEndpoint{
 	Path: "cluster/raft-node/{address}",
 
 	Delete: APIEndpointAction{Handler: internalClusterRaftNodeDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Put:    APIEndpointAction{Handler: internalClusterRaftNodePut, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
 var internalClusterHealCmd = APIEndpoint{
@@ -238,7 +254,46 @@ func clusterGet(d *Daemon, r *http.Request) response.Response {
 		MemberConfig: memberConfig,
 	}
 
-	return response.SyncResponseETag(true, cluster, cluster)
+	// The quorum health isn't part of the configuration, keep it out of the ETag.
+	etag := cluster
+
+	if cluster.Enabled {
+		cluster.Quorum, err = clusterGetQuorum(r.Context(), s)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	return response.SyncResponseETag(true, cluster, etag)
+}
+
+// clusterGetQuorum computes the health of the cluster database quorum from the raft
+// members and the last heartbeat of the cluster members.
+func clusterGetQuorum(ctx context.Context, s *state.State) (*api.ClusterQuorum, error) {
+	raftMembers, err := db.GetRaftMembers(ctx, s.DB.Node, s.DB.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading RAFT members: %w", err)
+	}
+
+	offlineThreshold := s.GlobalConfig.OfflineThreshold()
+
+	quorum := api.ClusterQuorum{}
+	for _, node := range raftMembers {
+		if node.Role != db.RaftVoter {
+			continue
+		}
+
+		quorum.Voters++
+
+		// Voters without a matching member record are considered offline.
+		if node.Member == nil || node.Member.IsOffline(offlineThreshold) {
+			quorum.OfflineVoters++
+		}
+	}
+
+	quorum.Healthy = quorum.Voters-quorum.OfflineVoters > quorum.Voters/2
+
+	return &quorum, nil
 }
 
 // Fetch information about all node-specific configuration keys set on the
@@ -489,6 +544,21 @@ func clusterPutJoin(d *Daemon, r *http.Request, req api.ClusterPut) response.Res
 		return response.BadRequest(fmt.Errorf("No server address provided for this member"))
 	}
 
+	// Check the server name early for join tokens bound to a name pattern, the cluster enforces it too.
+	if req.ClusterToken != "" {
+		joinToken, err := internalUtil.JoinTokenDecode(req.ClusterToken)
+		if err == nil && joinToken.ServerNamePattern != "" {
+			match, err := internalUtil.JoinTokenServerNameMatch(joinToken.ServerNamePattern, req.ServerName)
+			if err != nil {
+				return response.BadRequest(err)
+			}
+
+			if !match {
+				return response.BadRequest(fmt.Errorf("Server name %q doesn't match the join token's pattern %q", req.ServerName, joinToken.ServerNamePattern))
+			}
+		}
+	}
+
 	localHTTPSAddress := s.LocalConfig.HTTPSAddress()
 
 	var config *node.Config
@@ -623,11 +693,22 @@ func clusterPutJoin(d *Daemon, r *http.Request, req api.ClusterPut) response.Res
 
 		d.events.SetLocalLocation(d.serverName)
 
-		err = clusterInitMember(localClient, client, req.MemberConfig)
+		ignoredConfig, err := clusterInitMember(localClient, client, req.MemberConfig)
 		if err != nil {
+			// Expose the exact configuration problems to the joining client.
+			var configErr clusterMemberConfigError
+			if errors.As(err, &configErr) {
+				_ = op.UpdateMetadata(map[string]any{"invalid_member_config": configErr.keys, "ignored_member_config": ignoredConfig})
+			}
+
 			return fmt.Errorf("Failed to initialize member: %w", err)
 		}
 
+		// Let the joining client know about the member configuration which wasn't applied.
+		if len(ignoredConfig) > 0 {
+			_ = op.UpdateMetadata(map[string]any{"ignored_member_config": ignoredConfig})
+		}
+
 		// Get all defined storage pools and networks, so they can be compared to the ones in the cluster.
 		pools := []api.StoragePool{}
 		networks := []api.InitNetworksProjectPost{}
@@ -848,8 +929,8 @@ func clusterPutJoin(d *Daemon, r *http.Request, req api.ClusterPut) response.Res
 			logger.Warnf("Failed to trigger cluster rebalance: %v", err)
 		}
 
-		// Ensure all images are available after this node has joined.
-		err = autoSyncImages(s.ShutdownCtx, s)
+		// Ensure all images are available after this node has joined, only copying those it's missing.
+		err = autoSyncImages(s.ShutdownCtx, s, []string{localHTTPSAddress})
 		if err != nil {
 			logger.Warn("Failed to sync images")
 		}
@@ -959,20 +1040,54 @@ func clusterPutDisable(d *Daemon, r *http.Request, req api.ClusterPut) response.
 	})
 }
 
+// clusterMemberConfigError lists the required member configuration keys missing when joining a cluster.
+// Each entry has its description set to the reason it was reported.
+type clusterMemberConfigError struct {
+	keys []api.ClusterMemberConfigKey
+}
+
+// Error returns the list of problems as a single message.
+func (e clusterMemberConfigError) Error() string {
+	problems := make([]string, 0, len(e.keys))
+	for _, key := range e.keys {
+		problems = append(problems, fmt.Sprintf("Key %q for %s %q: %s", key.Key, key.Entity, key.Name, key.Description))
+	}
+
+	return fmt.Sprintf("Invalid member configuration: %s", strings.Join(problems, ", "))
+}
+
 // clusterInitMember initializes storage pools and networks on this member. We pass two client instances, one
 // connected to ourselves (the joining member) and one connected to the target cluster member to join.
-func clusterInitMember(d incus.InstanceServer, client incus.InstanceServer, memberConfig []api.ClusterMemberConfigKey) error {
+// The member configuration is checked before anything gets created. The keys which can't be applied are ignored and
+// returned along with the reason in their description, while missing required keys are all returned at once as a
+// clusterMemberConfigError.
+func clusterInitMember(d incus.InstanceServer, client incus.InstanceServer, memberConfig []api.ClusterMemberConfigKey) ([]api.ClusterMemberConfigKey, error) {
 	data := api.InitLocalPreseed{}
 
+	// Keep track of the configuration keys which can't be applied and of the missing ones.
+	ignoredConfig := []api.ClusterMemberConfigKey{}
+	ignoreKey := func(config api.ClusterMemberConfigKey, reason string) {
+		logger.Warn("Ignoring member config key", logger.Ctx{"entity": config.Entity, "name": config.Name, "key": config.Key, "reason": reason})
+		config.Description = reason
+		ignoredConfig = append(ignoredConfig, config)
+	}
+
+	missingConfig := []api.ClusterMemberConfigKey{}
+
+	var poolNames []string
+	networkNames := map[string][]string{}
+
 	// Fetch all pools currently defined in the cluster.
 	pools, err := client.GetStoragePools()
 	if err != nil {
-		return fmt.Errorf("Failed to fetch information about cluster storage pools: %w", err)
+		return nil, fmt.Errorf("Failed to fetch information about cluster storage pools: %w", err)
 	}
 
 	// Merge the returned storage pools configs with the node-specific
 	// configs provided by the user.
 	for _, pool := range pools {
+		poolNames = append(poolNames, pool.Name)
+
 		// Skip pending pools.
 		if pool.Status == "Pending" {
 			continue
@@ -1001,7 +1116,7 @@ func clusterInitMember(d incus.InstanceServer, client incus.InstanceServer, memb
 			}
 
 			if !slices.Contains(db.NodeSpecificStorageConfig, config.Key) {
-				logger.Warnf("Ignoring config key %q for storage pool %q", config.Key, config.Name)
+				ignoreKey(config, "Not a member specific key")
 				continue
 			}
 
@@ -1013,7 +1128,7 @@ func clusterInitMember(d incus.InstanceServer, client incus.InstanceServer, memb
 
 	projects, err := client.GetProjects()
 	if err != nil {
-		return fmt.Errorf("Failed to fetch project information about cluster networks: %w", err)
+		return nil, fmt.Errorf("Failed to fetch project information about cluster networks: %w", err)
 	}
 
 	for _, p := range projects {
@@ -1035,11 +1150,13 @@ func clusterInitMember(d incus.InstanceServer, client incus.InstanceServer, memb
 		// Fetch all project specific networks currently defined in the cluster for the project.
 		networks, err := client.UseProject(p.Name).GetNetworks()
 		if err != nil {
-			return fmt.Errorf("Failed to fetch network information about cluster networks in project %q: %w", p.Name, err)
+			return nil, fmt.Errorf("Failed to fetch network information about cluster networks in project %q: %w", p.Name, err)
 		}
 
 		// Merge the returned networks configs with the node-specific configs provided by the user.
 		for _, network := range networks {
+			networkNames[p.Name] = append(networkNames[p.Name], network.Name)
+
 			// Skip unmanaged or pending networks.
 			if !network.Managed || network.Status != api.NetworkStatusCreated {
 				continue
@@ -1067,24 +1184,66 @@ func clusterInitMember(d incus.InstanceServer, client incus.InstanceServer, memb
 					}
 
 					if !slices.Contains(db.NodeSpecificNetworkConfig, config.Key) {
-						logger.Warnf("Ignoring config key %q for network %q in project %q", config.Key, config.Name, p.Name)
+						ignoreKey(config, "Not a member specific key")
 						continue
 					}
 
 					post.Config[config.Key] = config.Value
 				}
+
+				// Networks on top of a host interface can't be created without one.
+				if slices.Contains([]string{"macvlan", "sriov", "physical"}, network.Type) && post.Config["parent"] == "" {
+					missingConfig = append(missingConfig, api.ClusterMemberConfigKey{Entity: "network", Name: network.Name, Key: "parent", Description: "Missing required key"})
+				}
 			}
 
 			data.Networks = append(data.Networks, post)
 		}
 	}
 
+	// Look for configuration keys targeting entities which don't exist in the cluster.
+	for _, config := range memberConfig {
+		switch config.Entity {
+		case "storage-pool":
+			if !slices.Contains(poolNames, config.Name) {
+				ignoreKey(config, "Storage pool doesn't exist")
+			}
+
+		case "network":
+			if slices.Contains(networkNames[api.ProjectDefaultName], config.Name) {
+				continue
+			}
+
+			// Networks of other projects don't have member specific configuration.
+			found := false
+			for _, names := range networkNames {
+				if slices.Contains(names, config.Name) {
+					found = true
+					break
+				}
+			}
+
+			if found {
+				ignoreKey(config, "Network isn't in the default project")
+			} else {
+				ignoreKey(config, "Network doesn't exist")
+			}
+
+		default:
+			ignoreKey(config, fmt.Sprintf("Unknown entity type %q", config.Entity))
+		}
+	}
+
+	if len(missingConfig) > 0 {
+		return ignoredConfig, clusterMemberConfigError{keys: missingConfig}
+	}
+
 	err = d.ApplyServerPreseed(api.InitPreseed{Server: data})
 	if err != nil {
-		return fmt.Errorf("Failed to initialize storage pools and networks: %w", err)
+		return ignoredConfig, fmt.Errorf("Failed to initialize storage pools and networks: %w", err)
 	}
 
-	return nil
+	return ignoredConfig, nil
 }
 
 // Perform a request to the /internal/cluster/accept endpoint to check if a new
@@ -1172,6 +1331,12 @@ func clusterAcceptMember(client incus.InstanceServer, name string, address strin
 //	---
 //	produces:
 //	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: resources
+//	    description: Include a summary of each member's resources and load
+//	    type: boolean
+//	    example: true
 //	responses:
 //	  "200":
 //	    description: API endpoints
@@ -1223,6 +1388,45 @@ func clusterNodesGet(d *Daemon, r *http.Request) response.Response {
 	}
 
 	var members []db.NodeInfo
+	var memberResources map[string]*api.ClusterMemberResources
+	if recursion && util.IsTrue(request.QueryParam(r, "resources")) {
+		var instanceCounts map[int64]int
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			members, err = tx.GetNodes(ctx)
+			if err != nil {
+				return fmt.Errorf("Failed getting cluster members: %w", err)
+			}
+
+			instanceCounts, err = tx.GetNodesInstanceCount(ctx)
+			if err != nil {
+				return fmt.Errorf("Failed getting instances count: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		// Only query the members which are reachable.
+		onlineMembers := make([]db.NodeInfo, 0, len(members))
+		for _, member := range members {
+			if member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+				continue
+			}
+
+			onlineMembers = append(onlineMembers, member)
+		}
+
+		memberResources = cluster.MembersResources(r.Context(), s, onlineMembers)
+		for _, member := range onlineMembers {
+			res, ok := memberResources[member.Name]
+			if ok {
+				res.Instances = instanceCounts[member.ID]
+			}
+		}
+	}
+
 	var membersInfo []api.ClusterMember
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		failureDomains, err := tx.GetFailureDomainsNames(ctx)
@@ -1252,6 +1456,7 @@ func clusterNodesGet(d *Daemon, r *http.Request) response.Response {
 			OfflineThreshold:     s.GlobalConfig.OfflineThreshold(),
 			MaxMemberVersion:     maxVersion,
 			RaftNodes:            raftNodes,
+			MemberResources:      memberResources,
 		}
 
 		if recursion {
@@ -1329,6 +1534,18 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("This server is not clustered"))
 	}
 
+	// Tokens are either bound to an exact name or to a name pattern.
+	if req.ServerNamePattern != "" {
+		if req.ServerName != "" {
+			return response.BadRequest(fmt.Errorf("A server name and a server name pattern can't both be provided"))
+		}
+
+		_, err = internalUtil.JoinTokenServerNameMatch(req.ServerNamePattern, "")
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
 	expiry, err := internalInstance.GetExpiry(time.Now(), s.GlobalConfig.ClusterJoinTokenExpiry())
 	if err != nil {
 		return response.BadRequest(err)
@@ -1389,7 +1606,9 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 			continue
 		}
 
-		if opServerName == req.ServerName {
+		// Tokens bound to a pattern are still single-use but several of them can be outstanding at once,
+		// so they are never considered duplicates.
+		if req.ServerName != "" && opServerName == req.ServerName {
 			// Join token operation matches requested server name, so lets cancel it.
 			logger.Warn("Cancelling duplicate join token operation", logger.Ctx{"operation": op.ID, "serverName": opServerName})
 			err = operationCancel(s, r, api.ProjectDefaultName, op)
@@ -1422,6 +1641,10 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 		"expiresAt":   expiry,
 	}
 
+	if req.ServerNamePattern != "" {
+		meta["serverNamePattern"] = req.ServerNamePattern // Validated against the name of the joining member instead.
+	}
+
 	resources := map[string][]api.URL{}
 	resources["cluster"] = []api.URL{}
 
@@ -1540,6 +1763,156 @@ func clusterNodeGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponseETag(true, memberInfo, memberInfo.ClusterMemberPut)
 }
 
+// swagger:operation PUT /1.0/cluster/members cluster cluster_members_put
+//
+//	Update several cluster members
+//
+//	Updates the entire configuration of several cluster members at once.
+//	All the updates are validated first and then applied in a single transaction,
+//	so either all of them succeed or none of them is applied.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: members
+//	    description: Cluster member configurations, indexed by member name
+//	    required: true
+//	    schema:
+//	      type: object
+//	      additionalProperties:
+//	        $ref: "#/definitions/ClusterMemberPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterNodesPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// Parse the request
+	req := map[string]api.ClusterMemberPut{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if len(req) == 0 {
+		return response.BadRequest(fmt.Errorf("No cluster member to update"))
+	}
+
+	leaderAddress, err := d.gateway.LeaderAddress()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	var raftNodes []db.RaftNode
+	err = s.DB.Node.Transaction(r.Context(), func(ctx context.Context, tx *db.NodeTx) error {
+		raftNodes, err = tx.GetRaftNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed loading RAFT nodes: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Process the members in a consistent order.
+	names := make([]string, 0, len(req))
+	for name := range req {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	rolesChanged := false
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		args, err := clusterNodeInfoArgs(ctx, tx, s, leaderAddress, raftNodes)
+		if err != nil {
+			return err
+		}
+
+		members := make([]db.NodeInfo, 0, len(names))
+		membersInfo := make([]*api.ClusterMember, 0, len(names))
+		membersReq := make([]api.ClusterMemberPut, 0, len(names))
+
+		// Validate all the updates before writing anything.
+		for _, name := range names {
+			member, err := tx.GetNodeByName(ctx, name)
+			if err != nil {
+				return fmt.Errorf("Failed loading cluster member %q: %w", name, err)
+			}
+
+			memberInfo, err := member.ToAPI(ctx, tx, *args)
+			if err != nil {
+				return err
+			}
+
+			memberReq := req[name]
+			err = clusterNodeValidateUpdate(memberInfo, memberReq)
+			if err != nil {
+				return api.StatusErrorf(http.StatusBadRequest, "Invalid update of cluster member %q: %v", name, err)
+			}
+
+			err = clusterNodeValidateConfig(ctx, tx, member, &memberReq, false)
+			if err != nil {
+				return api.StatusErrorf(http.StatusBadRequest, "Invalid configuration of cluster member %q: %v", name, err)
+			}
+
+			members = append(members, member)
+			membersInfo = append(membersInfo, memberInfo)
+			membersReq = append(membersReq, memberReq)
+		}
+
+		removedGroups := []string{}
+		for i, member := range members {
+			newRoles := clusterNodeRoles(membersReq[i])
+			if clusterRolesChanged(member.Roles, newRoles) {
+				rolesChanged = true
+			}
+
+			err = clusterNodeUpdate(ctx, tx, member, membersInfo[i], membersReq[i], newRoles)
+			if err != nil {
+				return fmt.Errorf("Failed updating cluster member %q: %w", member.Name, err)
+			}
+
+			_, memberRemovedGroups := clusterListChanges(membersInfo[i].Groups, membersReq[i].Groups)
+			removedGroups = append(removedGroups, memberRemovedGroups...)
+		}
+
+		// Don't leave groups used for instance placement without members.
+		return clusterGroupsCheckNotEmptied(ctx, tx, removedGroups)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// If cluster roles changed, then distribute the info to all members.
+	// The changes are already persisted, so members which couldn't be notified get them with the next heartbeat.
+	if s.Endpoints != nil && rolesChanged {
+		err = cluster.NotifyHeartbeat(s, d.gateway)
+		if err != nil {
+			logger.Warn("Cluster member roles were updated but not yet propagated to all members", logger.Ctx{"err": err})
+		}
+	}
+
+	requestor := request.CreateRequestor(r)
+	for _, name := range names {
+		s.Events.SendLifecycle(request.ProjectParam(r), lifecycle.ClusterMemberUpdated.Event(name, requestor, nil))
+	}
+
+	return response.EmptySyncResponse
+}
+
 // swagger:operation PATCH /1.0/cluster/members/{name} cluster cluster_member_patch
 //
 //	Partially update the cluster member
@@ -1552,6 +1925,11 @@ func clusterNodeGet(d *Daemon, r *http.Request) response.Response {
 //	produces:
 //	  - application/json
 //	parameters:
+//	  - in: query
+//	    name: dry-run
+//	    description: Only validate the request and return the changes it would make
+//	    type: boolean
+//	    example: true
 //	  - in: body
 //	    name: cluster
 //	    description: Cluster member configuration
@@ -1560,7 +1938,25 @@ func clusterNodeGet(d *Daemon, r *http.Request) response.Response {
 //	      $ref: "#/definitions/ClusterMemberPut"
 //	responses:
 //	  "200":
-//	    $ref: "#/responses/EmptySyncResponse"
+//	    description: Empty sync response, or the changes the update would make when using dry-run
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ClusterMemberPutPreview"
 //	  "400":
 //	    $ref: "#/responses/BadRequest"
 //	  "403":
@@ -1585,6 +1981,11 @@ func clusterNodePatch(d *Daemon, r *http.Request) response.Response {
 //	produces:
 //	  - application/json
 //	parameters:
+//	  - in: query
+//	    name: dry-run
+//	    description: Only validate the request and return the changes it would make
+//	    type: boolean
+//	    example: true
 //	  - in: body
 //	    name: cluster
 //	    description: Cluster member configuration
@@ -1593,7 +1994,25 @@ func clusterNodePatch(d *Daemon, r *http.Request) response.Response {
 //	      $ref: "#/definitions/ClusterMemberPut"
 //	responses:
 //	  "200":
-//	    $ref: "#/responses/EmptySyncResponse"
+//	    description: Empty sync response, or the changes the update would make when using dry-run
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ClusterMemberPutPreview"
 //	  "400":
 //	    $ref: "#/responses/BadRequest"
 //	  "403":
@@ -1634,14 +2053,9 @@ func updateClusterNode(s *state.State, gateway *cluster.Gateway, r *http.Request
 	var member db.NodeInfo
 	var memberInfo *api.ClusterMember
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
-		failureDomains, err := tx.GetFailureDomainsNames(ctx)
-		if err != nil {
-			return fmt.Errorf("Failed loading failure domains names: %w", err)
-		}
-
-		memberFailureDomains, err := tx.GetNodesFailureDomains(ctx)
+		args, err := clusterNodeInfoArgs(ctx, tx, s, leaderAddress, raftNodes)
 		if err != nil {
-			return fmt.Errorf("Failed loading member failure domains: %w", err)
+			return err
 		}
 
 		member, err = tx.GetNodeByName(ctx, name)
@@ -1649,23 +2063,9 @@ func updateClusterNode(s *state.State, gateway *cluster.Gateway, r *http.Request
 			return err
 		}
 
-		maxVersion, err := tx.GetNodeMaxVersion(ctx)
+		memberInfo, err = member.ToAPI(ctx, tx, *args)
 		if err != nil {
-			return fmt.Errorf("Failed getting max member version: %w", err)
-		}
-
-		args := db.NodeInfoArgs{
-			LeaderAddress:        leaderAddress,
-			FailureDomains:       failureDomains,
-			MemberFailureDomains: memberFailureDomains,
-			OfflineThreshold:     s.GlobalConfig.OfflineThreshold(),
-			MaxMemberVersion:     maxVersion,
-			RaftNodes:            raftNodes,
-		}
-
-		memberInfo, err = member.ToAPI(ctx, tx, args)
-		if err != nil {
-			return err
+			return err
 		}
 
 		return nil
@@ -1688,97 +2088,260 @@ func updateClusterNode(s *state.State, gateway *cluster.Gateway, r *http.Request
 	}
 
 	// Validate the request
+	err = clusterNodeValidateUpdate(memberInfo, req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	newRoles := clusterNodeRoles(req)
+	dryRun := util.IsTrue(request.QueryParam(r, "dry-run"))
+
+	// Update the database, rolling the changes back when only previewing them.
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		nodeInfo, err := tx.GetNodeByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Loading node information: %w", err)
+		}
+
+		err = clusterNodeApplyUpdate(ctx, tx, nodeInfo, memberInfo, &req, newRoles, isPatch)
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errClusterNodeUpdatePreview
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errClusterNodeUpdatePreview) {
+		return response.SmartError(err)
+	}
+
+	// Only report the changes when previewing the update.
+	if dryRun {
+		return response.SyncResponse(true, clusterNodeUpdatePreview(member, memberInfo, req, newRoles))
+	}
+
+	// If cluster roles changed, then distribute the info to all members.
+	// The change is already persisted, so members which couldn't be notified get it with the next heartbeat.
+	if s.Endpoints != nil && clusterRolesChanged(member.Roles, newRoles) {
+		err = cluster.NotifyHeartbeat(s, gateway)
+		if err != nil {
+			logger.Warn("Cluster member roles were updated but not yet propagated to all members", logger.Ctx{"member": name, "err": err})
+		}
+	}
+
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(request.ProjectParam(r), lifecycle.ClusterMemberUpdated.Event(name, requestor, nil))
+
+	return response.EmptySyncResponse
+}
+
+// clusterNodeInfoArgs returns the information about the cluster needed to render its members.
+func clusterNodeInfoArgs(ctx context.Context, tx *db.ClusterTx, s *state.State, leaderAddress string, raftNodes []db.RaftNode) (*db.NodeInfoArgs, error) {
+	failureDomains, err := tx.GetFailureDomainsNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading failure domains names: %w", err)
+	}
+
+	memberFailureDomains, err := tx.GetNodesFailureDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading member failure domains: %w", err)
+	}
+
+	maxVersion, err := tx.GetNodeMaxVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting max member version: %w", err)
+	}
+
+	return &db.NodeInfoArgs{
+		LeaderAddress:        leaderAddress,
+		FailureDomains:       failureDomains,
+		MemberFailureDomains: memberFailureDomains,
+		OfflineThreshold:     s.GlobalConfig.OfflineThreshold(),
+		MaxMemberVersion:     maxVersion,
+		RaftNodes:            raftNodes,
+	}, nil
+}
+
+// clusterNodeValidateUpdate checks the roles and groups of a cluster member update.
+func clusterNodeValidateUpdate(memberInfo *api.ClusterMember, req api.ClusterMemberPut) error {
 	if slices.Contains(memberInfo.Roles, string(db.ClusterRoleDatabase)) && !slices.Contains(req.Roles, string(db.ClusterRoleDatabase)) {
-		return response.BadRequest(fmt.Errorf("The %q role cannot be dropped at this time", db.ClusterRoleDatabase))
+		return fmt.Errorf("The %q role cannot be dropped at this time", db.ClusterRoleDatabase)
 	}
 
 	if !slices.Contains(memberInfo.Roles, string(db.ClusterRoleDatabase)) && slices.Contains(req.Roles, string(db.ClusterRoleDatabase)) {
-		return response.BadRequest(fmt.Errorf("The %q role cannot be added at this time", db.ClusterRoleDatabase))
+		return fmt.Errorf("The %q role cannot be added at this time", db.ClusterRoleDatabase)
 	}
 
 	// Nodes must belong to at least one group.
 	if len(req.Groups) == 0 {
-		return response.BadRequest(fmt.Errorf("Cluster members need to belong to at least one group"))
+		return fmt.Errorf("Cluster members need to belong to at least one group")
 	}
 
-	// Convert the roles.
+	return nil
+}
+
+// clusterNodeRoles converts the roles of a cluster member update.
+func clusterNodeRoles(req api.ClusterMemberPut) []db.ClusterRole {
 	newRoles := make([]db.ClusterRole, 0, len(req.Roles))
 	for _, role := range req.Roles {
 		newRoles = append(newRoles, db.ClusterRole(role))
 	}
 
-	// Update the database
-	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
-		nodeInfo, err := tx.GetNodeByName(ctx, name)
-		if err != nil {
-			return fmt.Errorf("Loading node information: %w", err)
-		}
+	return newRoles
+}
 
-		err = clusterValidateConfig(req.Config)
-		if err != nil {
-			return err
-		}
+// clusterNodeValidateConfig validates the configuration of a cluster member update, filling it with the
+// current values of the member when patching.
+func clusterNodeValidateConfig(ctx context.Context, tx *db.ClusterTx, nodeInfo db.NodeInfo, req *api.ClusterMemberPut, isPatch bool) error {
+	err := clusterValidateConfig(req.Config)
+	if err != nil {
+		return err
+	}
 
-		if isPatch {
-			// Populate request config with current values.
-			if req.Config == nil {
-				req.Config = nodeInfo.Config
-			} else {
-				for k, v := range nodeInfo.Config {
-					_, ok := req.Config[k]
-					if !ok {
-						req.Config[k] = v
-					}
+	if isPatch {
+		// Populate request config with current values.
+		if req.Config == nil {
+			req.Config = nodeInfo.Config
+		} else {
+			for k, v := range nodeInfo.Config {
+				_, ok := req.Config[k]
+				if !ok {
+					req.Config[k] = v
 				}
 			}
 		}
+	}
 
-		// Update node config.
-		err = tx.UpdateNodeConfig(ctx, nodeInfo.ID, req.Config)
+	for _, group := range req.Groups {
+		exists, err := dbCluster.ClusterGroupExists(ctx, tx.Tx(), group)
 		if err != nil {
-			return fmt.Errorf("Failed to update cluster member config: %w", err)
+			return err
 		}
 
-		// Update the description.
-		if req.Description != memberInfo.Description {
-			err = tx.SetDescription(nodeInfo.ID, req.Description)
-			if err != nil {
-				return fmt.Errorf("Update description: %w", err)
-			}
+		if !exists {
+			return api.StatusErrorf(http.StatusBadRequest, "Cluster group %q doesn't exist", group)
 		}
+	}
 
-		// Update the roles.
-		err = tx.UpdateNodeRoles(nodeInfo.ID, newRoles)
-		if err != nil {
-			return fmt.Errorf("Update roles: %w", err)
-		}
+	// Make sure the defaults inherited from the cluster groups don't conflict.
+	_, err = tx.GetClusterGroupsConfig(ctx, req.Groups, req.Config)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "%v", err)
+	}
 
-		err = tx.UpdateNodeFailureDomain(ctx, nodeInfo.ID, req.FailureDomain)
-		if err != nil {
-			return fmt.Errorf("Update failure domain: %w", err)
-		}
+	return nil
+}
 
-		// Update the cluster groups.
-		err = tx.UpdateNodeClusterGroups(ctx, nodeInfo.ID, req.Groups)
+// clusterNodeUpdate writes a validated cluster member update to the database.
+func clusterNodeUpdate(ctx context.Context, tx *db.ClusterTx, nodeInfo db.NodeInfo, memberInfo *api.ClusterMember, req api.ClusterMemberPut, newRoles []db.ClusterRole) error {
+	// Update node config.
+	err := tx.UpdateNodeConfig(ctx, nodeInfo.ID, req.Config)
+	if err != nil {
+		return fmt.Errorf("Failed to update cluster member config: %w", err)
+	}
+
+	// Update the description.
+	if req.Description != memberInfo.Description {
+		err = tx.SetDescription(nodeInfo.ID, req.Description)
 		if err != nil {
-			return fmt.Errorf("Update cluster groups: %w", err)
+			return fmt.Errorf("Update description: %w", err)
 		}
+	}
 
-		return nil
-	})
+	// Update the roles.
+	err = tx.UpdateNodeRoles(nodeInfo.ID, newRoles)
 	if err != nil {
-		return response.SmartError(err)
+		return fmt.Errorf("Update roles: %w", err)
 	}
 
-	// If cluster roles changed, then distribute the info to all members.
-	if s.Endpoints != nil && clusterRolesChanged(member.Roles, newRoles) {
-		cluster.NotifyHeartbeat(s, gateway)
+	err = tx.UpdateNodeFailureDomain(ctx, nodeInfo.ID, req.FailureDomain)
+	if err != nil {
+		return fmt.Errorf("Update failure domain: %w", err)
 	}
 
-	requestor := request.CreateRequestor(r)
-	s.Events.SendLifecycle(request.ProjectParam(r), lifecycle.ClusterMemberUpdated.Event(name, requestor, nil))
+	// Update the cluster groups.
+	err = tx.UpdateNodeClusterGroups(ctx, nodeInfo.ID, req.Groups)
+	if err != nil {
+		return fmt.Errorf("Update cluster groups: %w", err)
+	}
 
-	return response.EmptySyncResponse
+	return nil
+}
+
+// errClusterNodeUpdatePreview rolls back the transaction of a previewed cluster member update.
+var errClusterNodeUpdatePreview = errors.New("Cluster member update preview")
+
+// clusterNodeApplyUpdate validates a cluster member update and writes it to the database.
+// Previews of the update run it too, rolling back the transaction, so that they go through the same checks.
+func clusterNodeApplyUpdate(ctx context.Context, tx *db.ClusterTx, nodeInfo db.NodeInfo, memberInfo *api.ClusterMember, req *api.ClusterMemberPut, newRoles []db.ClusterRole, isPatch bool) error {
+	err := clusterNodeValidateConfig(ctx, tx, nodeInfo, req, isPatch)
+	if err != nil {
+		return err
+	}
+
+	err = clusterNodeUpdate(ctx, tx, nodeInfo, memberInfo, *req, newRoles)
+	if err != nil {
+		return err
+	}
+
+	// Don't leave groups used for instance placement without members.
+	_, removedGroups := clusterListChanges(memberInfo.Groups, req.Groups)
+
+	return clusterGroupsCheckNotEmptied(ctx, tx, removedGroups)
+}
+
+// clusterNodeUpdatePreview reports the changes a validated cluster member update makes.
+func clusterNodeUpdatePreview(member db.NodeInfo, memberInfo *api.ClusterMember, req api.ClusterMemberPut, newRoles []db.ClusterRole) api.ClusterMemberPutPreview {
+	preview := api.ClusterMemberPutPreview{
+		ConfigSet:             map[string]string{},
+		ConfigUnset:           []string{},
+		DescriptionChanged:    req.Description != memberInfo.Description,
+		FailureDomainChanged:  req.FailureDomain != memberInfo.FailureDomain,
+		HeartbeatNotification: clusterRolesChanged(member.Roles, newRoles),
+	}
+
+	for k, v := range req.Config {
+		oldValue, ok := member.Config[k]
+		if !ok || oldValue != v {
+			preview.ConfigSet[k] = v
+		}
+	}
+
+	for k := range member.Config {
+		_, ok := req.Config[k]
+		if !ok {
+			preview.ConfigUnset = append(preview.ConfigUnset, k)
+		}
+	}
+
+	sort.Strings(preview.ConfigUnset)
+
+	preview.RolesAdded, preview.RolesRemoved = clusterListChanges(memberInfo.Roles, req.Roles)
+	preview.GroupsAdded, preview.GroupsRemoved = clusterListChanges(memberInfo.Groups, req.Groups)
+
+	return preview
+}
+
+// clusterListChanges returns the entries of newList missing from oldList and the entries of oldList missing from newList.
+func clusterListChanges(oldList []string, newList []string) ([]string, []string) {
+	added := []string{}
+	for _, entry := range newList {
+		if !slices.Contains(oldList, entry) {
+			added = append(added, entry)
+		}
+	}
+
+	removed := []string{}
+	for _, entry := range oldList {
+		if !slices.Contains(newList, entry) {
+			removed = append(removed, entry)
+		}
+	}
+
+	return added, removed
 }
 
 // clusterRolesChanged checks whether the non-internal roles have changed between oldRoles and newRoles.
@@ -1821,6 +2384,26 @@ func clusterValidateConfig(config map[string]string) error {
 		//  defaultdesc: `all`
 		//  shortdesc: Controls how instances are scheduled to run on this member
 		"scheduler.instance": validate.Optional(validate.IsOneOf("all", "group", "manual")),
+
+		// gendoc:generate(entity=cluster, group=cluster, key=scheduler.priority)
+		// Value between `0` and `100`. When several members are eligible for an instance and have the
+		// same number of instances, the one with the highest priority is picked.
+		// See {ref}`clustering-instance-placement` for more information.
+		// ---
+		//  type: integer
+		//  defaultdesc: `0`
+		//  shortdesc: Priority of this member when placing instances
+		"scheduler.priority": validate.Optional(validate.IsInRange(0, 100)),
+
+		// gendoc:generate(entity=cluster, group=cluster, key=scheduler.evacuate.failure_domain)
+		// When this member is evacuated, its instances are preferably moved to members of this
+		// failure domain. Other members are only used if none is available in that failure domain.
+		// See {ref}`cluster-evacuate` for more information.
+		// ---
+		//  type: string
+		//  defaultdesc: failure domain of the member
+		//  shortdesc: Failure domain to prefer when evacuating this member
+		"scheduler.evacuate.failure_domain": validate.Optional(validate.IsAny),
 	}
 
 	for k, v := range config {
@@ -2066,7 +2649,7 @@ func clusterNodeDelete(d *Daemon, r *http.Request) response.Response {
 
 	logger.Info("Deleting member from cluster", logger.Ctx{"name": name, "force": force})
 
-	err = autoSyncImages(s.ShutdownCtx, s)
+	err = autoSyncImages(s.ShutdownCtx, s, nil)
 	if err != nil {
 		if force == 0 {
 			return response.SmartError(fmt.Errorf("Failed to sync images: %w", err))
@@ -2177,7 +2760,7 @@ func clusterNodeDelete(d *Daemon, r *http.Request) response.Response {
 	s.UpdateCertificateCache()
 
 	// Ensure all images are available after this node has been deleted.
-	err = autoSyncImages(s.ShutdownCtx, s)
+	err = autoSyncImages(s.ShutdownCtx, s, nil)
 	if err != nil {
 		logger.Warn("Failed to sync images")
 	}
@@ -2478,8 +3061,18 @@ type internalRaftNode struct {
 	Name    string `json:"name" yaml:"name"`
 }
 
+// A role change which a rebalance would make.
+type internalClusterRebalanceChange struct {
+	Name    string `json:"name" yaml:"name"`
+	Address string `json:"address" yaml:"address"`
+	Role    string `json:"role" yaml:"role"`
+	NewRole string `json:"new_role" yaml:"new_role"`
+}
+
 // Used to update the cluster after a database node has been removed, and
 // possibly promote another one as database node.
+//
+// With the dry-run query parameter set, the role changes are only computed and returned.
 func internalClusterPostRebalance(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
 
@@ -2495,9 +3088,10 @@ func internalClusterPostRebalance(d *Daemon, r *http.Request) response.Response
 	if localClusterAddress != leader {
 		logger.Debugf("Redirect cluster rebalance request to %s", leader)
 		url := &url.URL{
-			Scheme: "https",
-			Path:   "/internal/cluster/rebalance",
-			Host:   leader,
+			Scheme:   "https",
+			Path:     "/internal/cluster/rebalance",
+			RawQuery: r.URL.RawQuery,
+			Host:     leader,
 		}
 
 		return response.SyncResponseRedirect(url.String())
@@ -2507,6 +3101,25 @@ func internalClusterPostRebalance(d *Daemon, r *http.Request) response.Response
 	d.clusterMembershipMutex.Lock()
 	defer d.clusterMembershipMutex.Unlock()
 
+	if util.IsTrue(request.QueryParam(r, "dry-run")) {
+		changes, err := cluster.RebalancePlan(s, d.gateway, nil)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		result := make([]internalClusterRebalanceChange, 0, len(changes))
+		for _, change := range changes {
+			result = append(result, internalClusterRebalanceChange{
+				Name:    change.Name,
+				Address: change.Address,
+				Role:    change.OldRole.String(),
+				NewRole: change.NewRole.String(),
+			})
+		}
+
+		return response.SyncResponse(true, result)
+	}
+
 	err = rebalanceMemberRoles(s, d.gateway, r, nil)
 	if err != nil {
 		return response.SmartError(err)
@@ -2539,7 +3152,8 @@ again:
 			continue
 		}
 
-		if cluster.HasConnectivity(s.Endpoints.NetworkCert(), s.ServerCert(), address) {
+		// Retry a few times so that a transient failure doesn't get the member demoted.
+		if cluster.HasConnectivityWithRetry(s.Endpoints.NetworkCert(), s.ServerCert(), address, cluster.RebalanceConnectivityAttempts) {
 			break
 		}
 
@@ -2904,108 +3518,332 @@ func internalClusterRaftNodeDelete(d *Daemon, r *http.Request) response.Response
 	return response.SyncResponse(true, nil)
 }
 
-// swagger:operation GET /1.0/cluster/members/{name}/state cluster cluster_member_state_get
-//
-//	Get state of the cluster member
-//
-//	Gets state of a specific cluster member.
-//
-//	---
-//	produces:
-//	  - application/json
-//	responses:
-//	  "200":
-//	    description: Cluster member state
-//	    schema:
-//	      type: object
-//	      description: Sync response
-//	      properties:
-//	        type:
-//	          type: string
-//	          description: Response type
-//	          example: sync
-//	        status:
-//	          type: string
-//	          description: Status description
-//	          example: Success
-//	        status_code:
-//	          type: integer
-//	          description: Status code
-//	          example: 200
-//	        metadata:
-//	          $ref: "#/definitions/ClusterMemberState"
-//	  "403":
-//	    $ref: "#/responses/Forbidden"
-//	  "500":
-//	    $ref: "#/responses/InternalServerError"
-func clusterNodeStateGet(d *Daemon, r *http.Request) response.Response {
-	memberName, err := url.PathUnescape(mux.Vars(r)["name"])
+// Used to manually change the raft role of a member, for recovery purposes.
+func internalClusterRaftNodePut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	address, err := url.PathUnescape(mux.Vars(r)["address"])
 	if err != nil {
 		return response.SmartError(err)
 	}
 
-	s := d.State()
+	req := internalClusterRaftNodePutRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
 
-	// Forward request.
-	resp := forwardedResponseToNode(s, r, memberName)
-	if resp != nil {
-		return resp
+	roles := map[string]db.RaftRole{
+		db.RaftVoter.String():   db.RaftVoter,
+		db.RaftStandBy.String(): db.RaftStandBy,
+		db.RaftSpare.String():   db.RaftSpare,
 	}
 
-	memberState, err := cluster.MemberState(r.Context(), s, memberName)
-	if err != nil {
-		return response.SmartError(err)
+	role, ok := roles[req.Role]
+	if !ok {
+		return response.BadRequest(fmt.Errorf("Invalid raft role %q", req.Role))
 	}
 
-	return response.SyncResponse(true, memberState)
-}
+	// Redirect all requests to the leader, which is the one with
+	// authoritative knowledge of the current raft configuration.
+	localClusterAddress := s.LocalConfig.ClusterAddress()
 
-// swagger:operation POST /1.0/cluster/members/{name}/state cluster cluster_member_state_post
-//
-//	Evacuate or restore a cluster member
-//
-//	Evacuates or restores a cluster member.
-//
-//	---
-//	consumes:
-//	  - application/json
-//	produces:
-//	  - application/json
-//	parameters:
-//	  - in: body
-//	    name: cluster
-//	    description: Cluster member state
-//	    required: true
-//	    schema:
-//	      $ref: "#/definitions/ClusterMemberStatePost"
-//	responses:
-//	  "202":
-//	    $ref: "#/responses/Operation"
-//	  "400":
-//	    $ref: "#/responses/BadRequest"
-//	  "403":
-//	    $ref: "#/responses/Forbidden"
-//	  "500":
-//	    $ref: "#/responses/InternalServerError"
-func clusterNodeStatePost(d *Daemon, r *http.Request) response.Response {
-	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	leader, err := d.gateway.LeaderAddress()
 	if err != nil {
-		return response.SmartError(err)
+		return response.InternalError(err)
 	}
 
-	s := d.State()
+	if localClusterAddress != leader {
+		logger.Debugf("Redirect raft role change request to %s", leader)
+		url := &url.URL{
+			Scheme: "https",
+			Path:   fmt.Sprintf("/internal/cluster/raft-node/%s", url.PathEscape(address)),
+			Host:   leader,
+		}
 
-	// Forward request.
-	resp := forwardedResponseToNode(s, r, name)
-	if resp != nil {
-		return resp
+		return response.SyncResponseRedirect(url.String())
 	}
 
-	// Parse the request.
-	req := api.ClusterMemberStatePost{}
-	err = json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		return response.BadRequest(err)
+	// Get lock now we are on leader.
+	d.clusterMembershipMutex.Lock()
+	defer d.clusterMembershipMutex.Unlock()
+
+	nodes, err := d.gateway.RaftNodes()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var target *db.RaftNode
+	for i, node := range nodes {
+		if node.Address == address {
+			target = &nodes[i]
+			break
+		}
+	}
+
+	if target == nil {
+		return response.NotFound(fmt.Errorf("No raft member with address %q", address))
+	}
+
+	if target.Role == role {
+		return response.EmptySyncResponse
+	}
+
+	if target.Address == localClusterAddress {
+		return response.BadRequest(fmt.Errorf("The role of the leader can't be changed, hand over its role first"))
+	}
+
+	// Check which members are currently reachable.
+	var members []db.NodeInfo
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		members, err = tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	offlineThreshold := s.GlobalConfig.OfflineThreshold()
+	online := make(map[string]bool, len(members))
+	for _, member := range members {
+		online[member.Address] = !member.IsOffline(offlineThreshold)
+	}
+
+	if !online[target.Address] {
+		return response.BadRequest(fmt.Errorf("Cluster member %q is offline", target.Name))
+	}
+
+	// Ensure the online voters still form a quorum after the change.
+	oldRole := target.Role
+	target.Role = role
+
+	voters := 0
+	onlineVoters := 0
+	for _, node := range nodes {
+		if node.Role != db.RaftVoter {
+			continue
+		}
+
+		voters++
+		if online[node.Address] {
+			onlineVoters++
+		}
+	}
+
+	if onlineVoters <= voters/2 {
+		return response.BadRequest(fmt.Errorf("Changing the role of %q to %q would lose quorum (%d of %d voters online)", target.Name, role, onlineVoters, voters))
+	}
+
+	logger.Info("Manually changing raft role of member", logger.Ctx{"address": target.Address, "oldRole": oldRole, "newRole": role})
+	err = changeMemberRole(s, r, target.Address, nodes)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.ClusterMemberUpdated.Event(target.Name, requestor, map[string]any{"raft_role": role.String()}))
+
+	return response.EmptySyncResponse
+}
+
+// A request for the /internal/cluster/raft-node/{address} endpoint.
+type internalClusterRaftNodePutRequest struct {
+	// One of "voter", "stand-by" or "spare".
+	Role string `json:"role" yaml:"role"`
+}
+
+// Used to list the members of the raft cluster along with their role.
+func internalClusterRaftNodesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// Redirect all requests to the leader, which is the one with
+	// authoritative knowledge of the current raft configuration.
+	localClusterAddress := s.LocalConfig.ClusterAddress()
+
+	leader, err := d.gateway.LeaderAddress()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	if localClusterAddress != leader {
+		logger.Debugf("Redirect raft members request to %s", leader)
+		url := &url.URL{
+			Scheme: "https",
+			Path:   "/internal/cluster/raft-nodes",
+			Host:   leader,
+		}
+
+		return response.SyncResponseRedirect(url.String())
+	}
+
+	nodes, err := d.gateway.RaftNodes()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	raftNodes := make([]internalRaftNodeRole, 0, len(nodes))
+	for _, node := range nodes {
+		raftNodes = append(raftNodes, internalRaftNodeRole{
+			ID:      node.ID,
+			Address: node.Address,
+			Name:    node.Name,
+			Role:    node.Role.String(),
+		})
+	}
+
+	return response.SyncResponse(true, raftNodes)
+}
+
+// A member of the dqlite raft cluster as returned by the /internal/cluster/raft-nodes endpoint.
+type internalRaftNodeRole struct {
+	ID      uint64 `json:"id" yaml:"id"`
+	Address string `json:"address" yaml:"address"`
+	Name    string `json:"name" yaml:"name"`
+
+	// One of "voter", "stand-by" or "spare".
+	Role string `json:"role" yaml:"role"`
+}
+
+// swagger:operation GET /1.0/cluster/members/{name}/state cluster cluster_member_state_get
+//
+//	Get state of the cluster member
+//
+//	Gets state of a specific cluster member.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Cluster member state
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ClusterMemberState"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterNodeStateGet(d *Daemon, r *http.Request) response.Response {
+	memberName, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s := d.State()
+
+	// Forward request.
+	resp := forwardedResponseToNode(s, r, memberName)
+	if resp != nil {
+		return resp
+	}
+
+	memberState, err := cluster.MemberState(r.Context(), s, memberName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, memberState)
+}
+
+// swagger:operation POST /1.0/cluster/members/{name}/state cluster cluster_member_state_post
+//
+//	Evacuate, restore or drain a cluster member
+//
+//	Evacuates or restores a cluster member.
+//	Draining a cluster member prevents new instances from being placed on it, without moving its existing instances.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: cluster
+//	    description: Cluster member state
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ClusterMemberStatePost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterNodeStatePost(d *Daemon, r *http.Request) response.Response {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s := d.State()
+
+	// Forward request.
+	resp := forwardedResponseToNode(s, r, name)
+	if resp != nil {
+		return resp
+	}
+
+	// Parse the request.
+	req := api.ClusterMemberStatePost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Parallel < 0 {
+		return response.BadRequest(fmt.Errorf("The number of parallel migrations can't be negative"))
+	}
+
+	if req.DryRun && req.Action != "evacuate" {
+		return response.BadRequest(fmt.Errorf("Dry-run is only supported when evacuating"))
+	}
+
+	// Validate the evacuation targets.
+	if len(req.Targets) > 0 {
+		if req.Action != "evacuate" {
+			return response.BadRequest(fmt.Errorf("Targets can only be set when evacuating"))
+		}
+
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			for _, target := range req.Targets {
+				if target == name {
+					return api.StatusErrorf(http.StatusBadRequest, "Cluster member %q can't be its own evacuation target", name)
+				}
+
+				_, err := tx.GetNodeByName(ctx, target)
+				if err != nil {
+					return fmt.Errorf("Failed getting evacuation target %q: %w", target, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
 	}
 
 	// Validate the overrides.
@@ -3071,7 +3909,19 @@ func clusterNodeStatePost(d *Daemon, r *http.Request) response.Response {
 				Live:      live,
 			}
 
-			err := migrateInstance(ctx, s, inst, req, sourceMemberInfo, targetMemberInfo, op)
+			// Get the migration bandwidth limit.
+			var bandwidthLimit int64
+			limit := inst.ExpandedConfig()["cluster.evacuate.bandwidth_limit"]
+			if limit != "" {
+				var err error
+
+				bandwidthLimit, err = units.ParseByteSizeString(limit)
+				if err != nil {
+					return fmt.Errorf("Failed parsing bandwidth limit of instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
+				}
+			}
+
+			err := migrateInstance(ctx, s, inst, req, sourceMemberInfo, targetMemberInfo, bandwidthLimit, op)
 			if err != nil {
 				return fmt.Errorf("Failed to migrate instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
 			}
@@ -3106,9 +3956,13 @@ func clusterNodeStatePost(d *Daemon, r *http.Request) response.Response {
 			return nil
 		}
 
-		return evacuateClusterMember(s, d.gateway, r, req.Mode, stopFunc, migrateFunc)
+		return evacuateClusterMember(s, d.gateway, r, req, stopFunc, migrateFunc)
 	} else if req.Action == "restore" {
 		return restoreClusterMember(d, r)
+	} else if req.Action == "drain" {
+		return cordonClusterMember(s, r, name, true)
+	} else if req.Action == "uncordon" {
+		return cordonClusterMember(s, r, name, false)
 	}
 
 	return response.BadRequest(fmt.Errorf("Unknown action %q", req.Action))
@@ -3178,7 +4032,7 @@ func internalClusterHeal(d *Daemon, r *http.Request) response.Response {
 		return nil
 	}
 
-	return evacuateClusterMember(d.State(), d.gateway, r, "migrate", nil, migrateFunc)
+	return evacuateClusterMember(d.State(), d.gateway, r, api.ClusterMemberStatePost{Action: "evacuate", Mode: "migrate"}, nil, migrateFunc)
 }
 
 func evacuateClusterSetState(s *state.State, name string, state int) error {
@@ -3214,39 +4068,56 @@ func evacuateClusterSetState(s *state.State, name string, state int) error {
 	})
 }
 
-// evacuateHostShutdownDefaultTimeout default timeout (in seconds) for waiting for clean shutdown to complete.
-const evacuateHostShutdownDefaultTimeout = 30
-
-func evacuateClusterMember(s *state.State, gateway *cluster.Gateway, r *http.Request, mode string, stopInstance evacuateStopFunc, migrateInstance evacuateMigrateFunc) response.Response {
-	nodeName, err := url.PathUnescape(mux.Vars(r)["name"])
-	if err != nil {
-		return response.SmartError(err)
+// cordonClusterMember sets whether new instances can be placed on the member, leaving its existing instances alone.
+func cordonClusterMember(s *state.State, r *http.Request, name string, cordoned bool) response.Response {
+	opType := operationtype.ClusterMemberUncordon
+	if cordoned {
+		opType = operationtype.ClusterMemberDrain
 	}
 
-	// The instances are retrieved in a separate transaction, after the node is in EVACUATED state.
-	var dbInstances []dbCluster.Instance
-	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
-		// If evacuating, consider only the instances on the node which needs to be evacuated.
-		dbInstances, err = dbCluster.GetInstances(ctx, tx.Tx(), dbCluster.InstanceFilter{Node: &nodeName})
-		if err != nil {
-			return fmt.Errorf("Failed to get instances: %w", err)
-		}
+	run := func(op *operations.Operation) error {
+		return s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			node, err := tx.GetNodeByName(ctx, name)
+			if err != nil {
+				return fmt.Errorf("Failed to get cluster member by name: %w", err)
+			}
 
-		return nil
-	})
+			if node.State == db.ClusterMemberStatePending {
+				return fmt.Errorf("Cannot drain or uncordon a pending cluster member")
+			}
+
+			if node.Cordoned == cordoned {
+				if cordoned {
+					return fmt.Errorf("Cluster member is already drained")
+				}
+
+				return fmt.Errorf("Cluster member isn't drained")
+			}
+
+			err = tx.UpdateNodeCordoned(node.ID, cordoned)
+			if err != nil {
+				return fmt.Errorf("Failed to update cluster member: %w", err)
+			}
+
+			return nil
+		})
+	}
+
+	op, err := operations.OperationCreate(s, "", operations.OperationClassTask, opType, nil, nil, run, nil, nil, r)
 	if err != nil {
-		return response.SmartError(err)
+		return response.InternalError(err)
 	}
 
-	instances := make([]instance.Instance, len(dbInstances))
+	return operations.OperationResponse(op)
+}
 
-	for i, dbInst := range dbInstances {
-		inst, err := instance.LoadByProjectAndName(s, dbInst.Project, dbInst.Name)
-		if err != nil {
-			return response.SmartError(fmt.Errorf("Failed to load instance: %w", err))
-		}
+// evacuateHostShutdownDefaultTimeout default timeout (in seconds) for waiting for clean shutdown to complete.
+const evacuateHostShutdownDefaultTimeout = 30
 
-		instances[i] = inst
+func evacuateClusterMember(s *state.State, gateway *cluster.Gateway, r *http.Request, req api.ClusterMemberStatePost, stopInstance evacuateStopFunc, migrateInstance evacuateMigrateFunc) response.Response {
+	nodeName, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
 	}
 
 	run := func(op *operations.Operation) error {
@@ -3254,27 +4125,138 @@ func evacuateClusterMember(s *state.State, gateway *cluster.Gateway, r *http.Req
 		revert := revert.New()
 		defer revert.Fail()
 
-		// Set node status to EVACUATED.
-		err := evacuateClusterSetState(s, nodeName, db.ClusterMemberStateEvacuated)
+		// Check if resuming an evacuation which didn't complete.
+		var node db.NodeInfo
+		err := s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			node, err = tx.GetNodeByName(ctx, nodeName)
+			if err != nil {
+				return fmt.Errorf("Failed to get cluster member by name: %w", err)
+			}
+
+			return nil
+		})
 		if err != nil {
 			return err
 		}
 
-		// Ensure node is put into its previous state if anything fails.
-		revert.Add(func() {
-			_ = evacuateClusterSetState(s, nodeName, db.ClusterMemberStateCreated)
+		if req.DryRun {
+			// Only plan the evacuation, leaving the member as is.
+			if node.State == db.ClusterMemberStatePending {
+				return fmt.Errorf("Cannot evacuate or restore a pending cluster member")
+			}
+		} else if node.State == db.ClusterMemberStateEvacuated {
+			logger.Info("Resuming evacuation of cluster member", logger.Ctx{"member": nodeName})
+		} else {
+			// Set node status to EVACUATED.
+			err = evacuateClusterSetState(s, nodeName, db.ClusterMemberStateEvacuated)
+			if err != nil {
+				return err
+			}
+
+			// Ensure node is put into its previous state if anything fails.
+			revert.Add(func() {
+				_ = evacuateClusterSetState(s, nodeName, db.ClusterMemberStateCreated)
+			})
+		}
+
+		// The instances are retrieved after the node is in EVACUATED state, so that only the ones
+		// still on the member are considered when resuming an evacuation.
+		var dbInstances []dbCluster.Instance
+		err = s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			// If evacuating, consider only the instances on the node which needs to be evacuated.
+			dbInstances, err = dbCluster.GetInstances(ctx, tx.Tx(), dbCluster.InstanceFilter{Node: &nodeName})
+			if err != nil {
+				return fmt.Errorf("Failed to get instances: %w", err)
+			}
+
+			return nil
 		})
+		if err != nil {
+			return err
+		}
+
+		instances := make([]instance.Instance, len(dbInstances))
+
+		for i, dbInst := range dbInstances {
+			inst, err := instance.LoadByProjectAndName(s, dbInst.Project, dbInst.Name)
+			if err != nil {
+				return fmt.Errorf("Failed to load instance: %w", err)
+			}
+
+			instances[i] = inst
+		}
+
+		// Gather the resources of the requested targets to check the instances fit.
+		var targetResources map[string]*api.ClusterMemberResources
+		if len(req.Targets) > 0 && !slices.Contains([]string{"stop", "stateful-stop", "force-stop"}, req.Mode) {
+			var targetMembers []db.NodeInfo
+
+			err = s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+				for _, target := range req.Targets {
+					member, err := tx.GetNodeByName(ctx, target)
+					if err != nil {
+						return fmt.Errorf("Failed getting evacuation target %q: %w", target, err)
+					}
+
+					targetMembers = append(targetMembers, member)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			targetResources = cluster.MembersResources(context.Background(), s, targetMembers)
+		}
+
+		// Gather the resources of the other members once for the instance placement scriptlet rather than
+		// having it query them again for every instance.
+		var memberResources map[string]*api.Resources
+		if len(req.Targets) == 0 && s.GlobalConfig.InstancesPlacementScriptlet() != "" && !slices.Contains([]string{"stop", "stateful-stop", "force-stop"}, req.Mode) {
+			var candidateMembers []db.NodeInfo
+
+			err = s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+				members, err := tx.GetNodes(ctx)
+				if err != nil {
+					return fmt.Errorf("Failed getting cluster members: %w", err)
+				}
+
+				for _, member := range members {
+					if member.Name != nodeName && !member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+						candidateMembers = append(candidateMembers, member)
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			memberResources = scriptlet.InstancePlacementResources(context.Background(), s, candidateMembers, s.GlobalConfig.ClusterEvacuateWorkers())
+		}
 
 		opts := evacuateOpts{
 			s:               s,
 			gateway:         gateway,
 			r:               r,
 			instances:       instances,
-			mode:            mode,
+			mode:            req.Mode,
 			srcMemberName:   nodeName,
 			stopInstance:    stopInstance,
 			migrateInstance: migrateInstance,
 			op:              op,
+			dryRun:          req.DryRun,
+			parallel:        req.Parallel,
+			stopOnError:     req.StopOnError,
+			targets:         req.Targets,
+			targetResources: targetResources,
+			memberResources: memberResources,
 		}
 
 		err = evacuateInstances(context.Background(), opts)
@@ -3299,26 +4281,128 @@ func evacuateInstances(ctx context.Context, opts evacuateOpts) error {
 		return fmt.Errorf("Missing migration callback function")
 	}
 
+	// Instances are processed concurrently so the metadata and the target selection are guarded.
+	var metadataLock sync.Mutex
+	var selectLock sync.Mutex
+
 	metadata := make(map[string]any)
+	skipped := []map[string]string{}
+	stopped := []map[string]string{}
+	unplaceable := []map[string]string{}
 
-	for _, inst := range opts.instances {
+	// Record instances which couldn't be moved to another member along with the reason.
+	addUnplaceable := func(inst instance.Instance, reason string) {
+		metadataLock.Lock()
+		defer metadataLock.Unlock()
+
+		unplaceable = append(unplaceable, map[string]string{"project": inst.Project().Name, "name": inst.Name(), "reason": reason})
+		metadata["evacuation_unplaceable"] = unplaceable
+		_ = opts.op.UpdateMetadata(metadata)
+	}
+
+	// Record what would happen to each instance when only planning the evacuation.
+	plan := []map[string]string{}
+	addPlan := func(inst instance.Instance, action string, target string, reason string) {
+		metadataLock.Lock()
+		defer metadataLock.Unlock()
+
+		plan = append(plan, map[string]string{"project": inst.Project().Name, "name": inst.Name(), "member": inst.Location(), "action": action, "target": target, "reason": reason})
+		metadata["evacuation_plan"] = plan
+		_ = opts.op.UpdateMetadata(metadata)
+	}
+
+	// Instances being moved to each member, indexed by member ID, so that they get spread out.
+	planned := map[int64]int{}
+
+	parallel := max(opts.parallel, 1)
+	inFlight := 0
+	remaining := len(opts.instances)
+
+	// Report the number of migrations in progress when running several at once.
+	setProgress := func(message string, migrating int) {
+		metadataLock.Lock()
+		defer metadataLock.Unlock()
+
+		inFlight += migrating
+		if parallel > 1 {
+			message = fmt.Sprintf("Migrating %d instances, %d remaining", inFlight, remaining)
+		} else if message == "" {
+			// Keep the last step around when done with a migration.
+			return
+		}
+
+		metadata["evacuation_progress"] = message
+		_ = opts.op.UpdateMetadata(metadata)
+	}
+
+	evacuateInstance := func(inst instance.Instance) error {
 		instProject := inst.Project()
 		l := logger.AddContext(logger.Ctx{"project": instProject.Name, "instance": inst.Name()})
 
-		// Check if migratable.
-		action := inst.CanMigrate()
+		// Check if migratable.
+		action := inst.CanMigrate()
+
+		// Automatic evacuation only falls back to stopping the instance if its devices can't be migrated.
+		hasUnmigratableDevices := action == "stop" && slices.Contains([]string{"", "auto"}, inst.ExpandedConfig()["cluster.evacuate"])
+
+		// Apply overrides (instances configured to be skipped are always left alone).
+		if opts.mode != "" && opts.mode != "auto" && action != "skip" {
+			action = opts.mode
+		}
+
+		// Relaunching only moves the instances whose storage doesn't need copying, the others are started
+		// again on restore.
+		relaunchOnRestore := false
+		if action == "relaunch" {
+			remote, err := evacuateInstanceHasRemoteStorage(opts.s, inst)
+			if err != nil {
+				return err
+			}
+
+			action = "migrate"
+			if !remote {
+				action = "stop"
+				relaunchOnRestore = true
+			}
+		}
+
+		// Leave the instance in place if requested.
+		if action == "skip" {
+			if opts.dryRun {
+				addPlan(inst, action, "", "Instance is configured to stay on the cluster member")
+				return nil
+			}
+
+			l.Info("Skipping instance evacuation")
+
+			metadataLock.Lock()
+			skipped = append(skipped, map[string]string{"project": instProject.Name, "name": inst.Name()})
+			metadata["evacuation_skipped"] = skipped
+			_ = opts.op.UpdateMetadata(metadata)
+			metadataLock.Unlock()
+
+			return nil
+		}
+
+		// When planning, instances which don't get moved are only reported.
+		if opts.dryRun && action != "live-migrate" && action != "migrate" {
+			if action == "stop" && hasUnmigratableDevices {
+				addPlan(inst, action, "", "Instance has devices which can't be migrated")
+				addUnplaceable(inst, "Instance has devices which can't be migrated")
+			} else if relaunchOnRestore {
+				addPlan(inst, action, "", "Instance storage is local, it's started again on restore")
+			} else {
+				addPlan(inst, action, "", "Instance is configured to be stopped rather than moved")
+			}
 
-		// Apply overrides.
-		if opts.mode != "" && opts.mode != "auto" {
-			action = opts.mode
+			return nil
 		}
 
 		// Stop the instance if needed.
 		isRunning := inst.IsRunning()
-		if action != "live-migrate" {
+		if action != "live-migrate" && !opts.dryRun {
 			if opts.stopInstance != nil && isRunning {
-				metadata["evacuation_progress"] = fmt.Sprintf("Stopping %q in project %q", inst.Name(), instProject.Name)
-				_ = opts.op.UpdateMetadata(metadata)
+				setProgress(fmt.Sprintf("Stopping %q in project %q", inst.Name(), instProject.Name), 0)
 
 				err := opts.stopInstance(inst, action)
 				if err != nil {
@@ -3327,35 +4411,153 @@ func evacuateInstances(ctx context.Context, opts evacuateOpts) error {
 			}
 
 			if action != "migrate" {
+				// Report instances which would have been migrated if not for their devices.
+				if action == "stop" && hasUnmigratableDevices {
+					addUnplaceable(inst, "Instance has devices which can't be migrated")
+				}
+
+				// Record the running instances which got stopped, those are started again on restore.
+				if opts.stopInstance != nil && isRunning {
+					metadataLock.Lock()
+					stopped = append(stopped, map[string]string{"project": instProject.Name, "name": inst.Name()})
+					metadata["evacuation_stopped"] = stopped
+					_ = opts.op.UpdateMetadata(metadata)
+					metadataLock.Unlock()
+				}
+
 				// Done with this instance.
-				continue
+				return nil
 			}
 		}
 
-		// Find a new location for the instance.
-		sourceMemberInfo, targetMemberInfo, err := evacuateClusterSelectTarget(ctx, opts.s, opts.gateway, inst)
+		// Find a new location for the instance, only among the requested targets if any.
+		var sourceMemberInfo, targetMemberInfo *db.NodeInfo
+		var reason string
+		var err error
+
+		selectLock.Lock()
+		if len(opts.targets) > 0 {
+			sourceMemberInfo, targetMemberInfo, reason, err = evacuateClusterSelectRequestedTarget(ctx, opts.s, inst, opts.targets, opts.targetResources, planned)
+		} else {
+			sourceMemberInfo, targetMemberInfo, reason, err = evacuateClusterSelectTarget(ctx, opts.s, opts.gateway, inst, planned, opts.memberResources)
+		}
+
+		if err == nil {
+			planned[targetMemberInfo.ID]++
+		}
+
+		selectLock.Unlock()
+
+		// Report the instances which can't go to any of the requested targets when planning.
+		if err != nil && opts.dryRun && len(opts.targets) > 0 {
+			addUnplaceable(inst, err.Error())
+			addPlan(inst, action, "", err.Error())
+			return nil
+		}
+
 		if err != nil {
 			if api.StatusErrorCheck(err, http.StatusNotFound) {
 				// Skip migration if no target is available.
-				l.Warn("No migration target available for instance")
-				continue
+				l.Warn("No migration target available for instance", logger.Ctx{"err": err})
+				addUnplaceable(inst, err.Error())
+
+				if opts.dryRun {
+					addPlan(inst, action, "", err.Error())
+				}
+
+				return nil
 			}
+
+			return err
 		}
 
+		// Keep the instance accounted for on its target when planning so the following ones get spread out.
+		if opts.dryRun {
+			addPlan(inst, action, targetMemberInfo.Name, reason)
+			return nil
+		}
+
+		// Once migrated, the instance is counted on its target by the database.
+		defer func() {
+			selectLock.Lock()
+			planned[targetMemberInfo.ID]--
+			selectLock.Unlock()
+		}()
+
 		// Start migrating the instance.
-		metadata["evacuation_progress"] = fmt.Sprintf("Migrating %q in project %q to %q", inst.Name(), instProject.Name, targetMemberInfo.Name)
-		_ = opts.op.UpdateMetadata(metadata)
+		setProgress(fmt.Sprintf("Migrating %q in project %q to %q", inst.Name(), instProject.Name, targetMemberInfo.Name), 1)
+		defer setProgress("", -1)
 
 		// Set origin server (but skip if already set as that suggests more than one server being evacuated).
 		if inst.LocalConfig()["volatile.evacuate.origin"] == "" {
 			_ = inst.VolatileSet(map[string]string{"volatile.evacuate.origin": opts.srcMemberName})
 		}
 
+		// The migration progress can only be reported when migrating one instance at a time.
+		var migrateMetadata map[string]any
+		if parallel == 1 {
+			migrateMetadata = metadata
+		}
+
 		start := isRunning || instanceShouldAutoStart(inst)
-		err = opts.migrateInstance(ctx, opts.s, opts.r, inst, sourceMemberInfo, targetMemberInfo, action == "live-migrate", start, metadata, opts.op)
+		err = opts.migrateInstance(ctx, opts.s, opts.r, inst, sourceMemberInfo, targetMemberInfo, action == "live-migrate", start, migrateMetadata, opts.op)
 		if err != nil {
 			return err
 		}
+
+		return nil
+	}
+
+	// Process the instances with a bounded number of workers.
+	var errorsLock sync.Mutex
+	var failures []error
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, parallel)
+
+	for _, inst := range opts.instances {
+		workers <- struct{}{}
+
+		errorsLock.Lock()
+		abort := opts.stopOnError && len(failures) > 0
+		errorsLock.Unlock()
+
+		if abort {
+			<-workers
+			break
+		}
+
+		wg.Add(1)
+		go func(inst instance.Instance) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			err := evacuateInstance(inst)
+
+			metadataLock.Lock()
+			remaining--
+			metadataLock.Unlock()
+
+			if err != nil {
+				logger.Error("Failed evacuating instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+
+				errorsLock.Lock()
+				failures = append(failures, err)
+				errorsLock.Unlock()
+			}
+		}(inst)
+	}
+
+	wg.Wait()
+
+	if len(failures) == 1 {
+		return failures[0]
+	} else if len(failures) > 1 {
+		messages := make([]string, 0, len(failures))
+		for _, err := range failures {
+			messages = append(messages, err.Error())
+		}
+
+		return fmt.Errorf("Failed evacuating %d instances: %s", len(failures), strings.Join(messages, "; "))
 	}
 
 	return nil
@@ -3422,9 +4624,6 @@ func restoreClusterMember(d *Daemon, r *http.Request) response.Response {
 			_ = evacuateClusterSetState(s, originName, db.ClusterMemberStateEvacuated)
 		})
 
-		var source incus.InstanceServer
-		var sourceNode db.NodeInfo
-
 		metadata := make(map[string]any)
 
 		// Restart the local instances.
@@ -3458,141 +4657,173 @@ func restoreClusterMember(d *Daemon, r *http.Request) response.Response {
 
 		// Migrate back the remote instances.
 		for _, inst := range instances {
-			l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+			err = restoreClusterMemberInstance(s, r, op, originName, inst)
+			if err != nil {
+				return err
+			}
+		}
 
-			// Check the action.
-			live := inst.CanMigrate() == "live-migrate"
+		revert.Success()
+		return nil
+	}
 
-			metadata["evacuation_progress"] = fmt.Sprintf("Migrating %q in project %q from %q", inst.Name(), inst.Project().Name, inst.Location())
-			_ = op.UpdateMetadata(metadata)
+	op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.ClusterMemberRestore, nil, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
 
-			err = s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
-				sourceNode, err = tx.GetNodeByName(ctx, inst.Location())
-				if err != nil {
-					return fmt.Errorf("Failed to get node %q: %w", inst.Location(), err)
-				}
+	return operations.OperationResponse(op)
+}
 
-				return nil
-			})
-			if err != nil {
-				return fmt.Errorf("Failed to get node: %w", err)
-			}
+// restoreClusterMemberInstance migrates an evacuated instance back to its origin cluster member,
+// stopping it beforehand if it can't be live-migrated and starting it again afterwards.
+func restoreClusterMemberInstance(s *state.State, r *http.Request, op *operations.Operation, originName string, inst instance.Instance) error {
+	var sourceNode db.NodeInfo
+	var err error
 
-			source, err = cluster.Connect(sourceNode.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
-			if err != nil {
-				return fmt.Errorf("Failed to connect to source: %w", err)
-			}
+	metadata := make(map[string]any)
 
-			source = source.UseProject(inst.Project().Name)
+	l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
 
-			apiInst, _, err := source.GetInstance(inst.Name())
-			if err != nil {
-				return fmt.Errorf("Failed to get instance %q: %w", inst.Name(), err)
-			}
+	// Check the action.
+	live := inst.CanMigrate() == "live-migrate"
 
-			isRunning := apiInst.StatusCode == api.Running
-			if isRunning && !live {
-				metadata["evacuation_progress"] = fmt.Sprintf("Stopping %q in project %q", inst.Name(), inst.Project().Name)
-				_ = op.UpdateMetadata(metadata)
+	metadata["evacuation_progress"] = fmt.Sprintf("Migrating %q in project %q from %q", inst.Name(), inst.Project().Name, inst.Location())
+	_ = op.UpdateMetadata(metadata)
 
-				timeout := inst.ExpandedConfig()["boot.host_shutdown_timeout"]
-				val, err := strconv.Atoi(timeout)
-				if err != nil {
-					val = evacuateHostShutdownDefaultTimeout
-				}
+	err = s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+		sourceNode, err = tx.GetNodeByName(ctx, inst.Location())
+		if err != nil {
+			return fmt.Errorf("Failed to get node %q: %w", inst.Location(), err)
+		}
 
-				// Attempt a clean stop.
-				stopOp, err := source.UpdateInstanceState(inst.Name(), api.InstanceStatePut{Action: "stop", Force: false, Timeout: val}, "")
-				if err != nil {
-					return fmt.Errorf("Failed to stop instance %q: %w", inst.Name(), err)
-				}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to get node: %w", err)
+	}
 
-				// Wait for the stop operation to complete or timeout.
-				err = stopOp.Wait()
-				if err != nil {
-					l.Warn("Failed shutting down instance, forcing stop", logger.Ctx{"err": err})
+	source, err := cluster.Connect(sourceNode.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
+	if err != nil {
+		return fmt.Errorf("Failed to connect to source: %w", err)
+	}
 
-					// On failure, attempt a forceful stop.
-					stopOp, err = source.UpdateInstanceState(inst.Name(), api.InstanceStatePut{Action: "stop", Force: true}, "")
-					if err != nil {
-						// If this fails too, fail the whole operation.
-						return fmt.Errorf("Failed to stop instance %q: %w", inst.Name(), err)
-					}
+	source = source.UseProject(inst.Project().Name)
 
-					// Wait for the forceful stop to complete.
-					err = stopOp.Wait()
-					if err != nil && !strings.Contains(err.Error(), "The instance is already stopped") {
-						return fmt.Errorf("Failed to stop instance %q: %w", inst.Name(), err)
-					}
-				}
-			}
+	apiInst, _, err := source.GetInstance(inst.Name())
+	if err != nil {
+		return fmt.Errorf("Failed to get instance %q: %w", inst.Name(), err)
+	}
 
-			req := api.InstancePost{
-				Name:      inst.Name(),
-				Migration: true,
-				Live:      live,
-			}
+	isRunning := apiInst.StatusCode == api.Running
+	if isRunning && !live {
+		metadata["evacuation_progress"] = fmt.Sprintf("Stopping %q in project %q", inst.Name(), inst.Project().Name)
+		_ = op.UpdateMetadata(metadata)
 
-			source = source.UseTarget(originName)
+		timeout := inst.ExpandedConfig()["boot.host_shutdown_timeout"]
+		val, err := strconv.Atoi(timeout)
+		if err != nil {
+			val = evacuateHostShutdownDefaultTimeout
+		}
 
-			migrationOp, err := source.MigrateInstance(inst.Name(), req)
-			if err != nil {
-				return fmt.Errorf("Migration API failure: %w", err)
-			}
+		// Attempt a clean stop.
+		stopOp, err := source.UpdateInstanceState(inst.Name(), api.InstanceStatePut{Action: "stop", Force: false, Timeout: val}, "")
+		if err != nil {
+			return fmt.Errorf("Failed to stop instance %q: %w", inst.Name(), err)
+		}
 
-			err = migrationOp.Wait()
-			if err != nil {
-				return fmt.Errorf("Failed to wait for migration to finish: %w", err)
-			}
+		// Wait for the stop operation to complete or timeout.
+		err = stopOp.Wait()
+		if err != nil {
+			l.Warn("Failed shutting down instance, forcing stop", logger.Ctx{"err": err})
 
-			// Reload the instance after migration.
-			inst, err := instance.LoadByProjectAndName(s, inst.Project().Name, inst.Name())
+			// On failure, attempt a forceful stop.
+			stopOp, err = source.UpdateInstanceState(inst.Name(), api.InstanceStatePut{Action: "stop", Force: true}, "")
 			if err != nil {
-				return fmt.Errorf("Failed to load instance: %w", err)
+				// If this fails too, fail the whole operation.
+				return fmt.Errorf("Failed to stop instance %q: %w", inst.Name(), err)
 			}
 
-			config := inst.LocalConfig()
-			delete(config, "volatile.evacuate.origin")
-
-			args := db.InstanceArgs{
-				Architecture: inst.Architecture(),
-				Config:       config,
-				Description:  inst.Description(),
-				Devices:      inst.LocalDevices(),
-				Ephemeral:    inst.IsEphemeral(),
-				Profiles:     inst.Profiles(),
-				Project:      inst.Project().Name,
-				ExpiryDate:   inst.ExpiryDate(),
+			// Wait for the forceful stop to complete.
+			err = stopOp.Wait()
+			if err != nil && !strings.Contains(err.Error(), "The instance is already stopped") {
+				return fmt.Errorf("Failed to stop instance %q: %w", inst.Name(), err)
 			}
+		}
+	}
 
-			err = inst.Update(args, false)
-			if err != nil {
-				return fmt.Errorf("Failed to update instance %q: %w", inst.Name(), err)
-			}
+	req := api.InstancePost{
+		Name:      inst.Name(),
+		Migration: true,
+		Live:      live,
+	}
 
-			if !isRunning || live {
-				continue
-			}
+	// Start the instance again where it is if it was stopped for a move which then failed.
+	restartOnFailure := func(err error) error {
+		if !isRunning || live {
+			return err
+		}
 
-			metadata["evacuation_progress"] = fmt.Sprintf("Starting %q in project %q", inst.Name(), inst.Project().Name)
-			_ = op.UpdateMetadata(metadata)
+		startOp, startErr := source.UpdateInstanceState(inst.Name(), api.InstanceStatePut{Action: "start"}, "")
+		if startErr == nil {
+			startErr = startOp.Wait()
+		}
 
-			err = inst.Start(false)
-			if err != nil {
-				return fmt.Errorf("Failed to start instance %q: %w", inst.Name(), err)
-			}
+		if startErr != nil {
+			return fmt.Errorf("%w (failed to start instance again: %v)", err, startErr)
 		}
 
-		revert.Success()
+		return err
+	}
+
+	migrationOp, err := source.UseTarget(originName).MigrateInstance(inst.Name(), req)
+	if err != nil {
+		return restartOnFailure(fmt.Errorf("Migration API failure: %w", err))
+	}
+
+	err = migrationOp.Wait()
+	if err != nil {
+		return restartOnFailure(fmt.Errorf("Failed to wait for migration to finish: %w", err))
+	}
+
+	// Reload the instance after migration.
+	inst, err = instance.LoadByProjectAndName(s, inst.Project().Name, inst.Name())
+	if err != nil {
+		return fmt.Errorf("Failed to load instance: %w", err)
+	}
+
+	config := inst.LocalConfig()
+	delete(config, "volatile.evacuate.origin")
+
+	args := db.InstanceArgs{
+		Architecture: inst.Architecture(),
+		Config:       config,
+		Description:  inst.Description(),
+		Devices:      inst.LocalDevices(),
+		Ephemeral:    inst.IsEphemeral(),
+		Profiles:     inst.Profiles(),
+		Project:      inst.Project().Name,
+		ExpiryDate:   inst.ExpiryDate(),
+	}
+
+	err = inst.Update(args, false)
+	if err != nil {
+		return fmt.Errorf("Failed to update instance %q: %w", inst.Name(), err)
+	}
+
+	if !isRunning || live {
 		return nil
 	}
 
-	op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.ClusterMemberRestore, nil, nil, run, nil, nil, r)
+	metadata["evacuation_progress"] = fmt.Sprintf("Starting %q in project %q", inst.Name(), inst.Project().Name)
+	_ = op.UpdateMetadata(metadata)
+
+	err = inst.Start(false)
 	if err != nil {
-		return response.InternalError(err)
+		return fmt.Errorf("Failed to start instance %q: %w", inst.Name(), err)
 	}
 
-	return operations.OperationResponse(op)
+	return nil
 }
 
 // swagger:operation POST /1.0/cluster/groups cluster cluster_groups_post
@@ -3643,6 +4874,11 @@ func clusterGroupsPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	err = clusterValidateConfig(req.Config)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		obj := dbCluster.ClusterGroup{
 			Name:        req.Name,
@@ -3655,6 +4891,11 @@ func clusterGroupsPost(d *Daemon, r *http.Request) response.Response {
 			return err
 		}
 
+		err = dbCluster.CreateClusterGroupConfig(ctx, tx.Tx(), groupID, req.Config)
+		if err != nil {
+			return err
+		}
+
 		for _, node := range obj.Nodes {
 			_, err = dbCluster.CreateNodeClusterGroup(ctx, tx.Tx(), dbCluster.NodeClusterGroup{GroupID: int(groupID), Node: node})
 			if err != nil {
@@ -3662,7 +4903,7 @@ func clusterGroupsPost(d *Daemon, r *http.Request) response.Response {
 			}
 		}
 
-		return nil
+		return clusterGroupValidateMembersConfig(ctx, tx, obj.Nodes)
 	})
 	if err != nil {
 		return response.SmartError(err)
@@ -3785,6 +5026,11 @@ func clusterGroupsGet(d *Daemon, r *http.Request) response.Response {
 				for _, node := range nodeClusterGroups {
 					clusterGroups[i].Nodes = append(clusterGroups[i].Nodes, node.Node)
 				}
+
+				clusterGroups[i].Config, err = dbCluster.GetClusterGroupConfig(ctx, tx.Tx(), clusterGroups[i].ID)
+				if err != nil {
+					return err
+				}
 			}
 
 			apiClusterGroups := make([]*api.ClusterGroup, len(clusterGroups))
@@ -3876,6 +5122,11 @@ func clusterGroupGet(d *Daemon, r *http.Request) response.Response {
 			group.Nodes = append(group.Nodes, node.Node)
 		}
 
+		group.Config, err = dbCluster.GetClusterGroupConfig(ctx, tx.Tx(), group.ID)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -4020,6 +5271,11 @@ func clusterGroupPut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	err = clusterValidateConfig(req.Config)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		group, err := dbCluster.GetClusterGroup(ctx, tx.Tx(), name)
 		if err != nil {
@@ -4036,6 +5292,11 @@ func clusterGroupPut(d *Daemon, r *http.Request) response.Response {
 			return err
 		}
 
+		err = dbCluster.UpdateClusterGroupConfig(ctx, tx.Tx(), int64(group.ID), req.Config)
+		if err != nil {
+			return err
+		}
+
 		members, err := tx.GetClusterGroupNodes(ctx, name)
 		if err != nil {
 			return err
@@ -4079,7 +5340,17 @@ func clusterGroupPut(d *Daemon, r *http.Request) response.Response {
 			}
 		}
 
-		return nil
+		err = clusterGroupsCheckNotEmptied(ctx, tx, []string{name})
+		if err != nil {
+			return err
+		}
+
+		members, err = tx.GetClusterGroupNodes(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		return clusterGroupValidateMembersConfig(ctx, tx, members)
 	})
 	if err != nil {
 		return response.SmartError(err)
@@ -4151,6 +5422,11 @@ func clusterGroupPatch(d *Daemon, r *http.Request) response.Response {
 			dbClusterGroup.Nodes = append(dbClusterGroup.Nodes, node.Node)
 		}
 
+		dbClusterGroup.Config, err = dbCluster.GetClusterGroupConfig(ctx, tx.Tx(), dbClusterGroup.ID)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -4181,6 +5457,11 @@ func clusterGroupPatch(d *Daemon, r *http.Request) response.Response {
 		req.Members = clusterGroup.Members
 	}
 
+	err = clusterValidateConfig(req.Config)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		obj := dbCluster.ClusterGroup{
 			Name:        dbClusterGroup.Name,
@@ -4197,6 +5478,11 @@ func clusterGroupPatch(d *Daemon, r *http.Request) response.Response {
 			return err
 		}
 
+		err = dbCluster.UpdateClusterGroupConfig(ctx, tx.Tx(), groupID, req.Config)
+		if err != nil {
+			return err
+		}
+
 		err = dbCluster.DeleteNodeClusterGroup(ctx, tx.Tx(), int(groupID))
 		if err != nil {
 			return err
@@ -4253,7 +5539,17 @@ func clusterGroupPatch(d *Daemon, r *http.Request) response.Response {
 			}
 		}
 
-		return nil
+		err = clusterGroupsCheckNotEmptied(ctx, tx, []string{name})
+		if err != nil {
+			return err
+		}
+
+		members, err = tx.GetClusterGroupNodes(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		return clusterGroupValidateMembersConfig(ctx, tx, members)
 	})
 	if err != nil {
 		return response.SmartError(err)
@@ -4303,20 +5599,61 @@ func clusterGroupDelete(d *Daemon, r *http.Request) response.Response {
 		}
 
 		if len(members) > 0 {
-			return fmt.Errorf("Only empty cluster groups can be removed")
+			return fmt.Errorf("Only empty cluster groups can be removed")
+		}
+
+		err = clusterGroupCheckNoInstances(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+
+		return dbCluster.DeleteClusterGroup(ctx, tx.Tx(), name)
+	})
+
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(name, lifecycle.ClusterGroupDeleted.Event(name, requestor, nil))
+
+	return response.EmptySyncResponse
+}
+
+// clusterGroupCheckNoInstances returns an error naming the instances placed through the cluster group, if any.
+func clusterGroupCheckNoInstances(ctx context.Context, tx *db.ClusterTx, name string) error {
+	instances, err := tx.GetClusterGroupInstances(ctx, name)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances of cluster group %q: %w", name, err)
+	}
+
+	if len(instances) > 0 {
+		return api.StatusErrorf(http.StatusBadRequest, "Cluster group %q is still used for the placement of instances: %s", name, strings.Join(instances, ", "))
+	}
+
+	return nil
+}
+
+// clusterGroupsCheckNotEmptied checks that none of the given cluster groups was left without members while
+// instances are still placed through it.
+func clusterGroupsCheckNotEmptied(ctx context.Context, tx *db.ClusterTx, groups []string) error {
+	for _, group := range groups {
+		members, err := tx.GetClusterGroupNodes(ctx, group)
+		if err != nil {
+			return err
+		}
+
+		if len(members) > 0 {
+			continue
 		}
 
-		return dbCluster.DeleteClusterGroup(ctx, tx.Tx(), name)
-	})
-
-	if err != nil {
-		return response.SmartError(err)
+		err = clusterGroupCheckNoInstances(ctx, tx, group)
+		if err != nil {
+			return fmt.Errorf("Cannot remove the last member of cluster group %q: %w", group, err)
+		}
 	}
 
-	requestor := request.CreateRequestor(r)
-	s.Events.SendLifecycle(name, lifecycle.ClusterGroupDeleted.Event(name, requestor, nil))
-
-	return response.EmptySyncResponse
+	return nil
 }
 
 func clusterGroupValidateName(name string) error {
@@ -4351,9 +5688,46 @@ func clusterGroupValidateName(name string) error {
 	return nil
 }
 
-func evacuateClusterSelectTarget(ctx context.Context, s *state.State, gateway *cluster.Gateway, inst instance.Instance) (*db.NodeInfo, *db.NodeInfo, error) {
+// clusterGroupValidateMembersConfig checks that the defaults the given members inherit from their cluster groups don't conflict.
+func clusterGroupValidateMembersConfig(ctx context.Context, tx *db.ClusterTx, members []string) error {
+	for _, member := range members {
+		memberInfo, err := tx.GetNodeByName(ctx, member)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.GetClusterGroupsConfig(ctx, memberInfo.Groups, memberInfo.Config)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Invalid configuration for cluster member %q: %v", member, err)
+		}
+	}
+
+	return nil
+}
+
+// evacuateInstanceHasRemoteStorage returns whether the instance's root disk is on remote storage, in which case
+// moving it to another member doesn't require copying its data.
+func evacuateInstanceHasRemoteStorage(s *state.State, inst instance.Instance) (bool, error) {
+	poolName, err := inst.StoragePool()
+	if err != nil {
+		return false, fmt.Errorf("Failed getting storage pool of instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return false, fmt.Errorf("Failed loading storage pool %q: %w", poolName, err)
+	}
+
+	return pool.Driver().Info().Remote, nil
+}
+
+// evacuateClusterSelectTarget finds the member to move the instance to and returns it along with the reason it was chosen.
+// The planned instance counts, indexed by member ID, are added to the members' load when picking the least loaded one.
+// The optional memberResources, gathered ahead of the evacuation, are handed to the instance placement scriptlet.
+func evacuateClusterSelectTarget(ctx context.Context, s *state.State, gateway *cluster.Gateway, inst instance.Instance, planned map[int64]int, memberResources map[string]*api.Resources) (*db.NodeInfo, *db.NodeInfo, string, error) {
 	var sourceMemberInfo *db.NodeInfo
 	var targetMemberInfo *db.NodeInfo
+	var scope string
 
 	// Get candidate cluster members to move instances to.
 	var candidateMembers []db.NodeInfo
@@ -4371,19 +5745,30 @@ func evacuateClusterSelectTarget(ctx context.Context, s *state.State, gateway *c
 			return fmt.Errorf("Failed getting cluster members: %w", err)
 		}
 
-		// Filter candidates by group if needed.
+		// Filter candidates by group if needed, never considering the source member.
 		group := inst.LocalConfig()["volatile.cluster.group"]
-		if group != "" {
-			newMembers := make([]db.NodeInfo, 0, len(allMembers))
-			for _, member := range allMembers {
-				if !slices.Contains(member.Groups, group) {
-					continue
-				}
+		newMembers := make([]db.NodeInfo, 0, len(allMembers))
+		for _, member := range allMembers {
+			if member.ID == srcMember.ID {
+				continue
+			}
 
-				newMembers = append(newMembers, member)
+			if group != "" && !slices.Contains(member.Groups, group) {
+				continue
 			}
 
-			allMembers = newMembers
+			newMembers = append(newMembers, member)
+		}
+
+		allMembers = newMembers
+
+		scope = "online members"
+		if group != "" {
+			scope = fmt.Sprintf("online members of cluster group %q", group)
+
+			if len(allMembers) == 0 {
+				return api.StatusErrorf(http.StatusNotFound, "No cluster member in cluster group %q", group)
+			}
 		}
 
 		// Filter offline servers.
@@ -4392,17 +5777,33 @@ func evacuateClusterSelectTarget(ctx context.Context, s *state.State, gateway *c
 			return err
 		}
 
+		if len(candidateMembers) == 0 {
+			return api.StatusErrorf(http.StatusNotFound, "No online cluster member available for scheduling with a compatible architecture")
+		}
+
+		// Prefer keeping the instance within the failure domain.
+		domainMembers, err := evacuateClusterFilterFailureDomain(ctx, tx, srcMember, candidateMembers)
+		if err != nil {
+			return err
+		}
+
+		if len(domainMembers) < len(candidateMembers) {
+			scope += " in the preferred failure domain"
+		}
+
+		candidateMembers = domainMembers
+
 		return nil
 	})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 
 	// Run instance placement scriptlet if enabled.
 	if s.GlobalConfig.InstancesPlacementScriptlet() != "" {
 		leaderAddress, err := gateway.LeaderAddress()
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, "", err
 		}
 
 		// Copy request so we don't modify it when expanding the config.
@@ -4421,7 +5822,7 @@ func evacuateClusterSelectTarget(ctx context.Context, s *state.State, gateway *c
 
 		reqExpanded.Architecture, err = osarch.ArchitectureName(inst.Architecture())
 		if err != nil {
-			return nil, nil, fmt.Errorf("Failed getting architecture for instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
+			return nil, nil, "", fmt.Errorf("Failed getting architecture for instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
 		}
 
 		for _, p := range inst.Profiles() {
@@ -4429,34 +5830,205 @@ func evacuateClusterSelectTarget(ctx context.Context, s *state.State, gateway *c
 		}
 
 		ctx, cancel := context.WithTimeout(ctx, time.Second*5)
-		targetMemberInfo, err = scriptlet.InstancePlacementRun(ctx, logger.Log, s, &reqExpanded, candidateMembers, leaderAddress)
+		targetMemberInfo, err = scriptlet.InstancePlacementRun(ctx, logger.Log, s, &reqExpanded, candidateMembers, memberResources, leaderAddress)
 		if err != nil {
 			cancel()
-			return nil, nil, fmt.Errorf("Failed instance placement scriptlet for instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
+			return nil, nil, "", fmt.Errorf("Failed instance placement scriptlet for instance %q in project %q: %w", inst.Name(), inst.Project().Name, err)
 		}
 
 		cancel()
+
+		if targetMemberInfo != nil {
+			return sourceMemberInfo, targetMemberInfo, "Selected by the instance placement scriptlet", nil
+		}
 	}
 
 	// If target member not specified yet, then find the least loaded cluster member which
 	// supports the instance's architecture.
-	if targetMemberInfo == nil {
-		var err error
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		targetMemberInfo, err = tx.GetNodeWithLeastInstancesPlanned(ctx, candidateMembers, planned)
+		if err != nil {
+			return err
+		}
 
-		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
-			targetMemberInfo, err = tx.GetNodeWithLeastInstances(ctx, candidateMembers)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return sourceMemberInfo, targetMemberInfo, fmt.Sprintf("Fewest instances among the %s", scope), nil
+}
+
+// evacuateClusterSelectRequestedTarget finds the member to move the instance to among the requested targets and returns
+// it along with the reason it was chosen. Unlike automatic placement, it fails when none of the targets can host the
+// instance, giving the reason for each of them.
+// The instance gets accounted for in the chosen target's resources, so calls must not run concurrently.
+func evacuateClusterSelectRequestedTarget(ctx context.Context, s *state.State, inst instance.Instance, targets []string, resources map[string]*api.ClusterMemberResources, planned map[int64]int) (*db.NodeInfo, *db.NodeInfo, string, error) {
+	var sourceMemberInfo *db.NodeInfo
+	var targetMemberInfo *db.NodeInfo
+
+	problems := []string{}
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		// Get the source member info.
+		srcMember, err := tx.GetNodeByName(ctx, inst.Location())
+		if err != nil {
+			return fmt.Errorf("Failed getting current cluster member of instance %q", inst.Name())
+		}
+
+		sourceMemberInfo = &srcMember
+
+		candidateMembers := make([]db.NodeInfo, 0, len(targets))
+		for _, target := range targets {
+			member, err := tx.GetNodeByName(ctx, target)
+			if err != nil {
+				return fmt.Errorf("Failed getting evacuation target %q: %w", target, err)
+			}
+
+			problem, err := evacuateClusterCheckTarget(s, inst, member, resources[member.Name])
 			if err != nil {
 				return err
 			}
 
+			if problem != "" {
+				problems = append(problems, fmt.Sprintf("%q %s", member.Name, problem))
+				continue
+			}
+
+			candidateMembers = append(candidateMembers, member)
+		}
+
+		if len(candidateMembers) == 0 {
 			return nil
-		})
+		}
+
+		targetMemberInfo, err = tx.GetNodeWithLeastInstancesPlanned(ctx, candidateMembers, planned)
 		if err != nil {
-			return nil, nil, err
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	if targetMemberInfo == nil {
+		return nil, nil, "", fmt.Errorf("Instance %q in project %q can't be moved to any of the requested targets: %s", inst.Name(), inst.Project().Name, strings.Join(problems, ", "))
+	}
+
+	// Account for the instance's memory so the following instances don't overcommit the target.
+	memberResources := resources[targetMemberInfo.Name]
+	if memberResources != nil && inst.IsRunning() {
+		memory, _ := evacuateInstanceLimits(inst)
+		memberResources.MemoryUsed += uint64(memory)
+	}
+
+	return sourceMemberInfo, targetMemberInfo, "Fewest instances among the requested targets", nil
+}
+
+// evacuateClusterCheckTarget returns why the member can't host the instance, or an empty string if it can.
+// The resources are only checked when known.
+func evacuateClusterCheckTarget(s *state.State, inst instance.Instance, member db.NodeInfo, resources *api.ClusterMemberResources) (string, error) {
+	if member.Name == inst.Location() {
+		return "is the instance's current member", nil
+	}
+
+	if member.State != db.ClusterMemberStateCreated || member.Cordoned || member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+		return "isn't available for scheduling", nil
+	}
+
+	personalities, err := osarch.ArchitecturePersonalities(member.Architecture)
+	if err != nil {
+		return "", err
+	}
+
+	if !slices.Contains(append([]int{member.Architecture}, personalities...), inst.Architecture()) {
+		return "doesn't support the instance's architecture", nil
+	}
+
+	instProject := inst.Project()
+	err = project.AllowClusterMember(&instProject, &member)
+	if err != nil {
+		return "isn't allowed by the instance's project", nil
+	}
+
+	if resources != nil {
+		memory, cpus := evacuateInstanceLimits(inst)
+
+		if cpus > 0 && uint64(cpus) > resources.CPUTotal {
+			return fmt.Sprintf("only has %d CPUs while the instance is limited to %d", resources.CPUTotal, cpus), nil
+		}
+
+		if memory > 0 && inst.IsRunning() && resources.MemoryUsed+uint64(memory) > resources.MemoryTotal {
+			return "doesn't have enough free memory", nil
+		}
+	}
+
+	return "", nil
+}
+
+// evacuateInstanceLimits returns the memory (in bytes) and number of CPUs the instance is limited to.
+// Zero is returned for limits which aren't set or are relative.
+func evacuateInstanceLimits(inst instance.Instance) (int64, int) {
+	var memory int64
+	var cpus int
+
+	limit := inst.ExpandedConfig()["limits.memory"]
+	if limit != "" && !strings.HasSuffix(limit, "%") {
+		memory, _ = units.ParseByteSizeString(limit)
+	}
+
+	cpus, _ = strconv.Atoi(inst.ExpandedConfig()["limits.cpu"])
+
+	return max(memory, 0), max(cpus, 0)
+}
+
+// evacuateClusterFilterFailureDomain restricts the candidate members to those in the failure domain
+// instances of the source member should be evacuated to. That's the one set in the source member's
+// scheduler.evacuate.failure_domain configuration key, or its own failure domain otherwise.
+// If no candidate is part of that failure domain, all the candidates are returned.
+func evacuateClusterFilterFailureDomain(ctx context.Context, tx *db.ClusterTx, srcMember db.NodeInfo, candidateMembers []db.NodeInfo) ([]db.NodeInfo, error) {
+	domainNames, err := tx.GetFailureDomainsNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting failure domains: %w", err)
+	}
+
+	memberDomains, err := tx.GetNodesFailureDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting cluster member failure domains: %w", err)
+	}
+
+	memberDomainName := func(member db.NodeInfo) string {
+		name, ok := domainNames[memberDomains[member.Address]]
+		if !ok {
+			return "default"
+		}
+
+		return name
+	}
+
+	domain := srcMember.ExpandedConfig()["scheduler.evacuate.failure_domain"]
+	if domain == "" {
+		domain = memberDomainName(srcMember)
+	}
+
+	domainMembers := make([]db.NodeInfo, 0, len(candidateMembers))
+	for _, member := range candidateMembers {
+		if memberDomainName(member) != domain {
+			continue
 		}
+
+		domainMembers = append(domainMembers, member)
+	}
+
+	// Spill over to the other failure domains if none of the candidates is suitable.
+	if len(domainMembers) == 0 {
+		return candidateMembers, nil
 	}
 
-	return sourceMemberInfo, targetMemberInfo, nil
+	return domainMembers, nil
 }
 
 func autoHealClusterTask(d *Daemon) (task.Func, task.Schedule) {
@@ -4564,3 +6136,209 @@ func autoHealCluster(ctx context.Context, s *state.State, offlineMembers []db.No
 
 	return nil
 }
+
+// clusterFailbackBackoffMin is how long failback of an instance is put off after its first failed attempt.
+const clusterFailbackBackoffMin = time.Minute
+
+// clusterFailbackBackoffMax is the longest failback of an instance is put off after repeated failed attempts.
+const clusterFailbackBackoffMax = 6 * time.Hour
+
+// clusterFailbackFailure records the failed attempts at moving an instance back to its origin member.
+type clusterFailbackFailure struct {
+	count int
+	last  time.Time
+}
+
+// due returns whether failback of the instance can be attempted again.
+// The delay doubles with every failed attempt, up to clusterFailbackBackoffMax.
+func (f clusterFailbackFailure) due(now time.Time) bool {
+	if f.count == 0 {
+		return true
+	}
+
+	delay := clusterFailbackBackoffMax
+	if f.count <= 16 {
+		delay = min(clusterFailbackBackoffMin<<(f.count-1), clusterFailbackBackoffMax)
+	}
+
+	return !now.Before(f.last.Add(delay))
+}
+
+// clusterFailback migrates evacuated instances back to their origin cluster member once it has been restored
+// and is online again. It's run by the leader on every heartbeat round, rounds are skipped while a previous one
+// or any evacuation or restore is still in progress and instances are only moved once as the migration clears
+// their volatile.evacuate.origin. Instances which fail to be moved are left where they are and only attempted
+// again after an increasing delay.
+func clusterFailback(d *Daemon, unavailableMembers []string) {
+	if !d.clusterFailbackMutex.TryLock() {
+		return // Skip failback if a previous run is still in progress.
+	}
+
+	defer d.clusterFailbackMutex.Unlock()
+
+	if d.clusterFailbackFailures == nil {
+		d.clusterFailbackFailures = map[string]clusterFailbackFailure{}
+	}
+
+	s := d.State()
+
+	var members []db.NodeInfo
+	var evacuatedInstances []db.InstanceArgs
+	busy := false
+
+	err := s.DB.Cluster.Transaction(d.shutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+		ops, err := dbCluster.GetOperations(ctx, tx.Tx())
+		if err != nil {
+			return fmt.Errorf("Failed getting operations: %w", err)
+		}
+
+		for _, op := range ops {
+			if op.Type == operationtype.ClusterMemberEvacuate || op.Type == operationtype.ClusterMemberRestore {
+				busy = true
+				return nil
+			}
+		}
+
+		members, err = tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		return tx.InstanceList(ctx, func(inst db.InstanceArgs, p api.Project) error {
+			origin := inst.Config["volatile.evacuate.origin"]
+			if origin != "" && origin != inst.Node {
+				evacuatedInstances = append(evacuatedInstances, inst)
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		logger.Error("Failed failing back cluster instances", logger.Ctx{"err": err})
+		return
+	}
+
+	if busy || len(evacuatedInstances) == 0 {
+		return // Skip failback if members are being evacuated or restored, or if there is nothing to move.
+	}
+
+	// Only fail back to members which are restored, online and accept instances.
+	offlineThreshold := s.GlobalConfig.OfflineThreshold()
+	onlineMembers := make(map[string]bool, len(members))
+	originMembers := make(map[string]db.NodeInfo, len(members))
+	for _, member := range members {
+		if member.IsOffline(offlineThreshold) || slices.Contains(unavailableMembers, member.Name) {
+			continue
+		}
+
+		onlineMembers[member.Name] = true
+
+		if member.State != db.ClusterMemberStateCreated || member.Cordoned {
+			continue
+		}
+
+		if member.ExpandedConfig()["scheduler.instance"] == "manual" {
+			continue
+		}
+
+		originMembers[member.Name] = member
+	}
+
+	now := time.Now()
+	evacuated := make(map[string]bool, len(evacuatedInstances))
+	var instances []db.InstanceArgs
+	for _, inst := range evacuatedInstances {
+		key := project.Instance(inst.Project, inst.Name)
+		evacuated[key] = true
+
+		// Skip instances which recently failed to be moved back.
+		if !d.clusterFailbackFailures[key].due(now) {
+			continue
+		}
+
+		// Instances can only be moved away from members which are online too.
+		member, ok := originMembers[inst.Config["volatile.evacuate.origin"]]
+		if !ok || !onlineMembers[inst.Node] {
+			continue
+		}
+
+		// Members only accepting instances of their groups keep that restriction on failback.
+		if member.ExpandedConfig()["scheduler.instance"] == "group" && !slices.Contains(member.Groups, inst.Config["volatile.cluster.group"]) {
+			continue
+		}
+
+		instances = append(instances, inst)
+	}
+
+	// Forget about failures of instances which are no longer evacuated.
+	for key := range d.clusterFailbackFailures {
+		if !evacuated[key] {
+			delete(d.clusterFailbackFailures, key)
+		}
+	}
+
+	if len(instances) == 0 {
+		return
+	}
+
+	opRun := func(op *operations.Operation) error {
+		failed := 0
+		for _, dbInst := range instances {
+			key := project.Instance(dbInst.Project, dbInst.Name)
+			l := logger.AddContext(logger.Ctx{"project": dbInst.Project, "instance": dbInst.Name})
+
+			inst, err := instance.LoadByProjectAndName(s, dbInst.Project, dbInst.Name)
+			if err != nil {
+				l.Warn("Failed loading instance for failback", logger.Ctx{"err": err})
+				continue
+			}
+
+			// Check again in case the instance was moved in the meantime.
+			originName := inst.LocalConfig()["volatile.evacuate.origin"]
+			if originName == "" || originName == inst.Location() {
+				continue
+			}
+
+			l.Info("Failing back cluster instance", logger.Ctx{"member": originName})
+
+			err = restoreClusterMemberInstance(s, nil, op, originName, inst)
+			if err != nil {
+				failure := d.clusterFailbackFailures[key]
+				failure.count++
+				failure.last = time.Now()
+				d.clusterFailbackFailures[key] = failure
+
+				l.Warn("Failed failing back cluster instance", logger.Ctx{"member": originName, "attempts": failure.count, "err": err})
+				failed++
+
+				continue
+			}
+
+			delete(d.clusterFailbackFailures, key)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("Failed failing back %d instance(s)", failed)
+		}
+
+		return nil
+	}
+
+	op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.ClusterFailback, nil, nil, opRun, nil, nil, nil)
+	if err != nil {
+		logger.Error("Failed creating cluster instances failback operation", logger.Ctx{"err": err})
+		return
+	}
+
+	err = op.Start()
+	if err != nil {
+		logger.Error("Failed starting cluster instances failback operation", logger.Ctx{"err": err})
+		return
+	}
+
+	err = op.Wait(d.shutdownCtx)
+	if err != nil {
+		logger.Error("Failed failing back cluster instances", logger.Ctx{"err": err})
+		return
+	}
+}
@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/osarch"
 )
 
 // allocatePort asks the kernel for a free open port that is ready to use.
@@ -27,6 +32,25 @@ func allocatePort() (int, error) {
 	return l.Addr().(*net.TCPAddr).Port, l.Close()
 }
 
+// Instances which failed to be moved back are only retried after an increasing delay.
+func TestClusterFailbackFailure_Due(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, clusterFailbackFailure{}.due(now))
+
+	failure := clusterFailbackFailure{count: 1, last: now}
+	assert.False(t, failure.due(now.Add(30*time.Second)))
+	assert.True(t, failure.due(now.Add(time.Minute)))
+
+	failure = clusterFailbackFailure{count: 3, last: now}
+	assert.False(t, failure.due(now.Add(3*time.Minute)))
+	assert.True(t, failure.due(now.Add(4*time.Minute)))
+
+	failure = clusterFailbackFailure{count: 100, last: now}
+	assert.False(t, failure.due(now.Add(clusterFailbackBackoffMax-time.Second)))
+	assert.True(t, failure.due(now.Add(clusterFailbackBackoffMax)))
+}
+
 // A node which is already configured for networking can be converted to a
 // single-node cluster.
 func TestCluster_Bootstrap(t *testing.T) {
@@ -93,6 +117,76 @@ func TestCluster_RenameNode(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Several members can be updated at once, and an invalid update leaves all of them untouched.
+func TestCluster_UpdateMembers(t *testing.T) {
+	daemon, cleanup := newTestDaemon(t)
+	defer cleanup()
+
+	f := clusterFixture{t: t}
+	f.EnableNetworking(daemon, "")
+
+	client := f.ClientUnix(daemon)
+
+	cluster := api.ClusterPut{}
+	cluster.ServerName = "buzz"
+	cluster.Enabled = true
+	op, err := client.UpdateCluster(cluster, "")
+	require.NoError(t, err)
+	require.NoError(t, op.Wait())
+
+	member, _, err := client.GetClusterMember("buzz")
+	require.NoError(t, err)
+
+	memberPut := member.Writable()
+	memberPut.Description = "updated"
+	err = client.UpdateClusterMembers(map[string]api.ClusterMemberPut{"buzz": memberPut})
+	require.NoError(t, err)
+
+	member, _, err = client.GetClusterMember("buzz")
+	require.NoError(t, err)
+	assert.Equal(t, "updated", member.Description)
+
+	// The unknown member fails the whole update.
+	memberPut.Description = "not applied"
+	err = client.UpdateClusterMembers(map[string]api.ClusterMemberPut{"buzz": memberPut, "rusp": memberPut})
+	require.Error(t, err)
+
+	member, _, err = client.GetClusterMember("buzz")
+	require.NoError(t, err)
+	assert.Equal(t, "updated", member.Description)
+
+	// An empty update is rejected.
+	err = client.UpdateClusterMembers(map[string]api.ClusterMemberPut{})
+	require.Error(t, err)
+}
+
+// Only evacuations can be dry-run.
+func TestCluster_StateDryRun(t *testing.T) {
+	daemon, cleanup := newTestDaemon(t)
+	defer cleanup()
+
+	f := clusterFixture{t: t}
+	f.EnableNetworking(daemon, "")
+
+	client := f.ClientUnix(daemon)
+
+	cluster := api.ClusterPut{}
+	cluster.ServerName = "buzz"
+	cluster.Enabled = true
+	op, err := client.UpdateCluster(cluster, "")
+	require.NoError(t, err)
+	require.NoError(t, op.Wait())
+
+	for _, action := range []string{"restore", "drain", "uncordon"} {
+		_, err = client.UpdateClusterMemberState("buzz", api.ClusterMemberStatePost{Action: action, DryRun: true})
+		require.ErrorContains(t, err, "Dry-run is only supported when evacuating", action)
+	}
+
+	member, _, err := client.GetClusterMember("buzz")
+	require.NoError(t, err)
+	assert.Equal(t, "Online", member.Status)
+}
+
 // Test helper for cluster-related APIs.
 type clusterFixture struct {
 	t       *testing.T
@@ -151,3 +245,66 @@ func (f *clusterFixture) ClientUnix(daemon *Daemon) incus.InstanceServer {
 
 	return client
 }
+
+// Evacuation targets are checked for their availability, architecture and resources.
+func (suite *containerTestSuite) TestEvacuateClusterCheckTarget() {
+	args := db.InstanceArgs{
+		Type:         instancetype.Container,
+		Ephemeral:    false,
+		Name:         "testFoo",
+		Architecture: osarch.ARCH_64BIT_INTEL_X86,
+		Config: map[string]string{
+			"limits.cpu":    "4",
+			"limits.memory": "4GiB",
+		},
+	}
+
+	c, op, _, err := instance.CreateInternal(suite.d.State(), args, true, true)
+	suite.Req.Nil(err)
+	op.Done(nil)
+	defer func() { _ = c.Delete(true) }()
+
+	newMember := func(name string) db.NodeInfo {
+		return db.NodeInfo{
+			Name:         name,
+			Architecture: osarch.ARCH_64BIT_INTEL_X86,
+			State:        db.ClusterMemberStateCreated,
+			Heartbeat:    time.Now(),
+		}
+	}
+
+	evacuated := newMember("evacuated")
+	evacuated.State = db.ClusterMemberStateEvacuated
+
+	cordoned := newMember("cordoned")
+	cordoned.Cordoned = true
+
+	offline := newMember("offline")
+	offline.Heartbeat = time.Now().Add(-time.Hour)
+
+	arm := newMember("arm")
+	arm.Architecture = osarch.ARCH_64BIT_ARMV8_LITTLE_ENDIAN
+
+	tests := []struct {
+		name      string
+		member    db.NodeInfo
+		resources *api.ClusterMemberResources
+		reason    string
+	}{
+		{name: "Current member", member: newMember(c.Location()), reason: "is the instance's current member"},
+		{name: "Evacuated member", member: evacuated, reason: "isn't available for scheduling"},
+		{name: "Cordoned member", member: cordoned, reason: "isn't available for scheduling"},
+		{name: "Offline member", member: offline, reason: "isn't available for scheduling"},
+		{name: "Other architecture", member: arm, reason: "doesn't support the instance's architecture"},
+		{name: "Too few CPUs", member: newMember("small"), resources: &api.ClusterMemberResources{CPUTotal: 2, MemoryTotal: 8 * 1024 * 1024 * 1024}, reason: "only has 2 CPUs while the instance is limited to 4"},
+		{name: "Memory only checked for running instances", member: newMember("busy"), resources: &api.ClusterMemberResources{CPUTotal: 8, MemoryTotal: 8 * 1024 * 1024 * 1024, MemoryUsed: 6 * 1024 * 1024 * 1024}},
+		{name: "Without resources", member: newMember("unknown")},
+		{name: "Suitable member", member: newMember("large"), resources: &api.ClusterMemberResources{CPUTotal: 8, MemoryTotal: 8 * 1024 * 1024 * 1024}},
+	}
+
+	for _, test := range tests {
+		reason, err := evacuateClusterCheckTarget(suite.d.State(), c, test.member, test.resources)
+		suite.Req.Nil(err, test.name)
+		suite.Equal(test.reason, reason, test.name)
+	}
+}
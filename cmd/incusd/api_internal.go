@@ -23,6 +23,7 @@ import (
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/revert"
+	"github.com/lxc/incus/v6/internal/server/apparmor"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/backup"
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -48,16 +49,19 @@ import (
 )
 
 var apiInternal = []APIEndpoint{
+	internalAutostartCmd,
 	internalBGPStateCmd,
 	internalClusterAcceptCmd,
 	internalClusterAssignCmd,
 	internalClusterHandoverCmd,
 	internalClusterRaftNodeCmd,
+	internalClusterRaftNodesCmd,
 	internalClusterRebalanceCmd,
 	internalClusterHealCmd,
 	internalContainerOnStartCmd,
 	internalContainerOnStopCmd,
 	internalContainerOnStopNSCmd,
+	internalForkproxyProfileCmd,
 	internalGarbageCollectorCmd,
 	internalImageOptimizeCmd,
 	internalImageRefreshCmd,
@@ -123,6 +127,12 @@ var internalImageRefreshCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: internalRefreshImage, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+var internalForkproxyProfileCmd = APIEndpoint{
+	Path: "testing/forkproxy-profile/{name}/{device}",
+
+	Get: APIEndpointAction{Handler: internalForkproxyProfile, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
 var internalImageOptimizeCmd = APIEndpoint{
 	Path: "image-optimize",
 
@@ -141,11 +151,22 @@ var internalBGPStateCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: internalBGPState, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+var internalAutostartCmd = APIEndpoint{
+	Path: "testing/autostart/{name}",
+
+	Post: APIEndpointAction{Handler: internalAutostart, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
 type internalImageOptimizePost struct {
 	Image api.Image `json:"image" yaml:"image"`
 	Pool  string    `json:"pool"  yaml:"pool"`
 }
 
+type internalAutostartResult struct {
+	Autostart bool `json:"autostart" yaml:"autostart"`
+	Started   bool `json:"started"   yaml:"started"`
+}
+
 type internalWarningCreatePost struct {
 	Location       string `json:"location"         yaml:"location"`
 	Project        string `json:"project"          yaml:"project"`
@@ -196,6 +217,83 @@ func internalCreateWarning(d *Daemon, r *http.Request) response.Response {
 	return response.EmptySyncResponse
 }
 
+// internalAutostart evaluates the autostart decision for an instance and starts it if needed.
+// It runs the same logic as on daemon startup and is used for testing only.
+func internalAutostart(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, request.ProjectParam(r), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if s.ServerClustered && inst.Location() != s.ServerName {
+		return response.BadRequest(fmt.Errorf("Instance %q is located on member %q", inst.Name(), inst.Location()))
+	}
+
+	result := internalAutostartResult{
+		Autostart: instanceShouldAutoStart(inst),
+	}
+
+	if result.Autostart && !inst.IsRunning() {
+		instancesStart(s, []instance.Instance{inst})
+		result.Started = inst.IsRunning()
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// internalForkproxyDevice is a minimal proxy device used to render its AppArmor profile.
+type internalForkproxyDevice struct {
+	name   string
+	config deviceConfig.Device
+}
+
+func (d *internalForkproxyDevice) Name() string {
+	return d.name
+}
+
+func (d *internalForkproxyDevice) Config() deviceConfig.Device {
+	return d.config
+}
+
+// internalForkproxyProfile renders the AppArmor profile of a proxy device without loading it.
+func internalForkproxyProfile(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	devName, err := url.PathUnescape(mux.Vars(r)["device"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, request.ProjectParam(r), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	devConfig, ok := inst.ExpandedDevices()[devName]
+	if !ok || devConfig["type"] != "proxy" {
+		return response.NotFound(fmt.Errorf("Proxy device %q not found", devName))
+	}
+
+	profile, err := apparmor.ForkproxyProfile(s.OS, inst, &internalForkproxyDevice{name: devName, config: devConfig})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, profile)
+}
+
 func internalOptimizeImage(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
 
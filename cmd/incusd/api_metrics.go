@@ -22,6 +22,8 @@ import (
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v6/internal/server/storage/drivers"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 )
@@ -34,6 +36,10 @@ type metricsCacheEntry struct {
 var metricsCache map[string]metricsCacheEntry
 var metricsCacheLock sync.Mutex
 
+// storagePoolMetricsCache holds the storage pool metrics so that scrapes don't query the storage every time.
+var storagePoolMetricsCache metricsCacheEntry
+var storagePoolMetricsCacheLock sync.Mutex
+
 var metricsCmd = APIEndpoint{
 	Path: "metrics",
 
@@ -97,10 +103,14 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 
 	// Prepare response.
 	metricSet := metrics.NewMetricSet(nil)
+	serverMetrics := metrics.NewMetricSet(nil)
 
 	var projectNames []string
+	var poolNames []string
 
 	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
 		// Figure out the projects to retrieve.
 		if projectName != "" {
 			projectNames = []string{projectName}
@@ -117,8 +127,14 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 			}
 		}
 
+		// Get the storage pools to report on.
+		poolNames, err = tx.GetCreatedStoragePoolNames(ctx)
+		if err != nil && !response.IsNotFoundError(err) {
+			return fmt.Errorf("Failed loading storage pools: %w", err)
+		}
+
 		// Add internal metrics.
-		metricSet.Merge(internalMetrics(ctx, s.StartTime, tx))
+		serverMetrics.Merge(internalMetrics(ctx, s.StartTime, tx))
 
 		return nil
 	})
@@ -126,6 +142,10 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Add storage pool metrics.
+	serverMetrics.Merge(storagePoolMetrics(s, poolNames))
+	metricSet.Merge(serverMetrics)
+
 	// invalidProjectFilters returns project filters which are either not in cache or have expired.
 	invalidProjectFilters := func(projectNames []string) []dbCluster.InstanceFilter {
 		metricsCacheLock.Lock()
@@ -174,8 +194,9 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 
 	defer unlock()
 
-	// Setup a new response.
+	// Setup a new response, keeping the server metrics.
 	metricSet = metrics.NewMetricSet(nil)
+	metricSet.Merge(serverMetrics)
 
 	// Check if any of the missing data has been filled in since acquiring the lock.
 	// As its possible another request was already populating the cache when we tried to take the lock.
@@ -367,3 +388,39 @@ func internalMetrics(ctx context.Context, daemonStartTime time.Time, tx *db.Clus
 
 	return out
 }
+
+// storagePoolMetrics returns the driver specific metrics of the given storage pools on this server.
+// The metrics are cached for a few seconds and only a single request gathers them at a time.
+func storagePoolMetrics(s *state.State, poolNames []string) *metrics.MetricSet {
+	storagePoolMetricsCacheLock.Lock()
+	defer storagePoolMetricsCacheLock.Unlock()
+
+	if storagePoolMetricsCache.metrics != nil && storagePoolMetricsCache.expiry.After(time.Now()) {
+		return storagePoolMetricsCache.metrics
+	}
+
+	out := metrics.NewMetricSet(nil)
+
+	for _, poolName := range poolNames {
+		pool, err := storagePools.LoadByName(s, poolName)
+		if err != nil {
+			logger.Warn("Failed loading storage pool", logger.Ctx{"pool": poolName, "err": err})
+			continue
+		}
+
+		poolMetrics, err := pool.Driver().GetMetrics()
+		if err != nil {
+			if !errors.Is(err, storageDrivers.ErrNotSupported) {
+				logger.Warn("Failed getting storage pool metrics", logger.Ctx{"pool": poolName, "err": err})
+			}
+
+			continue
+		}
+
+		out.Merge(poolMetrics)
+	}
+
+	storagePoolMetricsCache = metricsCacheEntry{metrics: out, expiry: time.Now().Add(8 * time.Second)}
+
+	return out
+}
@@ -6,14 +6,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
 	"net/url"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -1072,6 +1075,65 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Compression algorithm to use for backups
 		"backups.compression_algorithm": validate.IsCompressionAlgorithm,
 
+		// gendoc:generate(entity=project, group=specific, key=backups.retention.age)
+		// Specify an expression like `1M 2H 3d 4w 5m 6y`.
+		// Instance backups older than this are automatically deleted.
+		// ---
+		//  type: string
+		//  shortdesc: How long to keep instance backups
+		"backups.retention.age": func(value string) error {
+			if value == "" {
+				return nil
+			}
+
+			_, err := internalInstance.GetExpiry(time.Time{}, value)
+			return err
+		},
+
+		// gendoc:generate(entity=project, group=specific, key=backups.retention.count)
+		// Only the most recent backups of each instance are kept, older ones are automatically deleted.
+		// ---
+		//  type: integer
+		//  shortdesc: Number of backups to keep for each instance
+		"backups.retention.count": validate.Optional(validate.IsInRange(1, math.MaxInt32)),
+
+		// gendoc:generate(entity=project, group=specific, key=backups.target.access_key)
+		//
+		// ---
+		//  type: string
+		//  shortdesc: Access key of the backup target
+		"backups.target.access_key": validate.IsAny,
+
+		// gendoc:generate(entity=project, group=specific, key=backups.target.secret_key)
+		//
+		// ---
+		//  type: string
+		//  shortdesc: Secret key of the backup target
+		"backups.target.secret_key": validate.IsAny,
+
+		// gendoc:generate(entity=project, group=specific, key=backups.target.url)
+		// Specify the URL of an S3 bucket, optionally followed by a path, like `https://s3.example.com/bucket/path`.
+		// Instance backups of the project are then written to it instead of being stored on the server.
+		// ---
+		//  type: string
+		//  shortdesc: S3 URL to upload instance backups to
+		"backups.target.url": validate.Optional(func(value string) error {
+			targetURL, err := url.Parse(value)
+			if err != nil {
+				return err
+			}
+
+			if targetURL.Scheme != "http" && targetURL.Scheme != "https" {
+				return fmt.Errorf("Backup target URL must use HTTP or HTTPS")
+			}
+
+			if strings.Trim(targetURL.Path, "/") == "" {
+				return fmt.Errorf("Backup target URL must include a bucket name")
+			}
+
+			return nil
+		}),
+
 		// gendoc:generate(entity=project, group=features, key=features.profiles)
 		//
 		// ---
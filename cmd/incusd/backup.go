@@ -1,15 +1,22 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"net"
+	"net/url"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
 
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/instancewriter"
 	"github.com/lxc/incus/v6/internal/revert"
 	"github.com/lxc/incus/v6/internal/server/backup"
@@ -23,9 +30,11 @@ import (
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/state"
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	"github.com/lxc/incus/v6/internal/server/storage/s3"
 	"github.com/lxc/incus/v6/internal/server/task"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/archive"
 	"github.com/lxc/incus/v6/shared/idmap"
 	"github.com/lxc/incus/v6/shared/ioprogress"
 	"github.com/lxc/incus/v6/shared/logger"
@@ -77,57 +86,79 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		return fmt.Errorf("Load backup object: %w", err)
 	}
 
+	var p *api.Project
+	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		project, err := dbCluster.GetProject(ctx, tx.Tx(), sourceInst.Project().Name)
+		if err != nil {
+			return err
+		}
+
+		p, err = project.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
 	// Detect compression method.
 	var compress string
 	b.SetCompressionAlgorithm(args.CompressionAlgorithm)
 	if b.CompressionAlgorithm() != "" {
 		compress = b.CompressionAlgorithm()
+	} else if p.Config["backups.compression_algorithm"] != "" {
+		compress = p.Config["backups.compression_algorithm"]
 	} else {
-		var p *api.Project
-		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-			project, err := dbCluster.GetProject(ctx, tx.Tx(), sourceInst.Project().Name)
+		compress = s.GlobalConfig.BackupsCompressionAlgorithm()
+	}
+
+	var tarFileWriter io.WriteCloser
+	var uploadRes chan error
+	var objectName string
+
+	if p.Config["backups.target.url"] != "" {
+		// Stream the tarball straight to the project's backup target.
+		l.Debug("Uploading backup tarball", logger.Ctx{"url": p.Config["backups.target.url"]})
+		uploadReader, uploadWriter := io.Pipe()
+		tarFileWriter = uploadWriter
+		uploadRes = make(chan error, 1)
+
+		// Abort the upload rather than completing it with a partial tarball on failure.
+		defer func() { _ = uploadWriter.CloseWithError(fmt.Errorf("Backup creation failed")) }()
+
+		go func() {
+			var err error
+			objectName, err = backupUpload(p.Name, p.Config, b.Name(), uploadReader)
+
+			// Make the tarball writer fail if the upload stopped early.
+			_ = uploadReader.CloseWithError(err)
+			uploadRes <- err
+		}()
+	} else {
+		// Create the target path if needed.
+		backupsPath := internalUtil.VarPath("backups", "instances", project.Instance(sourceInst.Project().Name, sourceInst.Name()))
+		if !util.PathExists(backupsPath) {
+			err := os.MkdirAll(backupsPath, 0700)
 			if err != nil {
 				return err
 			}
 
-			p, err = project.ToAPI(ctx, tx.Tx())
-
-			return err
-		})
-		if err != nil {
-			return err
+			revert.Add(func() { _ = os.Remove(backupsPath) })
 		}
 
-		if p.Config["backups.compression_algorithm"] != "" {
-			compress = p.Config["backups.compression_algorithm"]
-		} else {
-			compress = s.GlobalConfig.BackupsCompressionAlgorithm()
-		}
-	}
+		target := internalUtil.VarPath("backups", "instances", project.Instance(sourceInst.Project().Name, b.Name()))
 
-	// Create the target path if needed.
-	backupsPath := internalUtil.VarPath("backups", "instances", project.Instance(sourceInst.Project().Name, sourceInst.Name()))
-	if !util.PathExists(backupsPath) {
-		err := os.MkdirAll(backupsPath, 0700)
+		// Setup the tarball writer.
+		l.Debug("Opening backup tarball for writing", logger.Ctx{"path": target})
+		tarFileWriter, err = os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0600)
 		if err != nil {
-			return err
+			return fmt.Errorf("Error opening backup tarball for writing %q: %w", target, err)
 		}
 
-		revert.Add(func() { _ = os.Remove(backupsPath) })
+		defer func() { _ = tarFileWriter.Close() }()
+		revert.Add(func() { _ = os.Remove(target) })
 	}
 
-	target := internalUtil.VarPath("backups", "instances", project.Instance(sourceInst.Project().Name, b.Name()))
-
-	// Setup the tarball writer.
-	l.Debug("Opening backup tarball for writing", logger.Ctx{"path": target})
-	tarFileWriter, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return fmt.Errorf("Error opening backup tarball for writing %q: %w", target, err)
-	}
-
-	defer func() { _ = tarFileWriter.Close() }()
-	revert.Add(func() { _ = os.Remove(target) })
-
 	// Get IDMap to unshift container as the tarball is created.
 	var idmapSet *idmap.Set
 	if sourceInst.Type() == instancetype.Container {
@@ -143,6 +174,9 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 	defer func() { _ = tarPipeWriter.Close() }() // Ensure that go routine below always ends.
 	tarWriter := instancewriter.NewInstanceTarWriter(tarPipeWriter, idmapSet)
 
+	// Record the checksums of the files for the backup manifest.
+	tarWriter.EnableChecksums()
+
 	// Setup tar writer go routine, with optional compression.
 	tarWriterRes := make(chan error)
 	var compressErr error
@@ -183,7 +217,7 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 
 	// Write index file.
 	l.Debug("Adding backup index file")
-	err = backupWriteIndex(sourceInst, pool, b.OptimizedStorage(), !b.InstanceOnly(), tarWriter)
+	err = backupWriteIndex(s, sourceInst, pool, b.OptimizedStorage(), !b.InstanceOnly(), tarWriter)
 
 	// Check compression errors.
 	if compressErr != nil {
@@ -200,6 +234,13 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		return fmt.Errorf("Backup create: %w", err)
 	}
 
+	// Write manifest file.
+	l.Debug("Adding backup manifest file")
+	err = backupWriteManifest(tarWriter)
+	if err != nil {
+		return fmt.Errorf("Error writing backup manifest file: %w", err)
+	}
+
 	// Close off the tarball file.
 	err = tarWriter.Close()
 	if err != nil {
@@ -222,14 +263,86 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		return fmt.Errorf("Error closing tar file: %w", err)
 	}
 
+	if uploadRes != nil {
+		err = <-uploadRes
+		if err != nil {
+			return fmt.Errorf("Error uploading backup tarball: %w", err)
+		}
+
+		// The backup is only stored on the backup target, not on the server.
+		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.DeleteInstanceBackup(ctx, args.Name)
+		})
+		if err != nil {
+			return fmt.Errorf("Failed removing backup record: %w", err)
+		}
+
+		meta := op.Metadata()
+		if meta == nil {
+			meta = make(map[string]any)
+		}
+
+		meta["backup_target_object"] = objectName
+		_ = op.UpdateMetadata(meta)
+	}
+
 	revert.Success()
 	s.Events.SendLifecycle(sourceInst.Project().Name, lifecycle.InstanceBackupCreated.Event(args.Name, b.Instance(), nil))
 
 	return nil
 }
 
+// backupUpload writes the backup tarball read from src to the S3 backup target of the project.
+// The object is named after the project, instance and backup, below the path of the target URL.
+// It returns the name of the object in the bucket.
+func backupUpload(projectName string, projectConfig map[string]string, backupName string, src io.Reader) (string, error) {
+	targetURL, err := url.Parse(projectConfig["backups.target.url"])
+	if err != nil {
+		return "", fmt.Errorf("Invalid backup target URL: %w", err)
+	}
+
+	// The S3 client expects an explicit port.
+	if targetURL.Port() == "" {
+		port := "443"
+		if targetURL.Scheme == "http" {
+			port = "80"
+		}
+
+		targetURL.Host = net.JoinHostPort(targetURL.Hostname(), port)
+	}
+
+	// The first element of the path is the bucket, the rest is the prefix of the objects.
+	bucketName, prefix, _ := strings.Cut(strings.Trim(targetURL.Path, "/"), "/")
+	if bucketName == "" {
+		return "", fmt.Errorf("Backup target URL is missing a bucket name")
+	}
+
+	// Name the object after the backup format, detected from the start of the stream.
+	reader := bufio.NewReaderSize(src, 512)
+	header, err := reader.Peek(263)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	_, ext, _, err := archive.DetectCompressionFile(bytes.NewReader(header))
+	if err != nil {
+		return "", err
+	}
+
+	objectName := strings.TrimPrefix(fmt.Sprintf("%s/%s/%s%s", prefix, projectName, backupName, ext), "/")
+
+	transferManager := s3.NewTransferManager(targetURL, projectConfig["backups.target.access_key"], projectConfig["backups.target.secret_key"])
+
+	err = transferManager.UploadFile(bucketName, objectName, reader, -1)
+	if err != nil {
+		return "", err
+	}
+
+	return bucketName + "/" + objectName, nil
+}
+
 // backupWriteIndex generates an index.yaml file and then writes it to the root of the backup tarball.
-func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, optimized bool, snapshots bool, tarWriter *instancewriter.InstanceTarWriter) error {
+func backupWriteIndex(s *state.State, sourceInst instance.Instance, pool storagePools.Pool, optimized bool, snapshots bool, tarWriter *instancewriter.InstanceTarWriter) error {
 	// Indicate whether the driver will include a driver-specific optimized header.
 	poolDriverOptimizedHeader := false
 	if optimized {
@@ -273,6 +386,25 @@ func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, opti
 		}
 	}
 
+	// Record where the instance is in the cluster so that it can be restored to an equivalent member.
+	if s.ServerClustered {
+		indexInfo.Placement = &backup.Placement{Member: s.ServerName}
+
+		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			member, err := tx.GetNodeByName(ctx, s.ServerName)
+			if err != nil {
+				return err
+			}
+
+			indexInfo.Placement.FailureDomain, err = tx.GetNodeFailureDomain(ctx, member.ID)
+
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("Failed loading cluster member failure domain: %w", err)
+		}
+	}
+
 	// Convert to YAML.
 	indexData, err := yaml.Marshal(&indexInfo)
 	if err != nil {
@@ -297,6 +429,24 @@ func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, opti
 	return nil
 }
 
+// backupWriteManifest generates a manifest.yaml file listing the checksums of the files already written to
+// the backup tarball, and then adds it to the tarball.
+func backupWriteManifest(tarWriter *instancewriter.InstanceTarWriter) error {
+	manifestData, err := yaml.Marshal(&backup.Manifest{Files: tarWriter.Checksums()})
+	if err != nil {
+		return err
+	}
+
+	manifestFileInfo := instancewriter.FileInfo{
+		FileName:    backup.ManifestPath,
+		FileSize:    int64(len(manifestData)),
+		FileMode:    0644,
+		FileModTime: time.Now(),
+	}
+
+	return tarWriter.WriteFileFromReader(bytes.NewReader(manifestData), &manifestFileInfo)
+}
+
 func pruneExpiredBackupsTask(d *Daemon) (task.Func, task.Schedule) {
 	f := func(ctx context.Context) {
 		s := d.State()
@@ -307,6 +457,11 @@ func pruneExpiredBackupsTask(d *Daemon) (task.Func, task.Schedule) {
 				return fmt.Errorf("Failed pruning expired instance backups: %w", err)
 			}
 
+			err = pruneInstanceBackupsRetention(ctx, s)
+			if err != nil {
+				return fmt.Errorf("Failed enforcing instance backups retention: %w", err)
+			}
+
 			err = pruneExpiredStorageVolumeBackups(ctx, s)
 			if err != nil {
 				return fmt.Errorf("Failed pruning expired storage volume backups: %w", err)
@@ -388,6 +543,91 @@ func pruneExpiredInstanceBackups(ctx context.Context, s *state.State) error {
 	return nil
 }
 
+// pruneInstanceBackupsRetention deletes the backups of the local instances exceeding the retention
+// policy of their project.
+func pruneInstanceBackupsRetention(ctx context.Context, s *state.State) error {
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	for _, inst := range instances {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		retentionCount := inst.Project().Config["backups.retention.count"]
+		retentionAge := inst.Project().Config["backups.retention.age"]
+		if retentionCount == "" && retentionAge == "" {
+			continue
+		}
+
+		// Failures are only logged so that they don't prevent enforcing the retention of other backups.
+		l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+
+		backups, err := inst.Backups()
+		if err != nil {
+			l.Warn("Failed loading instance backups", logger.Ctx{"err": err})
+			continue
+		}
+
+		pruned, err := backupsExceedingRetention(backups, retentionCount, retentionAge, time.Now())
+		if err != nil {
+			l.Warn("Invalid backup retention", logger.Ctx{"err": err})
+			continue
+		}
+
+		for _, b := range pruned {
+			err = b.Delete()
+			if err != nil {
+				l.Warn("Failed deleting instance backup", logger.Ctx{"backup": b.Name(), "err": err})
+			}
+		}
+	}
+
+	return nil
+}
+
+// backupsExceedingRetention returns the backups which should be deleted to honor the retention count
+// (number of backups to keep) and age (expiry expression relative to the backup creation date).
+func backupsExceedingRetention(backups []backup.InstanceBackup, retentionCount string, retentionAge string, now time.Time) ([]backup.InstanceBackup, error) {
+	// Start with the most recent backups.
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].CreationDate().After(backups[j].CreationDate())
+	})
+
+	keep := len(backups)
+	if retentionCount != "" {
+		count, err := strconv.Atoi(retentionCount)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid backups.retention.count: %w", err)
+		}
+
+		keep = min(keep, count)
+	}
+
+	pruned := []backup.InstanceBackup{}
+	for i, b := range backups {
+		if i >= keep {
+			pruned = append(pruned, b)
+			continue
+		}
+
+		if retentionAge != "" {
+			expiry, err := internalInstance.GetExpiry(b.CreationDate(), retentionAge)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid backups.retention.age: %w", err)
+			}
+
+			if !expiry.After(now) {
+				pruned = append(pruned, b)
+			}
+		}
+	}
+
+	return pruned, nil
+}
+
 func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, projectName string, poolName string, volumeName string) error {
 	l := logger.AddContext(logger.Ctx{"project": projectName, "storage_volume": volumeName, "name": args.Name})
 	l.Debug("Volume backup started")
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/internal/server/backup"
+)
+
+func TestBackupsExceedingRetention(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	newBackup := func(name string, age time.Duration) backup.InstanceBackup {
+		return *backup.NewInstanceBackup(nil, nil, 0, name, now.Add(-age), time.Time{}, false, false)
+	}
+
+	tests := []struct {
+		name           string
+		retentionCount string
+		retentionAge   string
+		pruned         []string
+		err            bool
+	}{
+		{name: "No retention", pruned: []string{}},
+		{name: "Count", retentionCount: "2", pruned: []string{"c1/backup2", "c1/backup3"}},
+		{name: "Count above backups", retentionCount: "10", pruned: []string{}},
+		{name: "Count of zero", retentionCount: "0", pruned: []string{"c1/backup0", "c1/backup1", "c1/backup2", "c1/backup3"}},
+		{name: "Age", retentionAge: "2d", pruned: []string{"c1/backup2", "c1/backup3"}},
+		{name: "Count and age", retentionCount: "1", retentionAge: "2d", pruned: []string{"c1/backup1", "c1/backup2", "c1/backup3"}},
+		{name: "Invalid count", retentionCount: "foo", err: true},
+		{name: "Invalid age", retentionAge: "foo", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Backups are listed out of order to check that the most recent ones are kept.
+			backups := []backup.InstanceBackup{
+				newBackup("c1/backup2", 3*24*time.Hour),
+				newBackup("c1/backup0", time.Hour),
+				newBackup("c1/backup3", 4*24*time.Hour),
+				newBackup("c1/backup1", 24*time.Hour),
+			}
+
+			pruned, err := backupsExceedingRetention(backups, test.retentionCount, test.retentionAge, now)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			names := make([]string, 0, len(pruned))
+			for _, b := range pruned {
+				names = append(names, b.Name())
+			}
+
+			assert.Equal(t, test.pruned, names)
+		})
+	}
+}
@@ -314,8 +314,9 @@ func updateCertificateCacheFromLocal(d *Daemon) error {
 }
 
 // clusterMemberJoinTokenValid searches for cluster join token that matches the join token provided.
+// For tokens bound to a name pattern, the name of the joining member must match that pattern.
 // Returns matching operation if found and cancels the operation, otherwise returns nil.
-func clusterMemberJoinTokenValid(s *state.State, r *http.Request, projectName string, joinToken *api.ClusterMemberJoinToken) (*api.Operation, error) {
+func clusterMemberJoinTokenValid(s *state.State, r *http.Request, projectName string, joinToken *api.ClusterMemberJoinToken, serverName string) (*api.Operation, error) {
 	ops, err := operationsGetByType(s, r, projectName, operationtype.ClusterJoinToken)
 	if err != nil {
 		return nil, fmt.Errorf("Failed getting cluster join token operations: %w", err)
@@ -341,12 +342,26 @@ func clusterMemberJoinTokenValid(s *state.State, r *http.Request, projectName st
 			continue
 		}
 
-		if opServerName == joinToken.ServerName && opSecret == joinToken.Secret {
+		opServerNamePattern, _ := op.Metadata["serverNamePattern"].(string)
+
+		if opServerName == joinToken.ServerName && opServerNamePattern == joinToken.ServerNamePattern && opSecret == joinToken.Secret {
 			foundOp = op
 			break
 		}
 	}
 
+	// Check the joining member's name before using up the token.
+	if foundOp != nil && joinToken.ServerNamePattern != "" {
+		match, err := internalUtil.JoinTokenServerNameMatch(joinToken.ServerNamePattern, serverName)
+		if err != nil {
+			return nil, err
+		}
+
+		if !match {
+			return nil, api.StatusErrorf(http.StatusForbidden, "Server name %q doesn't match the join token's pattern %q", serverName, joinToken.ServerNamePattern)
+		}
+	}
+
 	if foundOp != nil {
 		// Token is single-use, so cancel it now.
 		err = operationCancel(s, r, projectName, foundOp)
@@ -554,8 +569,12 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 		joinToken, err := internalUtil.JoinTokenDecode(req.TrustToken)
 		if err == nil {
 			// If so then check there is a matching join operation.
-			joinOp, err := clusterMemberJoinTokenValid(s, r, api.ProjectDefaultName, joinToken)
+			joinOp, err := clusterMemberJoinTokenValid(s, r, api.ProjectDefaultName, joinToken, req.Name)
 			if err != nil {
+				if api.StatusErrorCheck(err, http.StatusForbidden) {
+					return response.SmartError(err)
+				}
+
 				return response.InternalError(fmt.Errorf("Failed during search for join token operation: %w", err))
 			}
 
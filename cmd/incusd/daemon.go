@@ -24,6 +24,7 @@ import (
 	"github.com/cowsql/go-cowsql/driver"
 	"github.com/gorilla/mux"
 	liblxc "github.com/lxc/go-lxc"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 
 	internalIO "github.com/lxc/incus/v6/internal/io"
@@ -128,6 +129,12 @@ type Daemon struct {
 	// changes).
 	clusterMembershipMutex sync.RWMutex
 
+	// Prevents concurrent runs of the automatic instance failback.
+	clusterFailbackMutex sync.Mutex
+
+	// Failed failback attempts indexed by instance, protected by clusterFailbackMutex.
+	clusterFailbackFailures map[string]clusterFailbackFailure
+
 	serverCert    func() *localtls.CertInfo
 	serverCertInt *localtls.CertInfo // Do not use this directly, use servertCert func.
 
@@ -155,6 +162,8 @@ type Daemon struct {
 
 	lokiClient *loki.Client
 
+	logCollector *logger.CollectorHook
+
 	// HTTP-01 challenge provider for ACME
 	http01Provider acme.HTTP01Provider
 
@@ -771,6 +780,37 @@ func (d *Daemon) Init() error {
 	return nil
 }
 
+func (d *Daemon) setupLogCollector(URL string, logLevel string) error {
+	// Stop any existing collector.
+	if d.logCollector != nil {
+		logger.RemoveHook(d.logCollector)
+		d.logCollector.Stop()
+		d.logCollector = nil
+	}
+
+	if URL == "" {
+		return nil
+	}
+
+	level, err := logrus.ParseLevel(logLevel)
+	if err != nil {
+		return err
+	}
+
+	levels := []logrus.Level{}
+	for _, l := range logrus.AllLevels {
+		if l <= level {
+			levels = append(levels, l)
+		}
+	}
+
+	// Start a new collector and attach it to the logger.
+	d.logCollector = logger.NewCollectorHook(URL, levels)
+	logger.AddHook(d.logCollector)
+
+	return nil
+}
+
 func (d *Daemon) setupLoki(URL string, cert string, key string, caCert string, instanceName string, logLevel string, labels []string, types []string) error {
 	// Stop any existing loki client.
 	if d.lokiClient != nil {
@@ -1362,6 +1402,7 @@ func (d *Daemon) init() error {
 
 	d.gateway.HeartbeatOfflineThreshold = d.globalConfig.OfflineThreshold()
 	lokiURL, lokiUsername, lokiPassword, lokiCACert, lokiInstance, lokiLoglevel, lokiLabels, lokiTypes := d.globalConfig.LokiServer()
+	logCollectorURL, logCollectorLoglevel := d.globalConfig.LoggingCollector()
 	oidcIssuer, oidcClientID, oidcAudience, oidcClaim := d.globalConfig.OIDCServer()
 	syslogSocketEnabled := d.localConfig.SyslogSocket()
 	openfgaAPIURL, openfgaAPIToken, openfgaStoreID := d.globalConfig.OpenFGA()
@@ -1378,6 +1419,14 @@ func (d *Daemon) init() error {
 		}
 	}
 
+	// Setup log collector.
+	if logCollectorURL != "" {
+		err = d.setupLogCollector(logCollectorURL, logCollectorLoglevel)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Setup syslog listener.
 	if syslogSocketEnabled {
 		err = d.setupSyslogSocket(true)
@@ -1616,11 +1665,20 @@ func (d *Daemon) init() error {
 
 		// Remove expired tokens (hourly)
 		d.tasks.Add(autoRemoveExpiredTokensTask(d))
+
+		// Check the health of network zone records (every 10 seconds)
+		d.tasks.Add(networkZoneHealthCheckTask(d))
 	}
 
 	// Start all background tasks
 	d.tasks.Start(d.shutdownCtx)
 
+	// Wait for the managed networks the instances may depend on.
+	networkTimeout := d.globalConfig.InstancesAutostartNetworkTimeout()
+	if networkTimeout > 0 && !networkWaitReady(d.shutdownCtx, networkTimeout) {
+		logger.Warn("Timed out waiting for networks to be initialized, starting instances anyway", logger.Ctx{"timeout": networkTimeout})
+	}
+
 	// Restore instances
 	instancesStart(d.State(), instances)
 
@@ -1749,7 +1807,16 @@ func (d *Daemon) Stop(ctx context.Context, sig os.Signal) error {
 
 		// Full shutdown requested.
 		if sig == unix.SIGPWR {
-			instancesShutdown(s, instances)
+			summary := instancesShutdown(s, instances)
+			logger.Info("Instances stopped", logger.Ctx{"stopped": summary.Stopped, "skipped": summary.Skipped, "forceStopped": len(summary.ForceStopped), "failed": len(summary.Failed)})
+
+			if len(summary.ForceStopped) > 0 {
+				logger.Warn("Some instances were forcefully stopped", logger.Ctx{"instances": strings.Join(summary.ForceStopped, ", ")})
+			}
+
+			if len(summary.Failed) > 0 {
+				logger.Error("Some instances couldn't be stopped", logger.Ctx{"instances": strings.Join(summary.Failed, ", ")})
+			}
 
 			logger.Info("Stopping networks")
 			networkShutdown(s)
@@ -2421,6 +2488,10 @@ func (d *Daemon) nodeRefreshTask(heartbeatData *cluster.APIHeartbeat, isLeader b
 
 			d.clusterMembershipMutex.Unlock()
 		}
+
+		if s.GlobalConfig.ClusterFailback() {
+			go clusterFailback(d, unavailableMembers)
+		}
 	}
 
 	wg.Wait()
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/lxc/incus/v6/client"
@@ -28,6 +29,7 @@ import (
 	"github.com/lxc/incus/v6/shared/cancel"
 	"github.com/lxc/incus/v6/shared/ioprogress"
 	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 )
@@ -59,6 +61,71 @@ func imageOperationLock(ctx context.Context, fingerprint string) (locking.Unlock
 	return locking.Lock(ctx, fmt.Sprintf("ImageOperation_%s", fingerprint))
 }
 
+// imagePostDownloadHookTimeout is how long the post-download hook may run before the image is rejected.
+const imagePostDownloadHookTimeout = 5 * time.Minute
+
+// imageRunPostDownloadHook runs the post-download hook against a downloaded image.
+// The image is rejected if the hook fails, otherwise any KEY=VALUE line it outputs is added to the image properties
+// provided the image server didn't already set that property.
+func imageRunPostDownloadHook(ctx context.Context, hook string, server string, info *api.Image, metaPath string, rootfsPath string) error {
+	// The hook runs as root, so only run executables which can't be modified by other users.
+	fi, err := os.Stat(hook)
+	if err != nil {
+		return fmt.Errorf("Failed accessing post-download hook: %w", err)
+	}
+
+	mode, uid, _ := internalIO.GetOwnerMode(fi)
+	if uid != 0 || mode.Perm()&0o022 != 0 {
+		return fmt.Errorf("Post-download hook %q must be owned by root and only writable by its owner", hook)
+	}
+
+	env := append(os.Environ(),
+		"INCUS_IMAGE_FINGERPRINT="+info.Fingerprint,
+		"INCUS_IMAGE_TYPE="+info.Type,
+		"INCUS_IMAGE_ARCHITECTURE="+info.Architecture,
+		"INCUS_IMAGE_SERVER="+server,
+	)
+
+	args := []string{metaPath}
+	if rootfsPath != "" {
+		args = append(args, rootfsPath)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, imagePostDownloadHookTimeout)
+	defer cancel()
+
+	stdout, _, err := subprocess.RunCommandSplit(ctx, env, nil, hook, args...)
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("Image %q rejected as the post-download hook didn't complete within %s", info.Fingerprint, imagePostDownloadHookTimeout)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Image %q rejected by post-download hook: %w", info.Fingerprint, err)
+	}
+
+	for _, line := range strings.Split(stdout, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found || key == "" {
+			continue
+		}
+
+		if info.Properties == nil {
+			info.Properties = map[string]string{}
+		}
+
+		// The hook can only add properties, not alter the ones describing the image.
+		_, exists := info.Properties[key]
+		if exists {
+			logger.Warn("Ignoring image property set by post-download hook as it's already set", logger.Ctx{"fingerprint": info.Fingerprint, "property": key})
+			continue
+		}
+
+		info.Properties[key] = value
+	}
+
+	return nil
+}
+
 // ImageDownload resolves the image fingerprint and if not in the database, downloads it.
 func ImageDownload(ctx context.Context, r *http.Request, s *state.State, op *operations.Operation, args *ImageDownloadArgs) (*api.Image, error) {
 	var err error
@@ -446,6 +513,20 @@ func ImageDownload(ctx context.Context, r *http.Request, s *state.State, op *ope
 		if err != nil {
 			return nil, err
 		}
+
+		// Run the post-download hook if configured.
+		hook := s.LocalConfig.ImagesPostDownloadHook()
+		if hook != "" {
+			rootfsPath := ""
+			if resp.RootfsSize > 0 {
+				rootfsPath = destName + ".rootfs"
+			}
+
+			err = imageRunPostDownloadHook(ctx, hook, args.Server, info, destName, rootfsPath)
+			if err != nil {
+				return nil, err
+			}
+		}
 	} else if protocol == "direct" {
 		// Setup HTTP client
 		httpClient, err := localUtil.HTTPClient(args.Certificate, s.Proxy)
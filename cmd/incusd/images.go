@@ -1192,7 +1192,7 @@ func imagesPost(d *Daemon, r *http.Request) response.Response {
 		}
 
 		// Sync the images between each node in the cluster on demand
-		err = imageSyncBetweenNodes(context.TODO(), s, r, projectName, info.Fingerprint)
+		err = imageSyncBetweenNodes(context.TODO(), s, r, projectName, info.Fingerprint, nil)
 		if err != nil {
 			return fmt.Errorf("Failed syncing image between nodes: %w", err)
 		}
@@ -4495,7 +4495,7 @@ func autoSyncImagesTask(d *Daemon) (task.Func, task.Schedule) {
 		}
 
 		opRun := func(op *operations.Operation) error {
-			return autoSyncImages(ctx, s)
+			return autoSyncImages(ctx, s, nil)
 		}
 
 		op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.ImagesSynchronize, nil, nil, opRun, nil, nil, nil)
@@ -4528,7 +4528,9 @@ func autoSyncImagesTask(d *Daemon) (task.Func, task.Schedule) {
 	return f, task.Hourly()
 }
 
-func autoSyncImages(ctx context.Context, s *state.State) error {
+// autoSyncImages replicates the images across the cluster.
+// If member addresses are given, only the images missing on those members are synced and only to them.
+func autoSyncImages(ctx context.Context, s *state.State, memberAddresses []string) error {
 	var imageProjectInfo map[string][]string
 
 	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
@@ -4536,8 +4538,35 @@ func autoSyncImages(ctx context.Context, s *state.State) error {
 
 		// Get all images.
 		imageProjectInfo, err = tx.GetImages(ctx)
+		if err != nil {
+			return err
+		}
 
-		return err
+		if memberAddresses == nil {
+			return nil
+		}
+
+		// Only keep the images which are missing on at least one of the members.
+		for fingerprint := range imageProjectInfo {
+			addresses, err := tx.GetNodesWithoutImage(ctx, fingerprint)
+			if err != nil {
+				return err
+			}
+
+			missing := false
+			for _, address := range addresses {
+				if slices.Contains(memberAddresses, address) {
+					missing = true
+					break
+				}
+			}
+
+			if !missing {
+				delete(imageProjectInfo, fingerprint)
+			}
+		}
+
+		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("Failed to query image fingerprints: %w", err)
@@ -4546,7 +4575,7 @@ func autoSyncImages(ctx context.Context, s *state.State) error {
 	for fingerprint, projects := range imageProjectInfo {
 		ch := make(chan error)
 		go func(projectName string, fingerprint string) {
-			err := imageSyncBetweenNodes(ctx, s, nil, projectName, fingerprint)
+			err := imageSyncBetweenNodes(ctx, s, nil, projectName, fingerprint, memberAddresses)
 			if err != nil {
 				logger.Error("Failed to synchronize images", logger.Ctx{"err": err, "fingerprint": fingerprint})
 			}
@@ -4564,7 +4593,9 @@ func autoSyncImages(ctx context.Context, s *state.State) error {
 	return nil
 }
 
-func imageSyncBetweenNodes(ctx context.Context, s *state.State, r *http.Request, project string, fingerprint string) error {
+// imageSyncBetweenNodes copies the image to other members until it's available on the configured number of members.
+// If member addresses are given, the image is only copied to those members.
+func imageSyncBetweenNodes(ctx context.Context, s *state.State, r *http.Request, project string, fingerprint string, memberAddresses []string) error {
 	logger.Info("Syncing image to members started", logger.Ctx{"fingerprint": fingerprint, "project": project})
 	defer logger.Info("Syncing image to members finished", logger.Ctx{"fingerprint": fingerprint, "project": project})
 
@@ -4645,8 +4676,18 @@ func imageSyncBetweenNodes(ctx context.Context, s *state.State, r *http.Request,
 		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 			// Get a list of nodes that do not have the image.
 			addresses, err = tx.GetNodesWithoutImage(ctx, fingerprint)
+			if err != nil {
+				return err
+			}
 
-			return err
+			// Restrict the targets to the requested members.
+			if memberAddresses != nil {
+				addresses = slices.DeleteFunc(addresses, func(address string) bool {
+					return !slices.Contains(memberAddresses, address)
+				})
+			}
+
+			return nil
 		})
 		if err != nil {
 			return fmt.Errorf("Failed to get nodes for the image synchronization: %w", err)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -162,6 +163,11 @@ func instanceCreateFromImage(ctx context.Context, s *state.State, r *http.Reques
 	revert.Add(cleanup)
 	defer instOp.Done(nil)
 
+	// Don't create secure boot enabled virtual machines from images which don't support it.
+	if inst.Type() == instancetype.VM && util.IsFalse(img.Properties["requirements.secureboot"]) && util.IsTrueOrEmpty(inst.ExpandedConfig()["security.secureboot"]) {
+		return api.StatusErrorf(http.StatusBadRequest, "The image %q is incompatible with secure boot. Please set security.secureboot=false on the instance", img.Fingerprint)
+	}
+
 	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 		err = tx.UpdateImageLastUseDate(ctx, args.Project, img.Fingerprint, time.Now().UTC())
 		if err != nil {
@@ -195,7 +201,7 @@ func instanceCreateFromImage(ctx context.Context, s *state.State, r *http.Reques
 	return nil
 }
 
-func instanceRebuildFromImage(ctx context.Context, s *state.State, r *http.Request, inst instance.Instance, img *api.Image, op *operations.Operation) error {
+func instanceRebuildFromImage(ctx context.Context, s *state.State, r *http.Request, inst instance.Instance, img *api.Image, resetIdentity bool, op *operations.Operation) error {
 	// Validate the type of the image matches the type of the instance.
 	imgType, err := instancetype.New(img.Type)
 	if err != nil {
@@ -211,7 +217,7 @@ func instanceRebuildFromImage(ctx context.Context, s *state.State, r *http.Reque
 		return err
 	}
 
-	err = inst.Rebuild(img, op)
+	err = inst.Rebuild(img, resetIdentity, op)
 	if err != nil {
 		return fmt.Errorf("Failed rebuilding instance from image: %w", err)
 	}
@@ -219,8 +225,8 @@ func instanceRebuildFromImage(ctx context.Context, s *state.State, r *http.Reque
 	return nil
 }
 
-func instanceRebuildFromEmpty(s *state.State, inst instance.Instance, op *operations.Operation) error {
-	err := inst.Rebuild(nil, op) // Rebuild as empty.
+func instanceRebuildFromEmpty(s *state.State, inst instance.Instance, resetIdentity bool, op *operations.Operation) error {
+	err := inst.Rebuild(nil, resetIdentity, op) // Rebuild as empty.
 	if err != nil {
 		return fmt.Errorf("Failed rebuilding as an empty instance: %w", err)
 	}
@@ -492,7 +498,7 @@ func autoCreateInstanceSnapshots(ctx context.Context, s *state.State, instances
 			return err
 		}
 
-		err = inst.Snapshot(snapshotName, expiry, false)
+		err = instanceSnapshotCreate(inst, snapshotName, expiry, false)
 		if err != nil {
 			l.Error("Error creating snapshot", logger.Ctx{"snapshot": snapshotName, "err": err})
 			return err
@@ -627,8 +633,20 @@ func pruneExpiredAndAutoCreateInstanceSnapshotsTask(d *Daemon) (task.Func, task.
 					return nil
 				}
 
-				// Check if snapshot is scheduled.
-				if !snapshotIsScheduledNow(schedule, int64(inst.ID())) {
+				// Check if snapshot is scheduled, spreading it over the jitter window if any.
+				jitter, _ := strconv.ParseInt(inst.ExpandedConfig()["snapshots.schedule.jitter"], 10, 64)
+
+				// Evaluate the schedule in the configured timezone, if any.
+				var timezone *time.Location
+				if inst.ExpandedConfig()["snapshots.schedule.timezone"] != "" {
+					timezone, err = time.LoadLocation(inst.ExpandedConfig()["snapshots.schedule.timezone"])
+					if err != nil {
+						logger.Warn("Invalid snapshot schedule timezone", logger.Ctx{"instance": inst.Name(), "project": inst.Project().Name, "err": err})
+						return nil
+					}
+				}
+
+				if !snapshotIsScheduledNow(schedule, int64(inst.ID()), jitter, timezone) {
 					return nil
 				}
 
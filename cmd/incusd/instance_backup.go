@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,18 +16,26 @@ import (
 
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
+	backupPkg "github.com/lxc/incus/v6/internal/server/backup"
 	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/archive"
+	"github.com/lxc/incus/v6/shared/osarch"
 )
 
 // swagger:operation GET /1.0/instances/{name}/backups instances instance_backups_get
@@ -692,3 +703,347 @@ func instanceBackupExportGet(d *Daemon, r *http.Request) response.Response {
 
 	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
 }
+
+// swagger:operation POST /1.0/instances/{name}/backups/{backup}/verify instances instance_backup_verify_post
+//
+//	Verify a backup
+//
+//	Reads the whole backup file and checks its files against the checksums recorded in its manifest.
+//	The result is stored as `verification` in the operation metadata.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceBackupVerifyPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	instanceType, err := urlInstanceTypeDetect(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	backupName, err := url.PathUnescape(mux.Vars(r)["backupName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Handle requests targeted to a container on a different node
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name, instanceType)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	fullName := name + internalInstance.SnapshotDelimiter + backupName
+	backup, err := instance.BackupLoadByName(s, projectName, fullName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	verify := func(op *operations.Operation) error {
+		backupPath := internalUtil.VarPath("backups", "instances", project.Instance(projectName, backup.Name()))
+
+		backupFile, err := os.Open(backupPath)
+		if err != nil {
+			return fmt.Errorf("Failed opening backup file: %w", err)
+		}
+
+		defer func() { _ = backupFile.Close() }()
+
+		result, err := backupPkg.Verify(backupFile, backupPath)
+		if err != nil {
+			return fmt.Errorf("Failed verifying backup: %w", err)
+		}
+
+		return op.UpdateMetadata(map[string]any{"verification": result})
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name)}
+	resources["backups"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name, "backups", backupName)}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask,
+		operationtype.BackupVerify, resources, nil, verify, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// swagger:operation POST /1.0/instances/{name}/backups/{backup}/restore instances instance_backup_restore
+//
+//	Restore a backup onto its instance
+//
+//	Restores the configuration, the data or both from a backup onto the existing instance.
+//	Restoring the data requires the instance to be stopped and leaves its snapshots untouched.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: restore
+//	    description: Restore request
+//	    schema:
+//	      $ref: "#/definitions/InstanceBackupRestorePost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceBackupRestorePost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	instanceType, err := urlInstanceTypeDetect(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	backupName, err := url.PathUnescape(mux.Vars(r)["backupName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Handle requests targeted to a container on a different node
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name, instanceType)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	req := api.InstanceBackupRestorePost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Mode == "" {
+		req.Mode = "full"
+	}
+
+	if !slices.Contains([]string{"full", "config", "data"}, req.Mode) {
+		return response.BadRequest(fmt.Errorf("Invalid restore mode %q", req.Mode))
+	}
+
+	fullName := name + internalInstance.SnapshotDelimiter + backupName
+	backup, err := instance.BackupLoadByName(s, projectName, fullName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	restore := func(op *operations.Operation) error {
+		unlock, err := instanceOperationLock(s.ShutdownCtx, projectName, name)
+		if err != nil {
+			return err
+		}
+
+		defer unlock()
+
+		inst, err := instance.LoadByProjectAndName(s, projectName, name)
+		if err != nil {
+			return err
+		}
+
+		backupPath := internalUtil.VarPath("backups", "instances", project.Instance(projectName, backup.Name()))
+
+		backupFile, err := os.Open(backupPath)
+		if err != nil {
+			return fmt.Errorf("Failed opening backup file: %w", err)
+		}
+
+		defer func() { _ = backupFile.Close() }()
+
+		_, algo, _, err := archive.DetectCompressionFile(backupFile)
+		if err != nil {
+			return err
+		}
+
+		if algo == ".squashfs" {
+			return fmt.Errorf("Backups compressed with squashfs can't be restored onto an existing instance")
+		}
+
+		_, err = backupFile.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		bInfo, err := backupPkg.GetInfo(backupFile, s.OS, backupPath)
+		if err != nil {
+			return err
+		}
+
+		bInfo.Project = projectName
+
+		if req.Mode != "config" {
+			err = instanceBackupRestoreData(s, inst, bInfo, backupFile, op)
+			if err != nil {
+				return fmt.Errorf("Failed restoring instance data: %w", err)
+			}
+		}
+
+		if req.Mode != "data" {
+			err = instanceBackupRestoreConfig(s, inst, bInfo)
+			if err != nil {
+				return fmt.Errorf("Failed restoring instance configuration: %w", err)
+			}
+		}
+
+		s.Events.SendLifecycle(projectName, lifecycle.InstanceRestored.Event(inst, map[string]any{"backup": backupName, "mode": req.Mode}))
+
+		return nil
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name)}
+	resources["backups"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name, "backups", backupName)}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask,
+		operationtype.BackupRestore, resources, nil, restore, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// instanceBackupRestoreData replaces the data of the instance with the one stored in a backup.
+func instanceBackupRestoreData(s *state.State, inst instance.Instance, bInfo *backupPkg.Info, backupFile io.ReadSeeker, op *operations.Operation) error {
+	pool, err := storagePools.LoadByInstance(s, inst)
+	if err != nil {
+		return err
+	}
+
+	err = pool.RestoreInstanceFromBackup(inst, *bInfo, backupFile, op)
+	if err != nil {
+		return err
+	}
+
+	// The files in the backup are owned by the unshifted container IDs, so have the container's
+	// filesystem shifted again on next start.
+	if inst.Type() == instancetype.Container {
+		err = inst.VolatileSet(map[string]string{"volatile.last_state.idmap": "[]"})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// instanceBackupRestoreConfig applies the configuration, devices and profiles recorded in a backup to the instance.
+// The volatile keys of the instance are kept as they reflect its current state rather than the backed up one.
+func instanceBackupRestoreConfig(s *state.State, inst instance.Instance, bInfo *backupPkg.Info) error {
+	if bInfo.Config == nil || bInfo.Config.Container == nil {
+		return fmt.Errorf("Backup doesn't include the instance configuration")
+	}
+
+	backupInst := bInfo.Config.Container
+
+	config := map[string]string{}
+	for key, value := range backupInst.Config {
+		if !strings.HasPrefix(key, internalInstance.ConfigVolatilePrefix) {
+			config[key] = value
+		}
+	}
+
+	for key, value := range inst.LocalConfig() {
+		if strings.HasPrefix(key, internalInstance.ConfigVolatilePrefix) {
+			config[key] = value
+		}
+	}
+
+	instPut := backupInst.InstancePut
+	instPut.Config = config
+
+	apiProfiles := make([]api.Profile, 0, len(instPut.Profiles))
+	err := s.DB.Cluster.Transaction(s.ShutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+		profiles, err := cluster.GetProfilesIfEnabled(ctx, tx.Tx(), inst.Project().Name, instPut.Profiles)
+		if err != nil {
+			return err
+		}
+
+		for _, profile := range profiles {
+			apiProfile, err := profile.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			apiProfiles = append(apiProfiles, *apiProfile)
+		}
+
+		return project.AllowInstanceUpdate(tx, inst.Project().Name, inst.Name(), instPut, inst.LocalConfig())
+	})
+	if err != nil {
+		return err
+	}
+
+	architecture, err := osarch.ArchitectureId(instPut.Architecture)
+	if err != nil {
+		architecture = inst.Architecture()
+	}
+
+	args := db.InstanceArgs{
+		Architecture: architecture,
+		Config:       instPut.Config,
+		Description:  instPut.Description,
+		Devices:      deviceConfig.NewDevices(instPut.Devices),
+		Ephemeral:    instPut.Ephemeral,
+		Profiles:     apiProfiles,
+		Project:      inst.Project().Name,
+	}
+
+	return inst.Update(args, true)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+
+	backupPkg "github.com/lxc/incus/v6/internal/server/backup"
+	"github.com/lxc/incus/v6/internal/server/backup/config"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// Restoring the data of a container has its filesystem shifted again on next start.
+func (suite *containerTestSuite) TestInstanceBackupRestoreData_ResetsIdmap() {
+	args := db.InstanceArgs{
+		Type:      instancetype.Container,
+		Ephemeral: false,
+		Name:      "testFoo",
+	}
+
+	c, op, _, err := instance.CreateInternal(suite.d.State(), args, true, true)
+	suite.Req.Nil(err)
+	op.Done(nil)
+	defer func() { _ = c.Delete(true) }()
+
+	err = c.VolatileSet(map[string]string{"volatile.last_state.idmap": `[{"Isuid":true,"Isgid":false,"Hostid":100000,"Nsid":0,"Maprange":65536}]`})
+	suite.Req.Nil(err)
+
+	bInfo := &backupPkg.Info{Name: "testFoo", Type: backupPkg.TypeContainer}
+	err = instanceBackupRestoreData(suite.d.State(), c, bInfo, strings.NewReader(""), nil)
+	suite.Req.Nil(err)
+
+	c, err = instance.LoadByProjectAndName(suite.d.State(), "default", "testFoo")
+	suite.Req.Nil(err)
+	suite.Equal("[]", c.LocalConfig()["volatile.last_state.idmap"])
+}
+
+// Restoring the configuration of an instance keeps its volatile keys.
+func (suite *containerTestSuite) TestInstanceBackupRestoreConfig_KeepsVolatile() {
+	args := db.InstanceArgs{
+		Type:      instancetype.Container,
+		Ephemeral: false,
+		Name:      "testFoo",
+		Config: map[string]string{
+			"limits.cpu":                "1",
+			"volatile.last_state.power": "STOPPED",
+		},
+	}
+
+	c, op, _, err := instance.CreateInternal(suite.d.State(), args, true, true)
+	suite.Req.Nil(err)
+	op.Done(nil)
+	defer func() { _ = c.Delete(true) }()
+
+	bInfo := &backupPkg.Info{
+		Name: "testFoo",
+		Type: backupPkg.TypeContainer,
+		Config: &config.Config{
+			Container: &api.Instance{
+				InstancePut: api.InstancePut{
+					Config: map[string]string{
+						"limits.cpu":                "2",
+						"volatile.last_state.power": "RUNNING",
+					},
+					Profiles: []string{"default"},
+				},
+			},
+		},
+	}
+
+	err = instanceBackupRestoreConfig(suite.d.State(), c, bInfo)
+	suite.Req.Nil(err)
+
+	c, err = instance.LoadByProjectAndName(suite.d.State(), "default", "testFoo")
+	suite.Req.Nil(err)
+	suite.Equal("2", c.LocalConfig()["limits.cpu"])
+	suite.Equal("STOPPED", c.LocalConfig()["volatile.last_state.power"])
+}
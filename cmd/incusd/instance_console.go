@@ -65,6 +65,12 @@ type consoleWs struct {
 
 	// channel type (either console or vga)
 	protocol string
+
+	// whether input from the websocket is dropped
+	readOnly bool
+
+	// path to record the session to (empty if not recorded)
+	recordPath string
 }
 
 func (s *consoleWs) Metadata() any {
@@ -208,17 +214,38 @@ func (s *consoleWs) doConsole(op *operations.Operation) error {
 	defer logger.Debug("Console websocket finished")
 	<-s.allConnected
 
-	// Get console from instance.
-	console, consoleDisconnectCh, err := s.instance.Console(s.protocol)
+	// Attach to the console, it's shared with the other sessions of the instance.
+	session, err := consoleAttach(s.instance, s.readOnly)
 	if err != nil {
 		return err
 	}
 
-	defer func() { _ = console.Close() }()
+	defer func() { _ = session.Close() }()
+
+	isPty := session.share.isPty
 
 	// Detect size of window and set it into console.
-	if s.width > 0 && s.height > 0 {
-		_ = linux.SetPtySize(int(console.Fd()), s.width, s.height)
+	if isPty && !s.readOnly && s.width > 0 && s.height > 0 {
+		_ = session.share.setSize(s.width, s.height)
+	}
+
+	// Record the session if required.
+	var consoleRWC io.ReadWriteCloser = session
+	var recorder *consoleRecorder
+	if s.recordPath != "" {
+		width, height := s.width, s.height
+		if width <= 0 || height <= 0 {
+			width, height = 80, 24
+		}
+
+		recorder, err = newConsoleRecorder(s.recordPath, width, height)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = recorder.Close() }()
+
+		consoleRWC = &recordedConsole{ReadWriteCloser: session, recorder: recorder}
 	}
 
 	consoleDoneCh := make(chan struct{})
@@ -257,6 +284,12 @@ func (s *consoleWs) doConsole(op *operations.Operation) error {
 				continue
 			}
 
+			// Read-only sessions mustn't change the terminal of the other sessions.
+			if s.readOnly {
+				logger.Debugf("Ignoring %q control command on a read-only console", command.Command)
+				continue
+			}
+
 			if command.Command == "window-resize" {
 				winchWidth, err := strconv.Atoi(command.Args["width"])
 				if err != nil {
@@ -270,13 +303,28 @@ func (s *consoleWs) doConsole(op *operations.Operation) error {
 					continue
 				}
 
-				err = linux.SetPtySize(int(console.Fd()), winchWidth, winchHeight)
+				if winchWidth <= 0 || winchHeight <= 0 {
+					logger.Debugf("Ignoring invalid window size: %dx%d", winchWidth, winchHeight)
+					continue
+				}
+
+				// Only terminals have a window size, VM serial consoles are plain sockets.
+				if !isPty {
+					logger.Debugf("Ignoring window size change on a console which isn't a terminal")
+					continue
+				}
+
+				err = session.share.setSize(winchWidth, winchHeight)
 				if err != nil {
 					logger.Debugf("Failed to set window size to: %dx%d", winchWidth, winchHeight)
 					continue
 				}
 
 				logger.Debugf("Set window size to: %dx%d", winchWidth, winchHeight)
+
+				if recorder != nil {
+					recorder.record("r", fmt.Sprintf("%dx%d", winchWidth, winchHeight))
+				}
 			}
 		}
 	}()
@@ -292,7 +340,20 @@ func (s *consoleWs) doConsole(op *operations.Operation) error {
 		defer l.Debug("Finished mirroring websocket to console")
 
 		l.Debug("Started mirroring websocket")
-		readDone, writeDone := ws.Mirror(conn, console)
+
+		// Let the user know that the session is being recorded.
+		if recorder != nil {
+			_ = conn.WriteMessage(websocket.BinaryMessage, []byte(consoleRecordingBanner))
+		}
+
+		// Drop the input of read-only sessions, still reading it to notice disconnections.
+		var readDone, writeDone chan error
+		if s.readOnly {
+			readDone = ws.MirrorRead(conn, consoleRWC)
+			writeDone = ws.MirrorWrite(conn, io.Discard)
+		} else {
+			readDone, writeDone = ws.Mirror(conn, consoleRWC)
+		}
 
 		<-readDone
 		l.Debug("Finished mirroring console to websocket")
@@ -303,9 +364,7 @@ func (s *consoleWs) doConsole(op *operations.Operation) error {
 	// Wait until either the console or the websocket is done.
 	select {
 	case <-mirrorDoneCh:
-		close(consoleDisconnectCh)
 	case <-consoleDoneCh:
-		close(consoleDisconnectCh)
 	}
 
 	// Get the console and control websockets.
@@ -320,16 +379,9 @@ func (s *consoleWs) doConsole(op *operations.Operation) error {
 		_ = ctrlConn.Close()
 	}()
 
-	// Write a reset escape sequence to the console to cancel any ongoing reads to the handle
-	// and then close it. This ordering is important, close the console before closing the
-	// websocket to ensure console doesn't get stuck reading.
-	_, err = console.Write([]byte("\x1bc"))
-	if err != nil {
-		_ = console.Close()
-		return err
-	}
-
-	err = console.Close()
+	// Detach from the console, closing it if no other session is attached to it. This ordering is
+	// important, detach before closing the websocket to ensure the session doesn't get stuck reading.
+	err = session.Close()
 	if err != nil {
 		return err
 	}
@@ -392,6 +444,9 @@ func (s *consoleWs) doVGA(op *operations.Operation) error {
 //	Connects to the console of an instance.
 //
 //	The returned operation metadata will contain two websockets, one for data and one for control.
+//	For the console type, the control websocket accepts "window-resize" messages to resize the terminal during the session.
+//	Read-only sessions drop any input sent on the data websocket and ignore control messages.
+//	Any number of read-only sessions can watch the console alongside a single interactive session.
 //
 //	---
 //	consumes:
@@ -486,6 +541,15 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("VGA console is only supported by virtual machines"))
 	}
 
+	if post.Type == instance.ConsoleTypeVGA && post.ReadOnly {
+		return response.BadRequest(fmt.Errorf("Read-only mode is only supported by the console type"))
+	}
+
+	recordSession := s.GlobalConfig.InstancesConsoleRecord()
+	if post.Type == instance.ConsoleTypeVGA && recordSession {
+		return response.BadRequest(fmt.Errorf("The VGA console can't be used as console sessions of this instance must be recorded"))
+	}
+
 	if !inst.IsRunning() {
 		return response.BadRequest(fmt.Errorf("Instance is not running"))
 	}
@@ -494,6 +558,10 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Instance is frozen"))
 	}
 
+	if post.Type == instance.ConsoleTypeConsole && !post.ReadOnly && consoleInteractiveAttached(inst) {
+		return response.BadRequest(fmt.Errorf("The console is already attached to by another interactive session"))
+	}
+
 	ws := &consoleWs{}
 	ws.fds = map[int]string{}
 	ws.conns = map[int]*websocket.Conn{}
@@ -513,6 +581,11 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 	ws.width = post.Width
 	ws.height = post.Height
 	ws.protocol = post.Type
+	ws.readOnly = post.ReadOnly
+
+	if recordSession {
+		ws.recordPath = consoleRecordingPath(inst)
+	}
 
 	resources := map[string][]api.URL{}
 	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", ws.instance.Name())}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/state"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// consoleRecordingBanner is shown to the user when attaching to a console which is being recorded.
+const consoleRecordingBanner = "\r\nThis console session is being recorded.\r\n\r\n"
+
+// consoleRecorder records a console session in the asciicast v2 format.
+type consoleRecorder struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	start   time.Time
+}
+
+// consoleRecordingPath returns the path of a new recording for the instance's console.
+// Recordings capture the console input, so they're only accessible to root.
+func consoleRecordingPath(inst instance.Instance) string {
+	return filepath.Join(inst.ConsoleRecordingsPath(), fmt.Sprintf("console_%s.cast", time.Now().UTC().Format("20060102T150405.000000000Z")))
+}
+
+// expireConsoleRecordings removes the console recordings older than instances.console.record_expiry.
+func expireConsoleRecordings(ctx context.Context, s *state.State) error {
+	expiryDays := s.GlobalConfig.InstancesConsoleRecordExpiryDays()
+	if expiryDays <= 0 {
+		return nil
+	}
+
+	expiry := time.Duration(expiryDays) * 24 * time.Hour
+
+	recordingsPath := internalUtil.VarPath("console-recordings")
+	entries, err := os.ReadDir(recordingsPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, entry := range entries {
+		// At each iteration we check if we got cancelled in the meantime.
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		if !entry.IsDir() {
+			continue
+		}
+
+		instPath := filepath.Join(recordingsPath, entry.Name())
+		recordings, err := os.ReadDir(instPath)
+		if err != nil {
+			return err
+		}
+
+		remaining := len(recordings)
+		for _, recording := range recordings {
+			info, err := recording.Info()
+			if err != nil {
+				continue
+			}
+
+			if time.Since(info.ModTime()) < expiry {
+				continue
+			}
+
+			err = os.Remove(filepath.Join(instPath, recording.Name()))
+			if err != nil {
+				return err
+			}
+
+			remaining--
+		}
+
+		// Don't leave empty directories behind.
+		if remaining == 0 {
+			_ = os.Remove(instPath)
+		}
+	}
+
+	return nil
+}
+
+// newConsoleRecorder creates the recording file and writes its header.
+func newConsoleRecorder(path string, width int, height int) (*consoleRecorder, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating console recordings directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating console recording: %w", err)
+	}
+
+	r := &consoleRecorder{
+		file:    f,
+		encoder: json.NewEncoder(f),
+		start:   time.Now(),
+	}
+
+	err = r.encoder.Encode(map[string]any{
+		"version":   2,
+		"width":     width,
+		"height":    height,
+		"timestamp": r.start.Unix(),
+	})
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("Failed writing console recording header: %w", err)
+	}
+
+	return r, nil
+}
+
+// record appends an event to the recording.
+// The event type is "o" for output, "i" for input and "r" for terminal resizes.
+func (r *consoleRecorder) record(eventType string, data string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.encoder.Encode([]any{time.Since(r.start).Seconds(), eventType, data})
+	if err != nil {
+		logger.Warn("Failed writing console recording", logger.Ctx{"path": r.file.Name(), "err": err})
+	}
+}
+
+// Close closes the recording file.
+func (r *consoleRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.file.Close()
+}
+
+// recordedConsole records the data read from and written to a console.
+type recordedConsole struct {
+	io.ReadWriteCloser
+
+	recorder *consoleRecorder
+}
+
+func (c *recordedConsole) Read(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Read(p)
+	if n > 0 {
+		c.recorder.record("o", string(p[:n]))
+	}
+
+	return n, err
+}
+
+func (c *recordedConsole) Write(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Write(p)
+	if n > 0 {
+		c.recorder.record("i", string(p[:n]))
+	}
+
+	return n, err
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/lxc/incus/v6/internal/linux"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/termios"
+)
+
+// consoleSessionBuffer is how many console reads can be queued for a session before it's considered too slow.
+const consoleSessionBuffer = 256
+
+// consoleShare is the text console connection of an instance, shared between all the sessions attached to it.
+// The drivers only accept a single client on their console, so a single connection is opened for the first
+// session and its output is mirrored to every session while only the interactive one can write to it.
+type consoleShare struct {
+	key          string
+	console      *os.File
+	disconnectCh chan error
+	isPty        bool
+
+	mu          sync.Mutex
+	sessions    map[*consoleSession]struct{}
+	interactive bool
+	hadInput    bool
+	closed      bool
+}
+
+// consoleSession is a session attached to a shared console.
+type consoleSession struct {
+	share    *consoleShare
+	readOnly bool
+
+	// output receives the console output, it's closed once the console is gone.
+	output  chan []byte
+	pending []byte
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var consoleSharesMu sync.Mutex
+
+// consoleShares holds the shared consoles indexed by instance.
+var consoleShares = map[string]*consoleShare{}
+
+// consoleInteractiveAttached returns whether an interactive session is attached to the instance's console.
+func consoleInteractiveAttached(inst instance.Instance) bool {
+	consoleSharesMu.Lock()
+	defer consoleSharesMu.Unlock()
+
+	share := consoleShares[project.Instance(inst.Project().Name, inst.Name())]
+	if share == nil {
+		return false
+	}
+
+	share.mu.Lock()
+	defer share.mu.Unlock()
+
+	return share.interactive
+}
+
+// consoleAttach attaches a new session to the instance's console, connecting to it if no other session is.
+// Only a single interactive session can be attached at any given time.
+func consoleAttach(inst instance.Instance, readOnly bool) (*consoleSession, error) {
+	return consoleAttachShared(project.Instance(inst.Project().Name, inst.Name()), readOnly, func() (*os.File, chan error, error) {
+		return inst.Console(instance.ConsoleTypeConsole)
+	})
+}
+
+// consoleAttachShared attaches a new session to the console shared under the key, using connect to connect
+// to it if no other session is attached.
+func consoleAttachShared(key string, readOnly bool, connect func() (*os.File, chan error, error)) (*consoleSession, error) {
+	consoleSharesMu.Lock()
+	defer consoleSharesMu.Unlock()
+
+	share := consoleShares[key]
+	if share == nil {
+		console, disconnectCh, err := connect()
+		if err != nil {
+			return nil, err
+		}
+
+		share = &consoleShare{
+			key:          key,
+			console:      console,
+			disconnectCh: disconnectCh,
+			sessions:     map[*consoleSession]struct{}{},
+		}
+
+		// Avoid console.Fd() which would switch the console to blocking mode and prevent closing it
+		// while it's being read from.
+		rawConn, err := console.SyscallConn()
+		if err == nil {
+			_ = rawConn.Control(func(fd uintptr) { share.isPty = termios.IsTerminal(int(fd)) })
+		}
+
+		consoleShares[key] = share
+
+		go share.run()
+	}
+
+	share.mu.Lock()
+	defer share.mu.Unlock()
+
+	if !readOnly {
+		if share.interactive {
+			return nil, fmt.Errorf("The console is already attached to by another interactive session")
+		}
+
+		share.interactive = true
+		share.hadInput = true
+	}
+
+	session := &consoleSession{
+		share:    share,
+		readOnly: readOnly,
+		output:   make(chan []byte, consoleSessionBuffer),
+		done:     make(chan struct{}),
+	}
+
+	share.sessions[session] = struct{}{}
+
+	return session, nil
+}
+
+// setSize sets the window size of the console, which must be a terminal.
+func (c *consoleShare) setSize(width int, height int) error {
+	rawConn, err := c.console.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	controlErr := rawConn.Control(func(fd uintptr) { err = linux.SetPtySize(int(fd), width, height) })
+	if controlErr != nil {
+		return controlErr
+	}
+
+	return err
+}
+
+// run mirrors the console output to the attached sessions until the console is closed.
+func (c *consoleShare) run() {
+	buf := make([]byte, 32*1024)
+
+	for {
+		n, err := c.console.Read(buf)
+		if n > 0 {
+			data := bytes.Clone(buf[:n])
+
+			c.mu.Lock()
+			sessions := make([]*consoleSession, 0, len(c.sessions))
+			for session := range c.sessions {
+				sessions = append(sessions, session)
+			}
+
+			c.mu.Unlock()
+
+			for _, session := range sessions {
+				if !session.readOnly {
+					// Don't read ahead of the interactive session.
+					select {
+					case session.output <- data:
+					case <-session.done:
+					}
+
+					continue
+				}
+
+				// Read-only sessions mustn't hold back the others, so they're dropped if they can't keep up.
+				select {
+				case session.output <- data:
+				default:
+					logger.Warn("Detaching read-only console session which can't keep up", logger.Ctx{"instance": c.key})
+
+					c.mu.Lock()
+					_, found := c.sessions[session]
+					if found {
+						delete(c.sessions, session)
+						close(session.output)
+					}
+
+					c.mu.Unlock()
+				}
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	// Let new sessions connect to the console again and the remaining ones know that this one is gone.
+	consoleSharesMu.Lock()
+	defer consoleSharesMu.Unlock()
+
+	if consoleShares[c.key] == c {
+		delete(consoleShares, c.key)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for session := range c.sessions {
+		close(session.output)
+	}
+
+	c.sessions = map[*consoleSession]struct{}{}
+}
+
+// detach removes the session from the shared console, closing the console once no session is left.
+func (c *consoleShare) detach(session *consoleSession) error {
+	consoleSharesMu.Lock()
+	defer consoleSharesMu.Unlock()
+
+	c.mu.Lock()
+	_, found := c.sessions[session]
+	delete(c.sessions, session)
+	if found && !session.readOnly {
+		c.interactive = false
+	}
+
+	// Close the console once the last session is gone.
+	if len(c.sessions) > 0 || c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.closed = true
+	c.mu.Unlock()
+
+	if consoleShares[c.key] == c {
+		delete(consoleShares, c.key)
+	}
+
+	close(c.disconnectCh)
+
+	// Write a reset escape sequence to the console to cancel any ongoing reads to the handle and then close
+	// it. Consoles which were only ever watched are never written to, even to reset them.
+	if c.hadInput {
+		_, err := c.console.Write([]byte("\x1bc"))
+		if err != nil {
+			_ = c.console.Close()
+			return err
+		}
+	}
+
+	return c.console.Close()
+}
+
+// Read returns the console output.
+func (s *consoleSession) Read(p []byte) (int, error) {
+	if len(s.pending) == 0 {
+		select {
+		case data, ok := <-s.output:
+			if !ok {
+				return 0, io.EOF
+			}
+
+			s.pending = data
+		case <-s.done:
+			return 0, io.EOF
+		}
+	}
+
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+
+	return n, nil
+}
+
+// Write sends input to the console, only interactive sessions may do so.
+func (s *consoleSession) Write(p []byte) (int, error) {
+	if s.readOnly {
+		return 0, fmt.Errorf("Read-only console sessions can't send input")
+	}
+
+	return s.share.console.Write(p)
+}
+
+// Close detaches the session from the console.
+func (s *consoleSession) Close() error {
+	var err error
+
+	s.closeOnce.Do(func() {
+		close(s.done)
+		err = s.share.detach(s)
+	})
+
+	return err
+}
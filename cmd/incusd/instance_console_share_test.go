@@ -0,0 +1,113 @@
+package main
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// newTestConsole returns a connected pair of sockets, the first one standing for the instance console.
+func newTestConsole(t *testing.T) (*os.File, *os.File) {
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_NONBLOCK, 0)
+	require.NoError(t, err)
+
+	return os.NewFile(uintptr(fds[0]), "console"), os.NewFile(uintptr(fds[1]), "guest")
+}
+
+// A single console connection is shared between an interactive session and read-only ones.
+func TestConsoleAttachShared(t *testing.T) {
+	console, guest := newTestConsole(t)
+	defer func() { _ = guest.Close() }()
+
+	connects := 0
+	connect := func() (*os.File, chan error, error) {
+		connects++
+		return console, make(chan error, 1), nil
+	}
+
+	interactive, err := consoleAttachShared("test_c1", false, connect)
+	require.NoError(t, err)
+
+	watcher1, err := consoleAttachShared("test_c1", true, connect)
+	require.NoError(t, err)
+
+	watcher2, err := consoleAttachShared("test_c1", true, connect)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, connects)
+
+	// A second interactive session is refused.
+	_, err = consoleAttachShared("test_c1", false, connect)
+	assert.Error(t, err)
+
+	// The console output is mirrored to all sessions.
+	_, err = guest.Write([]byte("login: "))
+	require.NoError(t, err)
+
+	for _, session := range []*consoleSession{interactive, watcher1, watcher2} {
+		buf := make([]byte, 7)
+		_, err := io.ReadFull(session, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "login: ", string(buf))
+	}
+
+	// Only the interactive session can send input.
+	_, err = watcher1.Write([]byte("root\n"))
+	assert.Error(t, err)
+
+	_, err = interactive.Write([]byte("root\n"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(guest, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "root\n", string(buf))
+
+	// Watchers can keep watching once the interactive session is gone and a new one can attach.
+	require.NoError(t, interactive.Close())
+
+	interactive, err = consoleAttachShared("test_c1", false, connect)
+	require.NoError(t, err)
+	assert.Equal(t, 1, connects)
+
+	// The console is closed once the last session detaches.
+	require.NoError(t, interactive.Close())
+	require.NoError(t, watcher1.Close())
+	require.NoError(t, watcher2.Close())
+
+	consoleSharesMu.Lock()
+	_, found := consoleShares["test_c1"]
+	consoleSharesMu.Unlock()
+	assert.False(t, found)
+
+	// The reset sequence is sent before the console is closed.
+	data, err := io.ReadAll(guest)
+	require.NoError(t, err)
+	assert.Equal(t, "\x1bc", string(data))
+}
+
+// Consoles which were only ever watched aren't written to when closed.
+func TestConsoleAttachShared_ReadOnly(t *testing.T) {
+	console, guest := newTestConsole(t)
+	defer func() { _ = guest.Close() }()
+
+	watcher, err := consoleAttachShared("test_c2", true, func() (*os.File, chan error, error) {
+		return console, make(chan error, 1), nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, watcher.Close())
+
+	data, err := io.ReadAll(guest)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	// Sessions see the end of the console.
+	n, err := watcher.Read(make([]byte, 1))
+	assert.Equal(t, 0, n)
+	assert.Equal(t, io.EOF, err)
+}
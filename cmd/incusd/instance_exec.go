@@ -47,6 +47,9 @@ const execWSStdin = 0
 const execWSStdout = 1
 const execWSStderr = 2
 
+// execMaxExtraFDs is the maximum number of additional file descriptors which can be requested.
+const execMaxExtraFDs = 16
+
 type execWs struct {
 	req api.InstanceExecPost
 
@@ -242,8 +245,9 @@ func (s *execWs) Do(op *operations.Operation) error {
 			stdout = ttys[execWSStdout]
 		}
 	} else {
-		ttys = make([]*os.File, 3)
-		ptys = make([]*os.File, 3)
+		// Standard streams followed by any additional file descriptors.
+		ttys = make([]*os.File, 3+s.req.ExtraFDs)
+		ptys = make([]*os.File, 3+s.req.ExtraFDs)
 		for i := 0; i < len(ttys); i++ {
 			ptys[i], ttys[i], err = os.Pipe()
 			if err != nil {
@@ -259,6 +263,8 @@ func (s *execWs) Do(op *operations.Operation) error {
 	waitAttachedChildIsDead, markAttachedChildIsDead := context.WithCancel(context.Background())
 	var wgEOF sync.WaitGroup
 
+	var cmd instance.Cmd
+
 	// Define a function to clean up TTYs and sockets when done.
 	finisher := func(cmdResult int, cmdErr error) error {
 		// Cancel this before closing the control connection so control handler can detect command ending.
@@ -287,13 +293,22 @@ func (s *execWs) Do(op *operations.Operation) error {
 			_ = pty.Close()
 		}
 
+		var waitStatus unix.WaitStatus
+		if cmd != nil {
+			waitStatus = cmd.WaitStatus()
+		}
+
 		// Make VM disconnections (shutdown/reboot) match containers.
 		if cmdErr == drivers.ErrExecDisconnected {
 			cmdResult = 129
+			waitStatus = unix.WaitStatus(unix.SIGHUP)
 			cmdErr = nil
 		}
 
 		metadata := jmap.Map{"return": cmdResult}
+		if cmd != nil {
+			execExitMetadata(metadata, waitStatus)
+		}
 
 		err = op.ExtendMetadata(metadata)
 		if err != nil {
@@ -303,7 +318,12 @@ func (s *execWs) Do(op *operations.Operation) error {
 		return cmdErr
 	}
 
-	cmd, err := s.instance.Exec(s.req, stdin, stdout, stderr)
+	var extraFiles []*os.File
+	if !s.req.Interactive {
+		extraFiles = ttys[execWSStderr+1:]
+	}
+
+	cmd, err = s.instance.Exec(s.req, stdin, stdout, stderr, extraFiles)
 	if err != nil {
 		return finisher(-1, err)
 	}
@@ -494,6 +514,18 @@ func (s *execWs) Do(op *operations.Operation) error {
 	return finisher(exitStatus, err)
 }
 
+// execExitMetadata records how the command ended in the operation metadata, next to its exit code.
+// The "signal" field holds the signal which terminated the command (0 if it exited normally) and
+// "status" holds its raw wait status, allowing to tell a signal apart from a matching exit code.
+func execExitMetadata(metadata jmap.Map, status unix.WaitStatus) {
+	metadata["signal"] = 0
+	if status.Signaled() {
+		metadata["signal"] = int(status.Signal())
+	}
+
+	metadata["status"] = int(status)
+}
+
 // swagger:operation POST /1.0/instances/{name}/exec instances instance_exec_post
 //
 //	Run a command
@@ -502,6 +534,7 @@ func (s *execWs) Do(op *operations.Operation) error {
 //
 //	The returned operation metadata will contain either 2 or 4 websockets.
 //	In non-interactive mode, you'll get one websocket for each of stdin, stdout and stderr.
+//	When additional file descriptors are requested, one more websocket is added for each of them.
 //	In interactive mode, a single bi-directional websocket is used for stdin and stdout/stderr.
 //
 //	An additional "control" socket is always added on top which can be used for out of band communications.
@@ -570,6 +603,14 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Cannot use %q in combination with %q", "interactive", "record-output"))
 	}
 
+	if post.ExtraFDs < 0 || post.ExtraFDs > execMaxExtraFDs {
+		return response.BadRequest(fmt.Errorf("The number of additional file descriptors must be between 0 and %d", execMaxExtraFDs))
+	}
+
+	if post.ExtraFDs > 0 && (post.Interactive || !post.WaitForWS) {
+		return response.BadRequest(fmt.Errorf("Additional file descriptors require %q and can't be used with %q", "wait-for-websocket", "interactive"))
+	}
+
 	// Forward the request if the container is remote.
 	client, err := cluster.ConnectIfInstanceIsRemote(s, projectName, name, r, instanceType)
 	if err != nil {
@@ -604,6 +645,10 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Instance is frozen"))
 	}
 
+	if post.ExtraFDs > 0 && inst.Type() != instancetype.Container {
+		return response.BadRequest(fmt.Errorf("Additional file descriptors are only supported for containers"))
+	}
+
 	// Process environment.
 	if post.Environment == nil {
 		post.Environment = map[string]string{}
@@ -676,6 +721,10 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 		if !post.Interactive {
 			ws.conns[execWSStdout] = nil
 			ws.conns[execWSStderr] = nil
+
+			for i := 0; i < post.ExtraFDs; i++ {
+				ws.conns[execWSStderr+1+i] = nil
+			}
 		}
 
 		ws.waitRequiredConnected = cancel.New(context.Background())
@@ -739,7 +788,7 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 		}
 
 		// Run the command.
-		cmd, err := inst.Exec(post, nil, stdout, stderr)
+		cmd, err := inst.Exec(post, nil, stdout, stderr, nil)
 		if err != nil {
 			return err
 		}
@@ -751,6 +800,8 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 		l.Debug("Instance process stopped", logger.Ctx{"err": cmdErr, "exitStatus": exitStatus})
 
 		metadata["return"] = exitStatus
+		execExitMetadata(metadata, cmd.WaitStatus())
+
 		err = op.ExtendMetadata(metadata)
 		if err != nil {
 			l.Error("Error updating metadata for cmd", logger.Ctx{"err": err, "cmd": post.Command})
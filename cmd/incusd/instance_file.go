@@ -15,16 +15,20 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/sftp"
+	"golang.org/x/sys/unix"
 
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/revert"
 	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/idmap"
 	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 func instanceFileHandler(d *Daemon, r *http.Request) response.Response {
@@ -106,10 +110,20 @@ func instanceFileHandler(d *Daemon, r *http.Request) response.Response {
 //	    description: Project name
 //	    type: string
 //	    example: default
+//	  - in: header
+//	    name: X-Incus-sparse
+//	    description: Whether to return files as a sparse stream, leaving out their holes
+//	    schema:
+//	      type: boolean
+//	    example: true
 //	responses:
 //	  "200":
 //	     description: Raw file or directory listing
 //	     headers:
+//	       X-Incus-sparse:
+//	         description: Whether the file is returned as a sparse stream
+//	         schema:
+//	           type: boolean
 //	       X-Incus-uid:
 //	         description: File owner UID
 //	         schema:
@@ -202,6 +216,27 @@ func instanceFileGet(s *state.State, inst instance.Instance, path string, r *htt
 		cleanup := revert.Clone()
 		revert.Success()
 
+		// Stream only the non-empty parts of the file if requested.
+		if util.IsTrue(r.Header.Get("X-Incus-sparse")) {
+			headers["Content-Type"] = "application/octet-stream"
+			headers["X-Incus-sparse"] = "true"
+
+			s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceFileRetrieved.Event(inst, logger.Ctx{"path": path}))
+			return response.ManualResponse(func(w http.ResponseWriter) error {
+				defer cleanup.Fail()
+
+				// Set the headers.
+				for k, v := range headers {
+					w.Header().Set(k, v)
+				}
+
+				w.WriteHeader(http.StatusOK)
+
+				_, err := io.Copy(w, util.NewSparseReader(file))
+				return err
+			})
+		}
+
 		// Make a file response struct.
 		files := make([]response.FileResponseEntry, 1)
 		files[0].Identifier = filepath.Base(path)
@@ -425,6 +460,18 @@ func instanceFileHead(s *state.State, inst instance.Instance, path string, r *ht
 //	    schema:
 //	      type: string
 //	    example: overwrite
+//	  - in: header
+//	    name: X-Incus-xattr
+//	    description: Extended attribute to set on the file, as its name and base64 encoded value separated by "=" (can be repeated)
+//	    schema:
+//	      type: string
+//	    example: security.selinux=c3lzdGVtX3U6b2JqZWN0X3I6YmluX3Q6czAA
+//	  - in: header
+//	    name: X-Incus-sparse
+//	    description: Whether the body is a sparse stream, only holding the non-empty parts of the file
+//	    schema:
+//	      type: boolean
+//	    example: true
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/EmptySyncResponse"
@@ -437,6 +484,41 @@ func instanceFileHead(s *state.State, inst instance.Instance, path string, r *ht
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
 func instanceFilePost(s *state.State, inst instance.Instance, path string, r *http.Request) response.Response {
+	// Extract file ownership and mode from headers
+	uid, gid, mode, type_, write := api.ParseFileHeaders(r.Header)
+
+	if !slices.Contains([]string{"overwrite", "append"}, write) {
+		return response.BadRequest(fmt.Errorf("Bad file write mode: %s", write))
+	}
+
+	xattrs, err := api.ParseFileXattrHeaders(r.Header)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	for name, value := range xattrs {
+		err = instanceFileValidXattr(name, value)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
+	sparse := util.IsTrue(r.Header.Get("X-Incus-sparse"))
+	if sparse && (type_ != "file" || write != "overwrite") {
+		return response.BadRequest(fmt.Errorf("Sparse transfers are only supported when overwriting files"))
+	}
+
+	// Extended attributes are set from the host, which requires access to the running container's filesystem.
+	if len(xattrs) > 0 {
+		if type_ != "file" {
+			return response.BadRequest(fmt.Errorf("Extended attributes can only be set on files"))
+		}
+
+		if inst.Type() != instancetype.Container || !inst.IsRunning() {
+			return response.BadRequest(fmt.Errorf("Extended attributes can only be set on files in running containers"))
+		}
+	}
+
 	// Get a SFTP client.
 	client, err := inst.FileSFTP()
 	if err != nil {
@@ -445,13 +527,6 @@ func instanceFilePost(s *state.State, inst instance.Instance, path string, r *ht
 
 	defer func() { _ = client.Close() }()
 
-	// Extract file ownership and mode from headers
-	uid, gid, mode, type_, write := api.ParseFileHeaders(r.Header)
-
-	if !slices.Contains([]string{"overwrite", "append"}, write) {
-		return response.BadRequest(fmt.Errorf("Bad file write mode: %s", write))
-	}
-
 	// Check if the file already exists.
 	_, err = client.Stat(path)
 	exists := err == nil
@@ -478,7 +553,12 @@ func instanceFilePost(s *state.State, inst instance.Instance, path string, r *ht
 		}
 
 		// Transfer the file into the instance.
-		_, err = io.Copy(file, r.Body)
+		if sparse {
+			_, err = util.SparseCopy(file, r.Body)
+		} else {
+			_, err = io.Copy(file, r.Body)
+		}
+
 		if err != nil {
 			return response.InternalError(err)
 		}
@@ -501,6 +581,14 @@ func instanceFilePost(s *state.State, inst instance.Instance, path string, r *ht
 			}
 		}
 
+		// Set the extended attributes last as changing the ownership clears the file capabilities.
+		if len(xattrs) > 0 {
+			err = instanceFileSetXattrs(inst, path, xattrs)
+			if err != nil {
+				return response.SmartError(err)
+			}
+		}
+
 		s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceFilePushed.Event(inst, logger.Ctx{"path": path}))
 		return response.EmptySyncResponse
 	} else if type_ == "symlink" {
@@ -562,6 +650,86 @@ func instanceFilePost(s *state.State, inst instance.Instance, path string, r *ht
 	}
 }
 
+// instanceFileValidXattr checks whether an extended attribute may be set on a file pushed into an instance.
+// The attributes are set by the host, so only those the container's root user could set itself are allowed.
+func instanceFileValidXattr(name string, value string) error {
+	if name == "security.capability" {
+		// Revision 1, 2 and 3 (namespaced) file capabilities.
+		if !slices.Contains([]int{12, 20, 24}, len(value)) {
+			return fmt.Errorf("Invalid file capabilities of length %d", len(value))
+		}
+
+		return nil
+	}
+
+	if strings.HasPrefix(name, "user.") && len(name) > len("user.") {
+		return nil
+	}
+
+	return fmt.Errorf("Extended attribute %q isn't allowed, only user attributes and file capabilities can be set", name)
+}
+
+// instanceFileSetXattrs sets the extended attributes on a file of a running container.
+// File capabilities are always bound to the container's root user so that they can't grant more
+// than the container itself could.
+func instanceFileSetXattrs(inst instance.Instance, path string, xattrs map[string]string) error {
+	c, ok := inst.(instance.Container)
+	if !ok {
+		return fmt.Errorf("Instance %q isn't a container", inst.Name())
+	}
+
+	for name, value := range xattrs {
+		err := instanceFileValidXattr(name, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	rootfs, err := os.Open(fmt.Sprintf("/proc/%d/root", inst.InitPID()))
+	if err != nil {
+		return fmt.Errorf("Failed opening the container's filesystem: %w", err)
+	}
+
+	defer func() { _ = rootfs.Close() }()
+
+	// Resolve the path within the container's filesystem so symlinks can't point outside of it.
+	fd, err := unix.Openat2(int(rootfs.Fd()), strings.TrimPrefix(path, "/"), &unix.OpenHow{
+		Flags:   unix.O_PATH | unix.O_CLOEXEC,
+		Resolve: unix.RESOLVE_IN_ROOT | unix.RESOLVE_NO_MAGICLINKS,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed opening %q: %w", path, err)
+	}
+
+	defer func() { _ = unix.Close(fd) }()
+
+	fdPath := fmt.Sprintf("/proc/self/fd/%d", fd)
+
+	idmapSet, err := c.CurrentIdmap()
+	if err != nil {
+		return fmt.Errorf("Failed getting the container's idmap: %w", err)
+	}
+
+	var rootUID int64
+	if idmapSet != nil {
+		rootUID, _ = idmapSet.ShiftFromNS(0, 0)
+	}
+
+	for name, value := range xattrs {
+		if name == "security.capability" {
+			err = idmap.SetCaps(fdPath, []byte(value), rootUID)
+		} else {
+			err = unix.Setxattr(fdPath, name, []byte(value), 0)
+		}
+
+		if err != nil {
+			return fmt.Errorf("Failed setting extended attribute %q on %q: %w", name, path, err)
+		}
+	}
+
+	return nil
+}
+
 // swagger:operation DELETE /1.0/instances/{name}/files instances instance_files_delete
 //
 //	Delete a file
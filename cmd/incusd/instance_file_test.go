@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+)
+
+func TestInstanceFileValidXattr(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{name: "user.comment", value: "hello", valid: true},
+		{name: "user.", value: "hello", valid: false},
+		{name: "security.capability", value: strings.Repeat("\x00", 20), valid: true},
+		{name: "security.capability", value: strings.Repeat("\x00", 24), valid: true},
+		{name: "security.capability", value: strings.Repeat("\x00", 4096), valid: false},
+		{name: "security.selinux", value: "system_u:object_r:shadow_t:s0", valid: false},
+		{name: "trusted.overlay.opaque", value: "y", valid: false},
+		{name: "system.posix_acl_access", value: "", valid: false},
+	}
+
+	for _, test := range tests {
+		err := instanceFileValidXattr(test.name, test.value)
+		if test.valid {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.Error(t, err, test.name)
+		}
+	}
+}
+
+// Extended attributes which the container couldn't set itself are rejected before anything is written.
+func (suite *containerTestSuite) TestInstanceFilePost_RejectsPrivilegedXattrs() {
+	args := db.InstanceArgs{
+		Type:      instancetype.Container,
+		Ephemeral: false,
+		Name:      "testFoo",
+	}
+
+	c, op, _, err := instance.CreateInternal(suite.d.State(), args, true, true)
+	suite.Req.Nil(err)
+	op.Done(nil)
+	defer func() { _ = c.Delete(true) }()
+
+	for _, header := range []string{"trusted.overlay.opaque=eQ==", "security.selinux=c3lzdGVtX3U6b2JqZWN0X3I6c2hhZG93X3Q6czA="} {
+		r := httptest.NewRequest(http.MethodPost, "/1.0/instances/testFoo/files?path=/foo", strings.NewReader("foo"))
+		r.Header.Set("X-Incus-xattr", header)
+		w := httptest.NewRecorder()
+
+		resp := instanceFilePost(suite.d.State(), c, "/foo", r)
+		suite.Req.Nil(resp.Render(w))
+		suite.Equal(http.StatusBadRequest, w.Code)
+		suite.Contains(w.Body.String(), "isn't allowed")
+	}
+
+	// User attributes get past the allowlist, the stopped container is what fails the request.
+	r := httptest.NewRequest(http.MethodPost, "/1.0/instances/testFoo/files?path=/foo", strings.NewReader("foo"))
+	r.Header.Set("X-Incus-xattr", "user.comment=aGVsbG8=")
+	w := httptest.NewRecorder()
+
+	resp := instanceFilePost(suite.d.State(), c, "/foo", r)
+	suite.Req.Nil(resp.Render(w))
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "running containers")
+}
@@ -136,7 +136,7 @@ func instanceRebuildPost(d *Daemon, r *http.Request) response.Response {
 
 	run := func(op *operations.Operation) error {
 		if req.Source.Type == "none" {
-			return instanceRebuildFromEmpty(s, inst, op)
+			return instanceRebuildFromEmpty(s, inst, req.ResetIdentity, op)
 		}
 
 		if req.Source.Server != "" {
@@ -150,7 +150,7 @@ func instanceRebuildPost(d *Daemon, r *http.Request) response.Response {
 			return fmt.Errorf("Image not provided for instance rebuild")
 		}
 
-		return instanceRebuildFromImage(context.TODO(), s, r, inst, sourceImage, op)
+		return instanceRebuildFromImage(context.TODO(), s, r, inst, sourceImage, req.ResetIdentity, op)
 	}
 
 	resources := map[string][]api.URL{}
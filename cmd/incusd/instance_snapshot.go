@@ -8,14 +8,20 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/kballard/go-shellquote"
+	"golang.org/x/sys/unix"
 
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/cluster"
 	"github.com/lxc/incus/v6/internal/server/db"
-	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/operations"
@@ -27,6 +33,7 @@ import (
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/validate"
 )
 
@@ -257,7 +264,7 @@ func instanceSnapshotsPost(d *Daemon, r *http.Request) response.Response {
 	}
 
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
-		dbProject, err := cluster.GetProject(context.Background(), tx.Tx(), projectName)
+		dbProject, err := dbCluster.GetProject(context.Background(), tx.Tx(), projectName)
 		if err != nil {
 			return err
 		}
@@ -320,6 +327,11 @@ func instanceSnapshotsPost(d *Daemon, r *http.Request) response.Response {
 
 	var expiry time.Time
 	if req.ExpiresAt != nil {
+		// A zero expiry disables the expiry, anything else must be in the future.
+		if !req.ExpiresAt.IsZero() && !req.ExpiresAt.After(time.Now()) {
+			return response.BadRequest(fmt.Errorf("Snapshot expiry date must be in the future"))
+		}
+
 		expiry = *req.ExpiresAt
 	} else {
 		expiry, err = internalInstance.GetExpiry(time.Now(), inst.ExpandedConfig()["snapshots.expiry"])
@@ -330,7 +342,7 @@ func instanceSnapshotsPost(d *Daemon, r *http.Request) response.Response {
 
 	snapshot := func(op *operations.Operation) error {
 		inst.SetOperation(op)
-		return inst.Snapshot(req.Name, expiry, req.Stateful)
+		return instanceSnapshotCreate(inst, req.Name, expiry, req.Stateful)
 	}
 
 	resources := map[string][]api.URL{}
@@ -711,6 +723,11 @@ func snapshotPost(s *state.State, r *http.Request, snapInst instance.Instance) r
 		return response.BadRequest(err)
 	}
 
+	promote, err := raw.GetBool("promote")
+	if err == nil && promote {
+		return snapshotPromote(s, r, snapInst, newName)
+	}
+
 	// Validate the name
 	err = validate.IsURLSegmentSafe(newName)
 	if err != nil {
@@ -748,6 +765,94 @@ func snapshotPost(s *state.State, r *http.Request, snapInst instance.Instance) r
 	return operations.OperationResponse(op)
 }
 
+// snapshotPromote creates a new standalone instance from the snapshot in a single operation.
+// The instance is created on the member holding the snapshot unless another one is requested through the
+// target query parameter.
+func snapshotPromote(s *state.State, r *http.Request, snapInst instance.Instance, newName string) response.Response {
+	if newName == "" {
+		return response.BadRequest(fmt.Errorf("A name for the new instance must be provided"))
+	}
+
+	err := instance.ValidName(newName, false)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	projectName := snapInst.Project().Name
+
+	// Promoting a snapshot creates a new instance, so the snapshot entitlement isn't enough.
+	err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectProject(projectName), auth.EntitlementCanCreateInstances)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	target := request.QueryParam(r, "target")
+	if target != "" && !s.ServerClustered {
+		return response.BadRequest(fmt.Errorf("Target only allowed when clustered"))
+	}
+
+	profileNames := make([]string, 0, len(snapInst.Profiles()))
+	for _, profile := range snapInst.Profiles() {
+		profileNames = append(profileNames, profile.Name)
+	}
+
+	req := api.InstancesPost{
+		Name: newName,
+		Type: snapInst.Type().ToAPI(),
+		Source: api.InstanceSource{
+			Type:         "copy",
+			Source:       snapInst.Name(),
+			Project:      projectName,
+			InstanceOnly: true,
+		},
+		InstancePut: api.InstancePut{
+			Profiles: profileNames,
+		},
+	}
+
+	// Create the instance on another member through the regular instance creation, which pulls the snapshot from here.
+	if target != "" && target != s.ServerName {
+		address, err := cluster.ResolveTarget(r.Context(), s, target)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		run := func(op *operations.Operation) error {
+			client, err := cluster.Connect(address, s.Endpoints.NetworkCert(), s.ServerCert(), r, false)
+			if err != nil {
+				return err
+			}
+
+			createOp, err := client.UseProject(projectName).UseTarget(target).CreateInstance(req)
+			if err != nil {
+				return err
+			}
+
+			return createOp.Wait()
+		}
+
+		resources := map[string][]api.URL{}
+		resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", newName)}
+
+		op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.InstanceCreate, resources, nil, run, nil, nil, r)
+		if err != nil {
+			return response.InternalError(err)
+		}
+
+		return operations.OperationResponse(op)
+	}
+
+	// Check that the project's limits are not violated.
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return project.AllowInstanceCreation(tx, projectName, req)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return createFromCopy(r.Context(), s, r, projectName, snapInst.Profiles(), &req)
+}
+
 // swagger:operation DELETE /1.0/instances/{name}/snapshots/{snapshot} instances instance_snapshot_delete
 //
 //	Delete a snapshot
@@ -792,3 +897,155 @@ func snapshotDelete(s *state.State, r *http.Request, snapInst instance.Instance)
 
 	return operations.OperationResponse(op)
 }
+
+// instanceSnapshotHookTimeout is how long the snapshot hooks may run before being killed.
+const instanceSnapshotHookTimeout = 5 * time.Minute
+
+// instanceSnapshotRunHook runs the command set in the given snapshot hook config key inside the instance.
+// Hooks are only run on running instances as they require executing commands inside of them.
+func instanceSnapshotRunHook(inst instance.Instance, key string) error {
+	command := inst.ExpandedConfig()[key]
+	if command == "" || !inst.IsRunning() || inst.IsFrozen() {
+		return nil
+	}
+
+	fields, err := shellquote.Split(command)
+	if err != nil {
+		return fmt.Errorf("Failed parsing %q: %w", key, err)
+	}
+
+	req := api.InstanceExecPost{
+		Command: fields,
+		Environment: map[string]string{
+			"PATH": "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+			"HOME": "/root",
+			"USER": "root",
+			"LANG": "C.UTF-8",
+		},
+	}
+
+	// Pass the instance environment the same way exec does.
+	for k, v := range inst.ExpandedConfig() {
+		envKey, found := strings.CutPrefix(k, "environment.")
+		if found {
+			req.Environment[envKey] = v
+		}
+	}
+
+	// The hooks aren't interactive, give them an empty stdin and capture their output.
+	stdin, err := os.Open(os.DevNull)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = stdin.Close() }()
+
+	var stdout, stderr bytes.Buffer
+
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = stdoutReader.Close() }()
+	defer func() { _ = stdoutWriter.Close() }()
+
+	stderrReader, stderrWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = stderrReader.Close() }()
+	defer func() { _ = stderrWriter.Close() }()
+
+	outputDone := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(&stdout, stdoutReader)
+		outputDone <- struct{}{}
+	}()
+
+	go func() {
+		_, _ = io.Copy(&stderr, stderrReader)
+		outputDone <- struct{}{}
+	}()
+
+	cmd, err := inst.Exec(req, stdin, stdoutWriter, stderrWriter, nil)
+	if err != nil {
+		return fmt.Errorf("Failed running %q: %w", key, err)
+	}
+
+	// Don't let a hung command block the snapshot, kill it once it runs out of time.
+	ctx, cancel := context.WithTimeout(context.Background(), instanceSnapshotHookTimeout)
+	defer cancel()
+
+	var exitStatus int
+	waitDone := make(chan struct{})
+	go func() {
+		exitStatus, err = cmd.Wait()
+		close(waitDone)
+	}()
+
+	select {
+	case <-waitDone:
+	case <-ctx.Done():
+		_ = cmd.Signal(unix.SIGKILL)
+		return fmt.Errorf("Command from %q didn't complete within %s", key, instanceSnapshotHookTimeout)
+	}
+
+	// Close our end of the pipes so the output can be fully read, processes left behind by the command may
+	// still hold them open so don't wait on those past the timeout.
+	_ = stdoutWriter.Close()
+	_ = stderrWriter.Close()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-outputDone:
+		case <-ctx.Done():
+			_ = stdoutReader.Close()
+			_ = stderrReader.Close()
+			<-outputDone
+		}
+	}
+
+	logger.Debug("Snapshot hook completed", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "key": key, "stdout": stdout.String(), "stderr": stderr.String()})
+
+	if err != nil {
+		return fmt.Errorf("Failed running %q: %w (stderr: %q)", key, err, strings.TrimSpace(stderr.String()))
+	}
+
+	if exitStatus != 0 {
+		return fmt.Errorf("Command from %q exited with status %d (stderr: %q)", key, exitStatus, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// instanceSnapshotCreate creates a snapshot of the instance, running its pre and post snapshot hooks around it.
+// The post snapshot hook is always run, even if the snapshot failed, so the application is resumed.
+func instanceSnapshotCreate(inst instance.Instance, name string, expiry time.Time, stateful bool) error {
+	l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "snapshot": name})
+
+	err := instanceSnapshotRunHook(inst, "snapshots.pre_hook")
+	if err != nil {
+		postErr := instanceSnapshotRunHook(inst, "snapshots.post_hook")
+		if postErr != nil {
+			l.Warn("Failed running post snapshot hook", logger.Ctx{"err": postErr})
+		}
+
+		return fmt.Errorf("Failed running pre snapshot hook: %w", err)
+	}
+
+	snapshotErr := inst.Snapshot(name, expiry, stateful)
+
+	err = instanceSnapshotRunHook(inst, "snapshots.post_hook")
+	if err != nil {
+		if snapshotErr != nil {
+			l.Warn("Failed running post snapshot hook", logger.Ctx{"err": err})
+			return snapshotErr
+		}
+
+		return fmt.Errorf("Failed running post snapshot hook: %w", err)
+	}
+
+	return snapshotErr
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// denyingAuthorizer denies a single entitlement and defers to the wrapped authorizer otherwise.
+type denyingAuthorizer struct {
+	auth.Authorizer
+
+	entitlement auth.Entitlement
+}
+
+func (a *denyingAuthorizer) CheckPermission(ctx context.Context, r *http.Request, object auth.Object, entitlement auth.Entitlement) error {
+	if entitlement == a.entitlement {
+		return api.StatusErrorf(http.StatusForbidden, "User does not have entitlement %q on object %q", entitlement, object)
+	}
+
+	return a.Authorizer.CheckPermission(ctx, r, object, entitlement)
+}
+
+func (suite *containerTestSuite) TestSnapshotPromote_RequiresCanCreateInstances() {
+	args := db.InstanceArgs{
+		Type:      instancetype.Container,
+		Ephemeral: false,
+		Name:      "testFoo",
+	}
+
+	c, op, _, err := instance.CreateInternal(suite.d.State(), args, true, true)
+	suite.Req.Nil(err)
+	op.Done(nil)
+	defer func() { _ = c.Delete(true) }()
+
+	s := *suite.d.State()
+	s.Authorizer = &denyingAuthorizer{Authorizer: s.Authorizer, entitlement: auth.EntitlementCanCreateInstances}
+
+	r := httptest.NewRequest(http.MethodPost, "/1.0/instances/testFoo/snapshots/snap0", nil)
+	w := httptest.NewRecorder()
+
+	resp := snapshotPromote(&s, r, c, "testBar")
+	suite.Req.Nil(resp.Render(w))
+	suite.Equal(http.StatusForbidden, w.Code, "Promoting a snapshot without can_create_instances should be denied")
+
+	// The instance must not have been created.
+	_, err = instance.LoadByProjectAndName(suite.d.State(), "default", "testBar")
+	suite.NotNil(err)
+}
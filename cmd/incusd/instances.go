@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"sync"
@@ -156,6 +157,20 @@ var instanceBackupExportCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: instanceBackupExportGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanManageBackups, "name")},
 }
 
+var instanceBackupVerifyCmd = APIEndpoint{
+	Name: "instanceBackupVerify",
+	Path: "instances/{name}/backups/{backupName}/verify",
+
+	Post: APIEndpointAction{Handler: instanceBackupVerifyPost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanManageBackups, "name")},
+}
+
+var instanceBackupRestoreCmd = APIEndpoint{
+	Name: "instanceBackupRestore",
+	Path: "instances/{name}/backups/{backupName}/restore",
+
+	Post: APIEndpointAction{Handler: instanceBackupRestorePost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanManageBackups, "name")},
+}
+
 type instanceAutostartList []instance.Instance
 
 func (slice instanceAutostartList) Len() int {
@@ -207,17 +222,7 @@ func instancesStart(s *state.State, instances []instance.Instance) {
 	// Let's make up to 3 attempts to start instances.
 	maxAttempts := 3
 
-	// Start the instances
-	for _, inst := range instances {
-		if !instanceShouldAutoStart(inst) {
-			continue
-		}
-
-		// If already running, we're done.
-		if inst.IsRunning() {
-			continue
-		}
-
+	startInstance := func(inst instance.Instance) {
 		// Get the instance config.
 		config := inst.ExpandedConfig()
 		autoStartDelay := config["boot.autostart.delay"]
@@ -280,6 +285,48 @@ func instancesStart(s *state.State, instances []instance.Instance) {
 			break
 		}
 	}
+
+	// Start instances of the same priority concurrently, up to the configured limit.
+	parallelism := max(s.GlobalConfig.InstancesAutostartParallelism(), 1)
+
+	var wg sync.WaitGroup
+	instStartCh := make(chan instance.Instance)
+	for i := 0; i < parallelism; i++ {
+		go func() {
+			for inst := range instStartCh {
+				startInstance(inst)
+				wg.Done()
+			}
+		}()
+	}
+
+	var currentBatchPriority int
+	first := true
+	for _, inst := range instances {
+		if !instanceShouldAutoStart(inst) {
+			continue
+		}
+
+		// If already running, we're done.
+		if inst.IsRunning() {
+			continue
+		}
+
+		priority, _ := strconv.Atoi(inst.ExpandedConfig()["boot.autostart.priority"])
+
+		// Wait for instances with higher priority to be started before starting the next batch.
+		if first || priority != currentBatchPriority {
+			first = false
+			currentBatchPriority = priority
+			wg.Wait()
+		}
+
+		wg.Add(1)
+		instStartCh <- inst
+	}
+
+	wg.Wait()
+	close(instStartCh)
 }
 
 type instanceStopList []instance.Instance
@@ -375,9 +422,35 @@ func instancesOnDisk(s *state.State) ([]instance.Instance, error) {
 	return instances, nil
 }
 
-func instancesShutdown(s *state.State, instances []instance.Instance) {
+// instancesShutdownSummary records how instances were stopped during a daemon shutdown.
+type instancesShutdownSummary struct {
+	Stopped      int      // Number of instances which stopped cleanly.
+	Skipped      int      // Number of instances which weren't running.
+	ForceStopped []string // Instances which had to be (or were configured to be) forcefully stopped.
+	Failed       []string // Instances which couldn't be stopped at all.
+}
+
+func instancesShutdown(s *state.State, instances []instance.Instance) instancesShutdownSummary {
 	sort.Sort(instanceStopList(instances))
 
+	var summary instancesShutdownSummary
+	var summaryMu sync.Mutex
+
+	recordResult := func(inst instance.Instance, forced bool, err error) {
+		name := fmt.Sprintf("%s/%s", inst.Project().Name, inst.Name())
+
+		summaryMu.Lock()
+		defer summaryMu.Unlock()
+
+		if err != nil {
+			summary.Failed = append(summary.Failed, name)
+		} else if forced {
+			summary.ForceStopped = append(summary.ForceStopped, name)
+		} else {
+			summary.Stopped++
+		}
+	}
+
 	// Limit shutdown concurrency to number of instances or number of CPU cores (which ever is less).
 	var wg sync.WaitGroup
 	instShutdownCh := make(chan instance.Instance)
@@ -403,20 +476,28 @@ func instancesShutdown(s *state.State, instances []instance.Instance) {
 					if err != nil {
 						logger.Warn("Failed statefully stopping instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
 					}
+
+					recordResult(inst, false, err)
 				} else if action == "force-stop" {
 					err := inst.Stop(false)
 					if err != nil {
 						logger.Warn("Failed forcefully stopping instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
 					}
+
+					recordResult(inst, true, err)
 				} else {
+					forced := false
 					err := inst.Shutdown(time.Second * time.Duration(timeoutSeconds))
 					if err != nil {
 						logger.Warn("Failed shutting down instance, forcefully stopping", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+						forced = true
 						err = inst.Stop(false)
 						if err != nil {
 							logger.Warn("Failed forcefully stopping instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
 						}
 					}
+
+					recordResult(inst, forced, err)
 				}
 
 				if inst.ID() > 0 {
@@ -435,6 +516,7 @@ func instancesShutdown(s *state.State, instances []instance.Instance) {
 	for i, inst := range instances {
 		// Skip stopped instances.
 		if !inst.IsRunning() {
+			summary.Skipped++
 			continue
 		}
 
@@ -455,4 +537,9 @@ func instancesShutdown(s *state.State, instances []instance.Instance) {
 
 	wg.Wait()
 	close(instShutdownCh)
+
+	slices.Sort(summary.ForceStopped)
+	slices.Sort(summary.Failed)
+
+	return summary
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/osarch"
 	localtls "github.com/lxc/incus/v6/shared/tls"
 	"github.com/lxc/incus/v6/shared/util"
 )
@@ -68,6 +69,11 @@ func urlInstanceTypeDetect(r *http.Request) (instancetype.Type, error) {
 //      name: all-projects
 //      description: Retrieve instances from all projects
 //      type: boolean
+//    - in: query
+//      name: architecture
+//      description: Only return instances of the given architecture
+//      type: string
+//      example: aarch64
 //  responses:
 //    "200":
 //      description: API endpoints
@@ -126,6 +132,11 @@ func urlInstanceTypeDetect(r *http.Request) (instancetype.Type, error) {
 //      name: all-projects
 //      description: Retrieve instances from all projects
 //      type: boolean
+//    - in: query
+//      name: architecture
+//      description: Only return instances of the given architecture
+//      type: string
+//      example: aarch64
 //  responses:
 //    "200":
 //      description: API endpoints
@@ -183,6 +194,11 @@ func urlInstanceTypeDetect(r *http.Request) (instancetype.Type, error) {
 //      name: all-projects
 //      description: Retrieve instances from all projects
 //      type: boolean
+//    - in: query
+//      name: architecture
+//      description: Only return instances of the given architecture
+//      type: string
+//      example: aarch64
 //  responses:
 //    "200":
 //      description: API endpoints
@@ -259,6 +275,19 @@ func doInstancesGet(s *state.State, r *http.Request) (any, error) {
 
 	mustLoadObjects := recursion > 0 || (recursion == 0 && clauses != nil && len(clauses.Clauses) > 0)
 
+	// Parse the architecture filter, applied when listing the instances from the database.
+	architecture := osarch.ARCH_UNKNOWN
+	architectureName := r.FormValue("architecture")
+	if architectureName != "" {
+		architecture, err = osarch.ArchitectureId(architectureName)
+		if err != nil {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Invalid architecture: %w", err)
+		}
+
+		// Use the canonical name to match the instances returned by other members.
+		architectureName, _ = osarch.ArchitectureName(architecture)
+	}
+
 	// Detect project mode.
 	projectName := request.QueryParam(r, "project")
 	allProjects := util.IsTrue(r.FormValue("all-projects"))
@@ -289,7 +318,7 @@ func doInstancesGet(s *state.State, r *http.Request) (any, error) {
 
 		offlineThreshold := s.GlobalConfig.OfflineThreshold()
 
-		memberAddressInstances, err = tx.GetInstancesByMemberAddress(ctx, offlineThreshold, filteredProjects, instanceType)
+		memberAddressInstances, err = tx.GetInstancesByMemberAddress(ctx, offlineThreshold, filteredProjects, instanceType, architecture)
 		if err != nil {
 			return fmt.Errorf("Failed getting instances by member address: %w", err)
 		}
@@ -383,6 +412,11 @@ func doInstancesGet(s *state.State, r *http.Request) (any, error) {
 					}
 
 					for _, apiInst := range apiInsts {
+						// Other members return all their instances, so apply the architecture filter here.
+						if architectureName != "" && apiInst.Architecture != architectureName {
+							continue
+						}
+
 						apiInst := apiInst // Local variable for append.
 						resultFullListAppend(&api.InstanceFull{Instance: apiInst})
 					}
@@ -400,6 +434,10 @@ func doInstancesGet(s *state.State, r *http.Request) (any, error) {
 				}
 
 				for _, c := range cs {
+					if architectureName != "" && c.Architecture != architectureName {
+						continue
+					}
+
 					c := c // Local variable for append.
 					resultFullListAppend(&c)
 				}
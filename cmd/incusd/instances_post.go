@@ -13,6 +13,7 @@ import (
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/gorilla/websocket"
 
+	incus "github.com/lxc/incus/v6/client"
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/revert"
 	"github.com/lxc/incus/v6/internal/server/backup"
@@ -563,7 +564,7 @@ func createFromCopy(ctx context.Context, s *state.State, r *http.Request, projec
 	return operations.OperationResponse(op)
 }
 
-func createFromBackup(s *state.State, r *http.Request, projectName string, data io.Reader, pool string, instanceName string) response.Response {
+func createFromBackup(s *state.State, r *http.Request, projectName string, data io.Reader, pool string, instanceName string, restorePlacement bool) response.Response {
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -647,6 +648,45 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 		return response.SmartError(err)
 	}
 
+	// Restore the instance on a cluster member equivalent to the one it was backed up from.
+	if restorePlacement && s.ServerClustered && bInfo.Placement != nil {
+		var targetMember *db.NodeInfo
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			targetMember, err = backupPlacementMember(ctx, s, tx, projectName, bInfo)
+			return err
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		if targetMember.Name != s.ServerName {
+			_, err = backupFile.Seek(0, io.SeekStart)
+			if err != nil {
+				return response.InternalError(err)
+			}
+
+			client, err := cluster.Connect(targetMember.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			client = client.UseProject(projectName)
+
+			logger.Debug("Forward instance backup import", logger.Ctx{"local": s.ServerName, "target": targetMember.Name, "targetAddress": targetMember.Address})
+			op, err := client.CreateInstanceFromBackup(incus.InstanceBackupArgs{
+				BackupFile: backupFile,
+				PoolName:   pool,
+				Name:       instanceName,
+			})
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			opAPI := op.Get()
+			return operations.ForwardedOperationResponse(projectName, &opAPI)
+		}
+	}
+
 	bInfo.Project = projectName
 
 	// Override pool.
@@ -774,6 +814,58 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 	return operations.OperationResponse(op)
 }
 
+// backupPlacementMember returns the cluster member matching the placement recorded in a backup.
+// This is the original member when it can still host the instance, otherwise the member with the least
+// instances in the same failure domain.
+func backupPlacementMember(ctx context.Context, s *state.State, tx *db.ClusterTx, projectName string, bInfo *backup.Info) (*db.NodeInfo, error) {
+	dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	targetProject, err := dbProject.ToAPI(ctx, tx.Tx())
+	if err != nil {
+		return nil, err
+	}
+
+	architecture, err := osarch.ArchitectureId(bInfo.Config.Container.Architecture)
+	if err != nil {
+		return nil, err
+	}
+
+	allMembers, err := tx.GetNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting cluster members: %w", err)
+	}
+
+	candidateMembers, err := tx.GetCandidateMembers(ctx, allMembers, []int{architecture}, "", project.GetRestrictedClusterGroups(targetProject), s.GlobalConfig.OfflineThreshold())
+	if err != nil {
+		return nil, err
+	}
+
+	domainMembers := []db.NodeInfo{}
+	for _, member := range candidateMembers {
+		if member.Name == bInfo.Placement.Member {
+			return &member, nil
+		}
+
+		failureDomain, err := tx.GetNodeFailureDomain(ctx, member.ID)
+		if err != nil {
+			return nil, fmt.Errorf("Failed getting failure domain of cluster member %q: %w", member.Name, err)
+		}
+
+		if failureDomain == bInfo.Placement.FailureDomain {
+			domainMembers = append(domainMembers, member)
+		}
+	}
+
+	if len(domainMembers) == 0 {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "No cluster member available in failure domain %q to restore the backup to", bInfo.Placement.FailureDomain)
+	}
+
+	return tx.GetNodeWithLeastInstances(ctx, domainMembers)
+}
+
 // swagger:operation POST /1.0/instances instances instances_post
 //
 //	Create a new instance
@@ -828,7 +920,7 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 
 	// If we're getting binary content, process separately
 	if r.Header.Get("Content-Type") == "application/octet-stream" {
-		return createFromBackup(s, r, targetProjectName, r.Body, r.Header.Get("X-Incus-pool"), r.Header.Get("X-Incus-name"))
+		return createFromBackup(s, r, targetProjectName, r.Body, r.Header.Get("X-Incus-pool"), r.Header.Get("X-Incus-name"), util.IsTrue(r.Header.Get("X-Incus-restore-placement")))
 	}
 
 	// Parse the request
@@ -1063,6 +1155,20 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 			}
 		}
 
+		// Keep the instances of the same spread group across multiple failure domains.
+		spreadGroup := req.Config["cluster.spread_group"]
+		if s.ServerClustered && !clusterNotification && spreadGroup != "" && s.GlobalConfig.ClusterSpreadFailureDomains() {
+			if targetMemberInfo != nil {
+				_, err = instancesSpreadFilterMembers(ctx, tx, targetProjectName, spreadGroup, []db.NodeInfo{*targetMemberInfo})
+			} else {
+				candidateMembers, err = instancesSpreadFilterMembers(ctx, tx, targetProjectName, spreadGroup, candidateMembers)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
 		if !clusterNotification {
 			// Check that the project's limits are not violated. Note this check is performed after
 			// automatically generated config values (such as ones from an InstanceType) have been set.
@@ -1101,7 +1207,7 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 			reqExpanded.Config = db.ExpandInstanceConfig(reqExpanded.Config, profiles)
 			reqExpanded.Devices = db.ExpandInstanceDevices(deviceConfig.NewDevices(reqExpanded.Devices), profiles).CloneNative()
 
-			targetMemberInfo, err = scriptlet.InstancePlacementRun(r.Context(), logger.Log, s, &reqExpanded, candidateMembers, leaderAddress)
+			targetMemberInfo, err = scriptlet.InstancePlacementRun(r.Context(), logger.Log, s, &reqExpanded, candidateMembers, nil, leaderAddress)
 			if err != nil {
 				return response.SmartError(fmt.Errorf("Failed instance placement scriptlet: %w", err))
 			}
@@ -1330,3 +1436,47 @@ func instanceCreateFinish(s *state.State, req *api.InstancesPost, args db.Instan
 
 	return inst.Start(false)
 }
+
+// instancesSpreadFilterMembers returns the candidate members on which an instance of the spread group can be placed
+// without having all the instances of the group in the same failure domain.
+func instancesSpreadFilterMembers(ctx context.Context, tx *db.ClusterTx, projectName string, spreadGroup string, candidateMembers []db.NodeInfo) ([]db.NodeInfo, error) {
+	groupMembers, err := tx.GetInstanceMembersWithConfig(ctx, projectName, "cluster.spread_group", spreadGroup)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting instances of spread group %q: %w", spreadGroup, err)
+	}
+
+	// Nothing to spread the instance from.
+	if len(groupMembers) == 0 {
+		return candidateMembers, nil
+	}
+
+	memberDomains, err := tx.GetNodesFailureDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting cluster member failure domains: %w", err)
+	}
+
+	groupDomains := map[uint64]bool{}
+	for _, address := range groupMembers {
+		groupDomains[memberDomains[address]] = true
+	}
+
+	// The group already spans multiple failure domains.
+	if len(groupDomains) > 1 {
+		return candidateMembers, nil
+	}
+
+	allowedMembers := make([]db.NodeInfo, 0, len(candidateMembers))
+	for _, member := range candidateMembers {
+		if groupDomains[memberDomains[member.Address]] {
+			continue
+		}
+
+		allowedMembers = append(allowedMembers, member)
+	}
+
+	if len(allowedMembers) == 0 {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Placing the instance would put all instances of spread group %q in the same failure domain", spreadGroup)
+	}
+
+	return allowedMembers, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/lxc/incus/v6/internal/server/backup"
+	"github.com/lxc/incus/v6/internal/server/backup/config"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/osarch"
+)
+
+// Backups are restored to their original member when possible, otherwise to another member of its failure domain.
+func (suite *containerTestSuite) TestBackupPlacementMember() {
+	architecture, err := osarch.ArchitectureGetLocal()
+	suite.Req.Nil(err)
+
+	newInfo := func(member string, failureDomain string) *backup.Info {
+		return &backup.Info{
+			Config:    &config.Config{Container: &api.Instance{Architecture: architecture}},
+			Placement: &backup.Placement{Member: member, FailureDomain: failureDomain},
+		}
+	}
+
+	// Roll back the changes to the cluster members once done.
+	errRollback := errors.New("rollback")
+
+	err = suite.d.db.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		members := map[string]string{"buzz": "rack1", "fizz": "rack1", "other": "rack2"}
+		ids := map[string]int64{}
+
+		for name, domain := range members {
+			id, err := tx.CreateNode(name, name+":8443")
+			suite.Req.Nil(err)
+
+			err = tx.UpdateNodeFailureDomain(ctx, id, domain)
+			suite.Req.Nil(err)
+
+			ids[name] = id
+		}
+
+		// The original member.
+		member, err := backupPlacementMember(ctx, suite.d.State(), tx, api.ProjectDefaultName, newInfo("fizz", "rack1"))
+		suite.Req.Nil(err)
+		suite.Equal("fizz", member.Name)
+
+		// Another member of the failure domain.
+		member, err = backupPlacementMember(ctx, suite.d.State(), tx, api.ProjectDefaultName, newInfo("gone", "rack2"))
+		suite.Req.Nil(err)
+		suite.Equal("other", member.Name)
+
+		// The original member can't host new instances.
+		err = tx.UpdateNodeCordoned(ids["fizz"], true)
+		suite.Req.Nil(err)
+
+		member, err = backupPlacementMember(ctx, suite.d.State(), tx, api.ProjectDefaultName, newInfo("fizz", "rack1"))
+		suite.Req.Nil(err)
+		suite.Equal("buzz", member.Name)
+
+		// No member left in the failure domain.
+		_, err = backupPlacementMember(ctx, suite.d.State(), tx, api.ProjectDefaultName, newInfo("gone", "rack3"))
+		suite.True(api.StatusErrorCheck(err, http.StatusBadRequest))
+
+		return errRollback
+	})
+	suite.Req.ErrorIs(err, errRollback)
+}
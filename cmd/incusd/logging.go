@@ -18,12 +18,17 @@ import (
 	"github.com/lxc/incus/v6/shared/logger"
 )
 
-// This task function expires logs when executed. It's started by the Daemon
-// and will run once every 24h.
+// This task function expires logs and console recordings when executed. It's
+// started by the Daemon and will run once every 24h.
 func expireLogsTask(state *state.State) (task.Func, task.Schedule) {
 	f := func(ctx context.Context) {
 		opRun := func(op *operations.Operation) error {
-			return expireLogs(ctx, state)
+			err := expireLogs(ctx, state)
+			if err != nil {
+				return err
+			}
+
+			return expireConsoleRecordings(ctx, state)
 		}
 
 		op, err := operations.OperationCreate(state, "", operations.OperationClassTask, operationtype.LogsExpire, nil, nil, opRun, nil, nil, nil)
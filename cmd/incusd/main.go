@@ -21,11 +21,14 @@ type cmdGlobal struct {
 	flagHelp    bool
 	flagVersion bool
 
-	flagLogFile    string
-	flagLogDebug   bool
-	flagLogSyslog  bool
-	flagLogTrace   []string
-	flagLogVerbose bool
+	flagLogFile      string
+	flagLogDebug     bool
+	flagLogSink      []string
+	flagLogSyslog    bool
+	flagLogTimestamp string
+	flagLogTimezone  string
+	flagLogTrace     []string
+	flagLogVerbose   bool
 }
 
 func (c *cmdGlobal) Run(cmd *cobra.Command, args []string) error {
@@ -51,12 +54,37 @@ func (c *cmdGlobal) Run(cmd *cobra.Command, args []string) error {
 	response.Init(daemon.Debug)
 
 	// Setup logger
-	syslog := ""
+	sinks := []logger.Sink{{Type: logger.SinkStderr, Format: logger.SinkFormatText}}
+
+	if c.flagLogFile != "" {
+		sinks = append(sinks, logger.Sink{Type: logger.SinkFile, Target: c.flagLogFile, Format: logger.SinkFormatText})
+	}
+
 	if c.flagLogSyslog {
-		syslog = "incus"
+		sinks = append(sinks, logger.Sink{Type: logger.SinkSyslog, Target: "incus"})
+	}
+
+	for _, value := range c.flagLogSink {
+		sink, err := logger.ParseSink(value)
+		if err != nil {
+			return err
+		}
+
+		sinks = append(sinks, *sink)
+	}
+
+	// Apply the default timestamp settings to the sinks that don't override them.
+	for i := range sinks {
+		if sinks[i].Timestamp == "" {
+			sinks[i].Timestamp = c.flagLogTimestamp
+		}
+
+		if sinks[i].Timezone == "" {
+			sinks[i].Timezone = c.flagLogTimezone
+		}
 	}
 
-	err = logger.InitLogger(c.flagLogFile, syslog, c.flagLogVerbose, c.flagLogDebug, events.NewEventHandler())
+	err = logger.InitLoggerSinks(sinks, c.flagLogVerbose, c.flagLogDebug, events.NewEventHandler())
 	if err != nil {
 		return err
 	}
@@ -93,6 +121,9 @@ func main() {
 	app.PersistentFlags().BoolVarP(&globalCmd.flagHelp, "help", "h", false, "Print help")
 	app.PersistentFlags().StringVar(&globalCmd.flagLogFile, "logfile", "", "Path to the log file"+"``")
 	app.PersistentFlags().BoolVar(&globalCmd.flagLogSyslog, "syslog", false, "Log to syslog")
+	app.PersistentFlags().StringArrayVar(&globalCmd.flagLogSink, "log-sink", []string{}, "Additional log destination (TYPE[:TARGET][,OPTION=VALUE...])"+"``")
+	app.PersistentFlags().StringVar(&globalCmd.flagLogTimestamp, "log-timestamp", "", "Log timestamp format (rfc3339 or rfc3339nano)"+"``")
+	app.PersistentFlags().StringVar(&globalCmd.flagLogTimezone, "log-timezone", "", "Log timezone (local or utc)"+"``")
 	app.PersistentFlags().StringArrayVar(&globalCmd.flagLogTrace, "trace", []string{}, "Log tracing targets"+"``")
 	app.PersistentFlags().BoolVarP(&globalCmd.flagLogDebug, "debug", "d", false, "Show all debug messages")
 	app.PersistentFlags().BoolVarP(&globalCmd.flagLogVerbose, "verbose", "v", false, "Show all information messages")
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/lxc/incus/v6/client"
+	clusterConfig "github.com/lxc/incus/v6/internal/server/cluster/config"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
@@ -25,6 +26,8 @@ func init() {
 
 type cmdActivateifneeded struct {
 	global *cmdGlobal
+
+	flagReason bool
 }
 
 func (c *cmdActivateifneeded) Command() *cobra.Command {
@@ -35,14 +38,18 @@ func (c *cmdActivateifneeded) Command() *cobra.Command {
   Check if the daemon should be started
 
   This command will check if the daemon has any auto-started instances,
-  instances which were running prior to the last shutdown or if it's
-  configured to listen on the network address.
+  instances which were running prior to the last shutdown, cluster work to
+  do or if it's configured to listen on the network or metrics address.
 
   If at least one of those is true, then a connection will be attempted to the
   socket which will cause a socket-activated daemon to be spawned.
+
+  With --reason, the category which triggered the activation (or "none") is
+  printed on stderr.
 `
 	cmd.RunE = c.Run
 	cmd.Hidden = true
+	cmd.Flags().BoolVar(&c.flagReason, "reason", false, "Print the reason for activating the daemon on stderr")
 
 	return cmd
 }
@@ -60,6 +67,8 @@ func (c *cmdActivateifneeded) Run(cmd *cobra.Command, args []string) error {
 	path := d.os.LocalDatabasePath()
 	if !util.PathExists(d.os.LocalDatabasePath()) {
 		logger.Debugf("No local database, so no need to start the daemon now")
+		c.printReason("none")
+
 		return nil
 	}
 
@@ -86,9 +95,12 @@ func (c *cmdActivateifneeded) Run(cmd *cobra.Command, args []string) error {
 
 	// Look for network socket
 	if localHTTPAddress != "" {
-		logger.Debugf("Daemon has core.https_address set, activating...")
-		_, err := incus.ConnectIncusUnix("", nil)
-		return err
+		return c.activate("https-address", "Daemon has core.https_address set, activating...")
+	}
+
+	// Look for metrics socket
+	if localConfig.MetricsAddress() != "" {
+		return c.activate("metrics-address", "Daemon has core.metrics_address set, activating...")
 	}
 
 	// Set a non-nil IdmapSet to be able to load unprivileged instances
@@ -98,6 +110,8 @@ func (c *cmdActivateifneeded) Run(cmd *cobra.Command, args []string) error {
 	path = d.os.GlobalDatabasePath()
 	if !util.PathExists(path) {
 		logger.Debugf("No global database, so no need to start the daemon now")
+		c.printReason("none")
+
 		return nil
 	}
 
@@ -113,6 +127,42 @@ func (c *cmdActivateifneeded) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Look for cluster-driven work when clustered
+	var member *db.NodeInfo
+	clusterAddress := localConfig.ClusterAddress()
+	if clusterAddress != "" {
+		var globalConfig *clusterConfig.Config
+		err = d.State().DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			memberInfo, err := tx.GetNodeByAddress(ctx, clusterAddress)
+			if err != nil {
+				return err
+			}
+
+			member = &memberInfo
+
+			// Only consider the instances of this server.
+			d.serverName = member.Name
+
+			globalConfig, err = clusterConfig.Load(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if member.State == db.ClusterMemberStateEvacuated {
+			return c.activate("cluster-evacuated", "Cluster member is evacuated, activating...")
+		}
+
+		if globalConfig.ClusterHealingThreshold() > 0 {
+			return c.activate("cluster-healing", "Cluster has cluster.healing_threshold set, activating...")
+		}
+	}
+
 	instances, err := instance.LoadNodeAll(d.State(), instancetype.Any)
 	if err != nil {
 		return err
@@ -120,23 +170,17 @@ func (c *cmdActivateifneeded) Run(cmd *cobra.Command, args []string) error {
 
 	for _, inst := range instances {
 		if instanceShouldAutoStart(inst) {
-			logger.Debugf("Daemon has auto-started instances, activating...")
-			_, err := incus.ConnectIncusUnix("", nil)
-			return err
+			return c.activate("autostart", "Daemon has auto-started instances, activating...")
 		}
 
 		if inst.IsRunning() {
-			logger.Debugf("Daemon has running instances, activating...")
-			_, err := incus.ConnectIncusUnix("", nil)
-			return err
+			return c.activate("running", "Daemon has running instances, activating...")
 		}
 
 		// Check for scheduled instance snapshots
 		config := inst.ExpandedConfig()
 		if config["snapshots.schedule"] != "" {
-			logger.Debugf("Daemon has scheduled instance snapshots, activating...")
-			_, err := incus.ConnectIncusUnix("", nil)
-			return err
+			return c.activate("instance-snapshot-schedule", "Daemon has scheduled instance snapshots, activating...")
 		}
 	}
 
@@ -156,16 +200,32 @@ func (c *cmdActivateifneeded) Run(cmd *cobra.Command, args []string) error {
 
 	for _, vol := range volumes {
 		if vol.Config["snapshots.schedule"] != "" {
-			logger.Debugf("Daemon has scheduled volume snapshots, activating...")
-			_, err := incus.ConnectIncusUnix("", nil)
-			return err
+			return c.activate("volume-snapshot-schedule", "Daemon has scheduled volume snapshots, activating...")
 		}
 	}
 
 	logger.Debugf("No need to start the daemon now")
+	c.printReason("none")
+
 	return nil
 }
 
+// activate connects to the daemon's socket which causes a socket-activated daemon to be spawned.
+func (c *cmdActivateifneeded) activate(reason string, message string) error {
+	logger.Debug(message)
+	c.printReason(reason)
+
+	_, err := incus.ConnectIncusUnix("", nil)
+	return err
+}
+
+// printReason prints the reason for activating (or not) the daemon if requested.
+func (c *cmdActivateifneeded) printReason(reason string) {
+	if c.flagReason {
+		fmt.Fprintln(os.Stderr, reason)
+	}
+}
+
 // Configure the sqlite connection so that it's safe to access the
 // dqlite-managed sqlite file, also without setting up raft.
 func sqliteDirectAccess(conn *sqlite3.SQLiteConn) error {
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"time"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/sys/unix"
@@ -14,6 +15,9 @@ import (
 	"github.com/lxc/incus/v6/shared/logger"
 )
 
+// daemonDebugDuration is how long debug logging stays enabled after receiving SIGUSR2.
+const daemonDebugDuration = 10 * time.Minute
+
 type cmdDaemon struct {
 	global *cmdGlobal
 
@@ -71,6 +75,9 @@ func (c *cmdDaemon) Run(cmd *cobra.Command, args []string) error {
 	chIgnore := make(chan os.Signal, 1)
 	signal.Notify(chIgnore, unix.SIGHUP)
 
+	chDebug := make(chan os.Signal, 1)
+	signal.Notify(chDebug, unix.SIGUSR2)
+
 	err := d.Init()
 	if err != nil {
 		return err
@@ -88,6 +95,16 @@ func (c *cmdDaemon) Run(cmd *cobra.Command, args []string) error {
 				}()
 			}
 
+		case <-chDebug:
+			// Capture a time-boxed window of debug logs, reverting automatically to the configured level.
+			err = logger.RaiseLevel("debug", daemonDebugDuration)
+			if err != nil {
+				logger.Error("Failed raising log level", logger.Ctx{"err": err})
+				continue
+			}
+
+			logger.Warn("Debug logging enabled", logger.Ctx{"duration": daemonDebugDuration})
+
 		case err = <-d.shutdownDoneCh:
 			return err
 		}
@@ -140,8 +140,43 @@ restart:
 #define EXEC_STDOUT_FD 4
 #define EXEC_STDERR_FD 5
 #define EXEC_PIPE_FD 6
+#define EXEC_MAX_EXTRA_FDS 16
 #define ARRAY_SIZE(arr) (sizeof(arr) / sizeof((arr)[0]))
 
+// The additional file descriptors are passed right after the status pipe but
+// the command expects them to start at fd 3. Move the standard streams and the
+// status pipe above them, then shift the additional ones into place.
+static int setup_extra_fds(int extra_fds, int *stdin_fd, int *stdout_fd, int *stderr_fd, int *status_pipe)
+{
+	int base = EXEC_PIPE_FD + extra_fds + 1;
+
+	*stdin_fd = fcntl(EXEC_STDIN_FD, F_DUPFD_CLOEXEC, base);
+	if (*stdin_fd < 0)
+		return -errno;
+
+	*stdout_fd = fcntl(EXEC_STDOUT_FD, F_DUPFD_CLOEXEC, base);
+	if (*stdout_fd < 0)
+		return -errno;
+
+	*stderr_fd = fcntl(EXEC_STDERR_FD, F_DUPFD_CLOEXEC, base);
+	if (*stderr_fd < 0)
+		return -errno;
+
+	*status_pipe = fcntl(EXEC_PIPE_FD, F_DUPFD_CLOEXEC, base);
+	if (*status_pipe < 0)
+		return -errno;
+
+	for (int i = 0; i < extra_fds; i++) {
+		if (dup2(EXEC_PIPE_FD + 1 + i, EXEC_STDIN_FD + i) < 0)
+			return -errno;
+	}
+
+	for (int fd = EXEC_STDIN_FD + extra_fds; fd < base; fd++)
+		close(fd);
+
+	return 0;
+}
+
 // We use a separate function because cleanup macros are called during stack
 // unwinding if I'm not mistaken and if the compiler knows it exits it won't
 // call them. That's not a problem since we're exiting but I just like to be on
@@ -159,7 +194,11 @@ __attribute__ ((noinline)) static int __forkexec(void)
 	lxc_attach_command_t command = {
 		.program = NULL,
 	};
-	int fds_to_ignore[] = {EXEC_STDIN_FD, EXEC_STDOUT_FD, EXEC_STDERR_FD, EXEC_PIPE_FD};
+	int fds_to_ignore[EXEC_PIPE_FD - EXEC_STDIN_FD + 1 + EXEC_MAX_EXTRA_FDS];
+	size_t len_fds_to_ignore = 0;
+	int stdin_fd = EXEC_STDIN_FD, stdout_fd = EXEC_STDOUT_FD, stderr_fd = EXEC_STDERR_FD;
+	int extra_fds;
+	int status;
 	ssize_t ret;
 	pid_t attached_pid;
 	uid_t uid;
@@ -187,6 +226,9 @@ __attribute__ ((noinline)) static int __forkexec(void)
 	coresched = atoi(advance_arg(true));
 	if (coresched != 0 && coresched != 1)
 		_exit(EXIT_FAILURE);
+	extra_fds = atoi(advance_arg(true));
+	if (extra_fds < 0 || extra_fds > EXEC_MAX_EXTRA_FDS)
+		_exit(EXIT_FAILURE);
 
 	for (char *arg = NULL, *section = NULL; (arg = advance_arg(false)); ) {
 		if (!strcmp(arg, "--") && (!section || strcmp(section, "cmd"))) {
@@ -217,7 +259,10 @@ __attribute__ ((noinline)) static int __forkexec(void)
 	if (!argvp || !*argvp)
 		return log_error(EXIT_FAILURE, "No command specified");
 
-	ret = incus_close_range(EXEC_PIPE_FD + 1, UINT_MAX, CLOSE_RANGE_UNSHARE);
+	for (int fd = EXEC_STDIN_FD; fd <= EXEC_PIPE_FD + extra_fds; fd++)
+		fds_to_ignore[len_fds_to_ignore++] = fd;
+
+	ret = incus_close_range(EXEC_PIPE_FD + extra_fds + 1, UINT_MAX, CLOSE_RANGE_UNSHARE);
 	if (ret) {
 		// Fallback to close_inherited() when the syscall is not
 		// available or when CLOSE_RANGE_UNSHARE isn't supported.
@@ -225,12 +270,18 @@ __attribute__ ((noinline)) static int __forkexec(void)
 		// available but openSUSE Leap 15.3 seems to have a partial
 		// backport without CLOSE_RANGE_UNSHARE support.
 		if (errno == ENOSYS || errno == EINVAL)
-			ret = close_inherited(fds_to_ignore, ARRAY_SIZE(fds_to_ignore));
+			ret = close_inherited(fds_to_ignore, len_fds_to_ignore);
 	}
 
 	if (ret)
 		return log_error(EXIT_FAILURE, "Aborting attach to prevent leaking file descriptors into container");
 
+	if (extra_fds > 0) {
+		ret = setup_extra_fds(extra_fds, &stdin_fd, &stdout_fd, &stderr_fd, &status_pipe);
+		if (ret)
+			return log_error(EXIT_FAILURE, "Failed to setup additional file descriptors");
+	}
+
 	ret = fd_cloexec(status_pipe, true);
 	if (ret)
 		return log_errno(EXIT_FAILURE, "Failed to make pipe close-on-exec");
@@ -248,9 +299,9 @@ __attribute__ ((noinline)) static int __forkexec(void)
 		attach_options.initial_cwd = cwd;
 	attach_options.env_policy = LXC_ATTACH_CLEAR_ENV;
 	attach_options.extra_env_vars = envvp;
-	attach_options.stdin_fd = 3;
-	attach_options.stdout_fd = 4;
-	attach_options.stderr_fd = 5;
+	attach_options.stdin_fd = stdin_fd;
+	attach_options.stdout_fd = stdout_fd;
+	attach_options.stderr_fd = stderr_fd;
 	attach_options.uid = uid;
 	attach_options.gid = gid;
 	command.program = argvp[0];
@@ -311,6 +362,11 @@ out_reap:
 	if (ret < 0)
 		return log_error(EXIT_FAILURE, "Failed to wait for child process %d", attached_pid);
 
+	// Report the raw wait status so the daemon can tell signals apart from exit codes.
+	status = ret;
+	if (write_nointr(status_pipe, &status, sizeof(status)) < 0)
+		fprintf(stderr, "Failed to send wait status of executing child to daemon\n");
+
 	if (WIFEXITED(ret))
 		return WEXITSTATUS(ret);
 
@@ -343,7 +399,7 @@ type cmdForkexec struct {
 func (c *cmdForkexec) Command() *cobra.Command {
 	// Main subcommand
 	cmd := &cobra.Command{}
-	cmd.Use = "forkexec <container name> <containers path> <config> <cwd> <uid> <gid> <coresched> -- env [key=value...] -- cmd <args...>"
+	cmd.Use = "forkexec <container name> <containers path> <config> <cwd> <uid> <gid> <coresched> <extra fds> -- env [key=value...] -- cmd <args...>"
 	cmd.Short = "Execute a task inside the container"
 	cmd.Long = `Description:
   Execute a task inside the container
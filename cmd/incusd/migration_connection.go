@@ -13,6 +13,7 @@ import (
 
 	"github.com/gorilla/websocket"
 
+	internalIO "github.com/lxc/incus/v6/internal/io"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/tcp"
@@ -70,6 +71,27 @@ type migrationConn struct {
 	conn           *websocket.Conn
 	connected      chan struct{}
 	disconnected   bool
+	bandwidthLimit int64
+}
+
+// rateLimitedConn wraps a connection, limiting the rate at which data is written to it.
+type rateLimitedConn struct {
+	io.ReadWriteCloser
+	writer io.Writer
+}
+
+// Write implements the Writer interface.
+func (c *rateLimitedConn) Write(p []byte) (int, error) {
+	return c.writer.Write(p)
+}
+
+// SetBandwidthLimit limits the rate (in bytes per second) at which data is sent over the connection.
+// A limit of zero disables rate limiting.
+func (c *migrationConn) SetBandwidthLimit(limit int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.bandwidthLimit = limit
 }
 
 // Secret returns the secret for this connection.
@@ -159,7 +181,17 @@ func (c *migrationConn) WebsocketIO(ctx context.Context) (io.ReadWriteCloser, er
 		return nil, err
 	}
 
-	return ws.NewWrapper(wsConn), nil
+	conn := ws.NewWrapper(wsConn)
+
+	c.mu.Lock()
+	bandwidthLimit := c.bandwidthLimit
+	c.mu.Unlock()
+
+	if bandwidthLimit > 0 {
+		return &rateLimitedConn{ReadWriteCloser: conn, writer: internalIO.NewRateLimitWriter(conn, bandwidthLimit)}, nil
+	}
+
+	return conn, nil
 }
 
 // Close closes the connection (if established) and marks it as disconnected so that it cannot be used again.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/task"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
@@ -512,3 +514,31 @@ func networkZonePut(d *Daemon, r *http.Request) response.Response {
 
 	return response.EmptySyncResponse
 }
+
+// networkZoneHealthCheckTask runs the health checks of the network zone records.
+// Only the cluster leader runs them, the results are shared with the other members through the database.
+func networkZoneHealthCheckTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		if s.ServerClustered {
+			leader, err := d.gateway.LeaderAddress()
+			if err != nil {
+				// No leader may be elected yet, the checks are retried on the next run anyway.
+				logger.Debug("Skipping network zone health checks as the cluster leader is unknown", logger.Ctx{"err": err})
+				return
+			}
+
+			if s.LocalConfig.ClusterAddress() != leader {
+				return // Skip health checks if not cluster leader.
+			}
+		}
+
+		err := zone.HealthCheck(ctx, s)
+		if err != nil {
+			logger.Warn("Failed running network zone health checks", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(10 * time.Second)
+}
@@ -1481,6 +1481,28 @@ func networkLeasesGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponse(true, leases)
 }
 
+// networksReady is closed once all the managed networks have been initialized on this member.
+var networksReady = make(chan struct{})
+var networksReadyOnce sync.Once
+
+// networkMarkReady records that all the managed networks have been initialized.
+func networkMarkReady() {
+	networksReadyOnce.Do(func() { close(networksReady) })
+}
+
+// networkWaitReady waits for all the managed networks to be initialized, up to the given timeout.
+// Returns false if they weren't all initialized in time.
+func networkWaitReady(ctx context.Context, timeout time.Duration) bool {
+	select {
+	case <-networksReady:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-time.After(timeout):
+		return false
+	}
+}
+
 func networkStartup(s *state.State) error {
 	var err error
 
@@ -1667,6 +1689,7 @@ func networkStartup(s *state.State) error {
 
 					if remainingNetworks <= 0 {
 						logger.Info("All networks initialized")
+						networkMarkReady()
 					}
 
 					// At least one remaining network was initialized, check if any instances
@@ -1688,6 +1711,7 @@ func networkStartup(s *state.State) error {
 		}()
 	} else {
 		logger.Info("All networks initialized")
+		networkMarkReady()
 	}
 
 	return nil
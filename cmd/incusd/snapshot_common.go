@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"math/rand"
 	"strconv"
 	"strings"
 	"time"
@@ -25,12 +26,18 @@ var SnapshotScheduleAliases = map[string]string{
 	"@never":    "",
 }
 
-func snapshotIsScheduledNow(spec string, subjectID int64) bool {
+// snapshotIsScheduledNow returns whether the schedule is due now for the subject.
+// When jitter is set, the schedule is delayed by a stable random number of minutes (up to jitter) for the
+// subject, spreading the snapshots of subjects sharing the same schedule.
+// The schedule is evaluated in the given timezone, or in the local one if nil.
+func snapshotIsScheduledNow(spec string, subjectID int64, jitter int64, timezone *time.Location) bool {
 	var result = false
 
+	delay := getJitterForSubject(subjectID, jitter)
+
 	specs := buildCronSpecs(spec, subjectID)
 	for _, curSpec := range specs {
-		isNow, err := cronSpecIsNow(curSpec)
+		isNow, err := cronSpecIsNow(curSpec, delay, timezone)
 		if err == nil && isNow {
 			result = true
 		}
@@ -97,14 +104,33 @@ func getObfuscatedTimeValuesForSubject(subjectID int64) (string, string) {
 	return minuteResult, hourResult
 }
 
-func cronSpecIsNow(spec string) (bool, error) {
+// snapshotScheduleJitterMax is the maximum number of minutes scheduled snapshots can be delayed by.
+const snapshotScheduleJitterMax = 24 * 60
+
+// getJitterForSubject returns a stable random delay of up to jitter minutes for the subject.
+func getJitterForSubject(subjectID int64, jitter int64) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+
+	jitter = min(jitter, snapshotScheduleJitterMax)
+
+	delay := rand.New(rand.NewSource(subjectID)).Int63n(jitter + 1)
+
+	return time.Duration(delay) * time.Minute
+}
+
+func cronSpecIsNow(spec string, delay time.Duration, timezone *time.Location) (bool, error) {
 	sched, err := cron.ParseStandard(spec)
 	if err != nil {
 		return false, fmt.Errorf("Could not parse cron '%s'", spec)
 	}
 
-	// Check if it's time to snapshot
-	now := time.Now()
+	// Check if it's time to snapshot, evaluating the schedule as of the delay ago.
+	now := time.Now().Add(-delay)
+	if timezone != nil {
+		now = now.In(timezone)
+	}
 
 	// Truncate the time now back to the start of the minute.
 	// This is neded because the cron scheduler will add a minute to the scheduled time
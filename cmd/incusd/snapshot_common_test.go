@@ -1,7 +1,9 @@
 package main
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -20,7 +22,7 @@ func (suite *containerTestSuite) TestSnapshotScheduling() {
 	c, op, _, err := instance.CreateInternal(suite.d.State(), args, true, true)
 	suite.Req.Nil(err)
 	suite.Equal(true, snapshotIsScheduledNow("* * * * *",
-		int64(c.ID())),
+		int64(c.ID()), 0, nil),
 		"snapshot.schedule config '* * * * *' should have matched now")
 	suite.Equal(true, snapshotIsScheduledNow("@daily,"+
 		"@hourly,"+
@@ -30,7 +32,7 @@ func (suite *containerTestSuite) TestSnapshotScheduling() {
 		"@annually,"+
 		"@yearly,"+
 		" * * * * *",
-		int64(c.ID())),
+		int64(c.ID()), 0, nil),
 		"snapshot.schedule config '* * * * *' should have matched now")
 	op.Done(nil)
 }
@@ -38,3 +40,26 @@ func (suite *containerTestSuite) TestSnapshotScheduling() {
 func TestSnapshotCommon(t *testing.T) {
 	suite.Run(t, new(containerTestSuite))
 }
+
+func TestGetJitterForSubject(t *testing.T) {
+	// No jitter means no delay.
+	if getJitterForSubject(42, 0) != 0 {
+		t.Error("Expected no delay without jitter")
+	}
+
+	// The delay is stable for a subject and within the jitter.
+	delay := getJitterForSubject(42, 30)
+	if delay != getJitterForSubject(42, 30) {
+		t.Error("Expected a stable delay for the same subject")
+	}
+
+	if delay < 0 || delay > 30*time.Minute {
+		t.Errorf("Delay %v isn't within the jitter", delay)
+	}
+
+	// Large values are capped without allocating anything proportional to them.
+	delay = getJitterForSubject(42, math.MaxUint32)
+	if delay < 0 || delay > snapshotScheduleJitterMax*time.Minute {
+		t.Errorf("Delay %v isn't capped", delay)
+	}
+}
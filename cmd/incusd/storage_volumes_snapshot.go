@@ -1212,7 +1212,7 @@ func pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d *Daemon) (task.Func, t
 				}
 
 				// Check if snapshot is scheduled.
-				if !snapshotIsScheduledNow(schedule, v.ID) {
+				if !snapshotIsScheduledNow(schedule, v.ID, 0, nil) {
 					continue
 				}
 
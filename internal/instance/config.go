@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kballard/go-shellquote"
+
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/validate"
@@ -157,6 +159,9 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//   -  `stateful-stop`: Instances are not migrated. Instead, they are stopped on the current server
 	//      but with their runtime state (memory) stored on disk for resuming on restore.
 	//   -  `force-stop`: Instances are not migrated. Instead, they are forcefully stopped.
+	//   -  `relaunch`: Instances are stopped. Those using remote storage are then started on another server,
+	//      while the others are started again on restore.
+	//   -  `skip`: Instances are left untouched on the evacuated server and reported as skipped.
 	//
 	// See {ref}`cluster-evacuate` for more information.
 	// ---
@@ -164,7 +169,27 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  defaultdesc: `auto`
 	//  liveupdate: no
 	//  shortdesc: What to do when evacuating the instance
-	"cluster.evacuate": validate.Optional(validate.IsOneOf("auto", "migrate", "live-migrate", "stop", "stateful-stop", "force-stop")),
+	"cluster.evacuate": validate.Optional(validate.IsOneOf("auto", "migrate", "live-migrate", "stop", "stateful-stop", "force-stop", "relaunch", "skip")),
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=cluster.evacuate.bandwidth_limit)
+	// Maximum amount of data per second sent to the target server when migrating the instance as part of
+	// a cluster member evacuation.
+	// See {ref}`cluster-evacuate` for more information.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Bandwidth limit (in bytes per second) when migrating the instance during evacuation
+	"cluster.evacuate.bandwidth_limit": validate.Optional(validate.IsSize),
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=cluster.spread_group)
+	// Instances of a project sharing the same value are spread across failure domains when
+	// {config:option}`server-cluster:cluster.spread_failure_domains` is enabled.
+	// Only the value set on the instance itself is considered, not one coming from a profile.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Group of instances to spread across failure domains
+	"cluster.spread_group": validate.IsAny,
 
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu)
 	// A number or a specific range of CPUs to expose to the instance.
@@ -286,6 +311,32 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Schedule for automatic instance snapshots
 	"snapshots.schedule": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly", "@startup", "@never"})),
 
+	// gendoc:generate(entity=instance, group=snapshots, key=snapshots.schedule.jitter)
+	// Each instance gets a stable random delay within the window, which spreads the snapshots of instances sharing the same schedule.
+	// The delay can't exceed 1440 minutes (24 hours).
+	// ---
+	//  type: integer
+	//  defaultdesc: `0`
+	//  liveupdate: no
+	//  shortdesc: Maximum number of minutes to delay scheduled snapshots by
+	"snapshots.schedule.jitter": validate.Optional(validate.IsInRange(0, 24*60)),
+
+	// gendoc:generate(entity=instance, group=snapshots, key=snapshots.schedule.timezone)
+	// Specify an IANA timezone name, like `Europe/Paris`, to evaluate {config:option}`instance-snapshots:snapshots.schedule` in that timezone rather than in the local timezone of the server.
+	// ---
+	//  type: string
+	//  defaultdesc: local timezone of the server
+	//  liveupdate: no
+	//  shortdesc: Timezone in which the snapshot schedule is evaluated
+	"snapshots.schedule.timezone": validate.Optional(func(value string) error {
+		_, err := time.LoadLocation(value)
+		if err != nil {
+			return fmt.Errorf("Invalid timezone %q: %w", value, err)
+		}
+
+		return nil
+	}),
+
 	// gendoc:generate(entity=instance, group=snapshots, key=snapshots.schedule.stopped)
 	//
 	// ---
@@ -295,6 +346,24 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Whether to automatically snapshot stopped instances
 	"snapshots.schedule.stopped": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=snapshots, key=snapshots.pre_hook)
+	// Specify a command, run inside the instance before taking a snapshot of it, to quiesce the application for an application-consistent snapshot.
+	// The snapshot is aborted if the command fails or doesn't complete within 5 minutes. It's only run when the instance is running.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  shortdesc: Command run in the instance before a snapshot
+	"snapshots.pre_hook": validate.Optional(isShellCommand),
+
+	// gendoc:generate(entity=instance, group=snapshots, key=snapshots.post_hook)
+	// Specify a command, run inside the instance after taking a snapshot of it, to resume the application quiesced by {config:option}`instance-snapshots:snapshots.pre_hook`.
+	// It's run even if the snapshot failed and is killed if it doesn't complete within 5 minutes. It's only run when the instance is running.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  shortdesc: Command run in the instance after a snapshot
+	"snapshots.post_hook": validate.Optional(isShellCommand),
+
 	// gendoc:generate(entity=instance, group=snapshots, key=snapshots.pattern)
 	// Specify a Pongo2 template string that represents the snapshot name.
 	// This template is used for scheduled snapshots and for unnamed snapshots.
@@ -1299,3 +1368,17 @@ func InstanceIncludeWhenCopying(configKey string, remoteCopy bool) bool {
 
 	return true // Keep all other keys.
 }
+
+// isShellCommand validates that the value can be split into a command and its arguments.
+func isShellCommand(value string) error {
+	fields, err := shellquote.Split(value)
+	if err != nil {
+		return fmt.Errorf("Invalid command: %w", err)
+	}
+
+	if len(fields) == 0 {
+		return fmt.Errorf("Empty command")
+	}
+
+	return nil
+}
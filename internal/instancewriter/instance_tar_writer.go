@@ -4,6 +4,8 @@ package instancewriter
 
 import (
 	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -21,6 +23,7 @@ type InstanceTarWriter struct {
 	tarWriter *tar.Writer
 	idmapSet  *idmap.Set
 	linkMap   map[uint64]string
+	checksums map[string]string
 }
 
 // NewInstanceTarWriter returns a ContainerTarWriter for the provided target Writer and id map.
@@ -38,6 +41,36 @@ func (ctw *InstanceTarWriter) ResetHardLinkMap() {
 	ctw.linkMap = map[uint64]string{}
 }
 
+// EnableChecksums makes the writer record the SHA256 checksum of the regular files added to the tarball.
+func (ctw *InstanceTarWriter) EnableChecksums() {
+	ctw.checksums = map[string]string{}
+}
+
+// Checksums returns the SHA256 checksums of the regular files added to the tarball, indexed by their name.
+// It's nil unless EnableChecksums was called.
+func (ctw *InstanceTarWriter) Checksums() map[string]string {
+	return ctw.checksums
+}
+
+// copy writes the file content to the tarball, recording its checksum if enabled.
+func (ctw *InstanceTarWriter) copy(name string, src io.Reader) error {
+	if ctw.checksums == nil {
+		_, err := io.Copy(ctw.tarWriter, src)
+		return err
+	}
+
+	hash := sha256.New()
+
+	_, err := io.Copy(io.MultiWriter(ctw.tarWriter, hash), src)
+	if err != nil {
+		return err
+	}
+
+	ctw.checksums[name] = hex.EncodeToString(hash.Sum(nil))
+
+	return nil
+}
+
 // WriteFile adds a file to the tarball with the specified name using the srcPath file as the contents of the file.
 // The ignoreGrowth argument indicates whether to error if the srcPath file increases in size beyond the size in fi
 // during the write. If false the write will return an error. If true, no error is returned, instead only the size
@@ -172,7 +205,7 @@ func (ctw *InstanceTarWriter) WriteFile(name string, srcPath string, fi os.FileI
 			r = io.LimitReader(r, fi.Size())
 		}
 
-		_, err = io.Copy(ctw.tarWriter, r)
+		err = ctw.copy(hdr.Name, r)
 		if err != nil {
 			return fmt.Errorf("Failed to copy file content %q: %w", srcPath, err)
 		}
@@ -199,8 +232,12 @@ func (ctw *InstanceTarWriter) WriteFileFromReader(src io.Reader, fi os.FileInfo)
 		return fmt.Errorf("Failed to write tar header: %w", err)
 	}
 
-	_, err = io.Copy(ctw.tarWriter, src)
-	return err
+	if hdr.Typeflag != tar.TypeReg {
+		_, err = io.Copy(ctw.tarWriter, src)
+		return err
+	}
+
+	return ctw.copy(hdr.Name, src)
 }
 
 // Close finishes writing the tarball.
@@ -0,0 +1,59 @@
+package io
+
+import (
+	"io"
+	"time"
+)
+
+// RateLimitWriter limits the rate at which data gets written to the wrapped writer.
+type RateLimitWriter struct {
+	writer io.Writer
+	limit  int64
+	start  time.Time
+	n      int64
+}
+
+// NewRateLimitWriter returns a new RateLimitWriter wrapping the given writer.
+//
+// The limit is expressed in bytes per second. If it's not positive, then no limit is applied.
+func NewRateLimitWriter(writer io.Writer, limit int64) *RateLimitWriter {
+	return &RateLimitWriter{
+		writer: writer,
+		limit:  limit,
+	}
+}
+
+// Write implements the Writer interface.
+func (w *RateLimitWriter) Write(p []byte) (int, error) {
+	if w.limit <= 0 {
+		return w.writer.Write(p)
+	}
+
+	if w.start.IsZero() {
+		w.start = time.Now()
+	}
+
+	// Write in chunks of at most a tenth of a second worth of data to keep the rate smooth.
+	chunkSize := max(w.limit/10, 1)
+
+	written := 0
+	for written < len(p) {
+		end := min(written+int(chunkSize), len(p))
+
+		n, err := w.writer.Write(p[written:end])
+		written += n
+		w.n += int64(n)
+		if err != nil {
+			return written, err
+		}
+
+		// Wait until the average rate is back under the limit.
+		expected := time.Duration(float64(w.n) / float64(w.limit) * float64(time.Second))
+		elapsed := time.Since(w.start)
+		if expected > elapsed {
+			time.Sleep(expected - elapsed)
+		}
+	}
+
+	return written, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"os/exec"
+	"syscall"
 
 	"golang.org/x/sys/unix"
 )
@@ -42,7 +43,7 @@ func ExitStatus(err error) (int, error) {
 	// Detect and extract ExitError to check the embedded exit status.
 	if errors.As(err, &exitErr) {
 		// If the process was signaled, extract the signal.
-		status, isWaitStatus := exitErr.Sys().(unix.WaitStatus)
+		status, isWaitStatus := exitErr.Sys().(syscall.WaitStatus)
 		if isWaitStatus && status.Signaled() {
 			return 128 + int(status.Signal()), nil // 128 + n == Fatal error signal "n"
 		}
@@ -53,3 +54,18 @@ func ExitStatus(err error) (int, error) {
 
 	return -1, err // Not able to extract an exit status.
 }
+
+// ExitSignal extracts the signal which terminated the command from the error returned by exec.Cmd.
+// If the command wasn't terminated by a signal then 0 is returned.
+func ExitSignal(err error) unix.Signal {
+	var exitErr *exec.ExitError
+
+	if errors.As(err, &exitErr) {
+		status, isWaitStatus := exitErr.Sys().(syscall.WaitStatus)
+		if isWaitStatus && status.Signaled() {
+			return status.Signal()
+		}
+	}
+
+	return 0
+}
@@ -2,9 +2,11 @@ package apparmor
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -33,7 +35,9 @@ profile "{{ .name }}" flags=(attach_disconnected,mediate_deleted) {
   capability fowner,
   capability fsetid,
   capability kill,
+{{- if .privilegedPort }}
   capability net_bind_service,
+{{- end }}
   capability setgid,
   capability setuid,
   capability sys_admin,
@@ -55,6 +59,12 @@ profile "{{ .name }}" flags=(attach_disconnected,mediate_deleted) {
   ptrace (trace),
 
   /etc/machine-id r,
+
+  # Name resolution for TCP and UDP addresses
+  /etc/gai.conf r,
+  /etc/host.conf r,
+  /etc/hosts r,
+  /etc/nsswitch.conf r,
   /run/systemd/resolve/stub-resolv.conf r,
   /run/{resolvconf,NetworkManager,systemd/resolve,connman,netconfig}/resolv.conf r,
   /usr/lib/systemd/resolv.conf r,
@@ -84,19 +94,16 @@ profile "{{ .name }}" flags=(attach_disconnected,mediate_deleted) {
 }
 `))
 
-// forkproxyProfile generates the AppArmor profile template from the given network.
-func forkproxyProfile(sysOS *sys.OS, inst instance, dev device) (string, error) {
+// ForkproxyProfile renders the AppArmor profile for the given proxy device without writing or loading it.
+func ForkproxyProfile(sysOS *sys.OS, inst instance, dev device) (string, error) {
 	// Add any socket used by forkproxy.
 	sockets := []string{}
 
-	fields := strings.SplitN(dev.Config()["listen"], ":", 2)
-	if fields[0] == "unix" && !strings.HasPrefix(fields[1], "@") {
-		sockets = append(sockets, fields[1])
-	}
-
-	fields = strings.SplitN(dev.Config()["connect"], ":", 2)
-	if fields[0] == "unix" && !strings.HasPrefix(fields[1], "@") {
-		sockets = append(sockets, fields[1])
+	for _, key := range []string{"listen", "connect"} {
+		protocol, address, _ := strings.Cut(dev.Config()[key], ":")
+		if protocol == "unix" && !strings.HasPrefix(address, "@") {
+			sockets = append(sockets, address)
+		}
 	}
 
 	// AppArmor requires deref of all paths.
@@ -125,12 +132,13 @@ func forkproxyProfile(sysOS *sys.OS, inst instance, dev device) (string, error)
 	// Render the profile.
 	var sb *strings.Builder = &strings.Builder{}
 	err = forkproxyProfileTpl.Execute(sb, map[string]any{
-		"name":        ForkproxyProfileName(inst, dev),
-		"varPath":     internalUtil.VarPath(""),
-		"exePath":     execPath,
-		"logPath":     inst.LogPath(),
-		"libraryPath": strings.Split(os.Getenv("LD_LIBRARY_PATH"), ":"),
-		"sockets":     sockets,
+		"name":           ForkproxyProfileName(inst, dev),
+		"varPath":        internalUtil.VarPath(""),
+		"exePath":        execPath,
+		"logPath":        inst.LogPath(),
+		"libraryPath":    strings.Split(os.Getenv("LD_LIBRARY_PATH"), ":"),
+		"sockets":        sockets,
+		"privilegedPort": forkproxyListensPrivilegedPort(dev.Config()["listen"]),
 	})
 	if err != nil {
 		return "", err
@@ -139,6 +147,32 @@ func forkproxyProfile(sysOS *sys.OS, inst instance, dev device) (string, error)
 	return sb.String(), nil
 }
 
+// forkproxyListensPrivilegedPort returns whether the listen address includes a privileged port.
+// If the address can't be parsed, it's assumed to include one.
+func forkproxyListensPrivilegedPort(listen string) bool {
+	protocol, address, _ := strings.Cut(listen, ":")
+	if protocol == "unix" {
+		return false
+	}
+
+	_, ports, err := net.SplitHostPort(address)
+	if err != nil {
+		return true
+	}
+
+	for _, port := range strings.Split(ports, ",") {
+		// For port ranges, the first port is the lowest one.
+		start, _, _ := strings.Cut(strings.TrimSpace(port), "-")
+
+		portNum, err := strconv.ParseUint(start, 10, 16)
+		if err != nil || portNum < 1024 {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ForkproxyProfileName returns the AppArmor profile name.
 func ForkproxyProfileName(inst instance, dev device) string {
 	path := internalUtil.VarPath("")
@@ -171,7 +205,7 @@ func ForkproxyLoad(sysOS *sys.OS, inst instance, dev device) error {
 		return err
 	}
 
-	updated, err := forkproxyProfile(sysOS, inst, dev)
+	updated, err := ForkproxyProfile(sysOS, inst, dev)
 	if err != nil {
 		return err
 	}
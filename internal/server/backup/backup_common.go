@@ -25,6 +25,11 @@ func (b *CommonBackup) Name() string {
 	return b.name
 }
 
+// CreationDate returns when the backup was created.
+func (b *CommonBackup) CreationDate() time.Time {
+	return b.creationDate
+}
+
 // CompressionAlgorithm returns the compression used for the tarball.
 func (b *CommonBackup) CompressionAlgorithm() string {
 	return b.compressionAlgorithm
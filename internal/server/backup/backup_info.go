@@ -54,6 +54,13 @@ type Info struct {
 	OptimizedHeader  *bool          `json:"optimized_header,omitempty" yaml:"optimized_header,omitempty"` // Optional field to handle older optimized backups that don't have this field.
 	Type             Type           `json:"type,omitempty" yaml:"type,omitempty"`                         // Type of backup.
 	Config           *config.Config `json:"config,omitempty" yaml:"config,omitempty"`                     // Equivalent of backup.yaml but embedded in index for quick retrieval.
+	Placement        *Placement     `json:"placement,omitempty" yaml:"placement,omitempty"`               // Location of the instance in the cluster, only set for clustered servers.
+}
+
+// Placement represents the location of an instance in the cluster at the time of the backup.
+type Placement struct {
+	Member        string `json:"member" yaml:"member"`
+	FailureDomain string `json:"failure_domain,omitempty" yaml:"failure_domain,omitempty"`
 }
 
 // GetInfo extracts backup information from a given ReadSeeker.
@@ -0,0 +1,198 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/archive"
+)
+
+// ManifestPath is the path of the manifest in backup tarballs.
+const ManifestPath = "backup/manifest.yaml"
+
+// Manifest represents the list of the files in a backup tarball along with their checksums.
+type Manifest struct {
+	Files map[string]string `yaml:"files"` // SHA256 checksum of each regular file, indexed by its path.
+}
+
+// Verify reads the whole backup tarball and checks its files against the manifest stored in it.
+// Problems reading the backup are reported in the result, the returned error is only set when the
+// backup can't be read at all.
+func Verify(r io.ReadSeeker, outputPath string) (*api.InstanceBackupVerification, error) {
+	_, err := r.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, unpacker, err := archive.DetectCompressionFile(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if unpacker == nil {
+		return nil, fmt.Errorf("Unsupported backup compression")
+	}
+
+	_, err = r.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest *Manifest
+	checksums := map[string]string{}
+
+	readErr := verifyRead(r, unpacker, outputPath, func(hdr *tar.Header, tr *tar.Reader) error {
+		if hdr.Name == ManifestPath {
+			manifest = &Manifest{}
+			return yaml.NewDecoder(tr).Decode(manifest)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			return nil
+		}
+
+		hash := sha256.New()
+		_, err := io.Copy(hash, tr)
+		if err != nil {
+			return err
+		}
+
+		checksums[hdr.Name] = hex.EncodeToString(hash.Sum(nil))
+
+		return nil
+	})
+
+	result := api.InstanceBackupVerification{
+		Files: []api.InstanceBackupVerificationFile{},
+	}
+
+	if readErr != nil {
+		result.Error = fmt.Sprintf("Failed reading backup: %v", readErr)
+	} else if manifest == nil {
+		result.Error = "Backup has no manifest"
+	}
+
+	// Without a manifest, there's nothing to check the files against.
+	if manifest == nil {
+		return &result, nil
+	}
+
+	paths := make([]string, 0, len(manifest.Files))
+	for path := range manifest.Files {
+		paths = append(paths, path)
+	}
+
+	for path := range checksums {
+		_, found := manifest.Files[path]
+		if !found {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	result.Valid = readErr == nil
+	for _, path := range paths {
+		file := api.InstanceBackupVerificationFile{
+			Path:     path,
+			Expected: manifest.Files[path],
+			Actual:   checksums[path],
+		}
+
+		switch {
+		case file.Expected == "":
+			file.Status = "unexpected"
+		case file.Actual == "":
+			file.Status = "missing"
+		case file.Expected != file.Actual:
+			file.Status = "mismatch"
+		default:
+			file.Status = "ok"
+		}
+
+		if file.Status != "ok" {
+			result.Valid = false
+		}
+
+		result.Files = append(result.Files, file)
+	}
+
+	return &result, nil
+}
+
+// verifyRead decompresses the backup tarball and calls entryFunc on each of its entries.
+// Unlike TarReader, it reports errors from the decompression tool so that corrupted data is detected.
+func verifyRead(r io.Reader, unpacker []string, outputPath string, entryFunc func(hdr *tar.Header, tr *tar.Reader) error) error {
+	if len(unpacker) == 0 {
+		return verifyReadTar(r, entryFunc)
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(unpacker[0], unpacker[1:]...)
+	cmd.Stdin = r
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	// Call the wrapper if defined.
+	if archive.RunWrapper != nil {
+		cleanup, err := archive.RunWrapper(cmd, outputPath, []string{unpacker[0]})
+		if err != nil {
+			return err
+		}
+
+		defer cleanup()
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	err = verifyReadTar(stdout, entryFunc)
+
+	// Consume any trailing data so that the decompression tool can complete.
+	_, _ = io.Copy(io.Discard, stdout)
+
+	waitErr := cmd.Wait()
+	if waitErr != nil {
+		return fmt.Errorf("Failed decompressing backup: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return err
+}
+
+// verifyReadTar calls entryFunc on each entry of the tarball.
+func verifyReadTar(r io.Reader, entryFunc func(hdr *tar.Header, tr *tar.Reader) error) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = entryFunc(hdr, tr)
+		if err != nil {
+			return fmt.Errorf("Failed reading %q: %w", hdr.Name, err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+// testBackupTarball returns an uncompressed tarball with the given files and, unless nil, manifest.
+func testBackupTarball(t *testing.T, files map[string]string, manifest *Manifest) *bytes.Reader {
+	var buf bytes.Buffer
+
+	tw := tar.NewWriter(&buf)
+
+	writeFile := func(name string, content []byte) {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg, Format: tar.FormatUSTAR})
+		require.NoError(t, err)
+
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+	}
+
+	for name, content := range files {
+		writeFile(name, []byte(content))
+	}
+
+	if manifest != nil {
+		content, err := yaml.Marshal(manifest)
+		require.NoError(t, err)
+
+		writeFile(ManifestPath, content)
+	}
+
+	require.NoError(t, tw.Close())
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func testChecksum(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+func TestVerify(t *testing.T) {
+	files := map[string]string{
+		"backup/index.yaml":            "name: c1\n",
+		"backup/container/rootfs/file": "hello",
+	}
+
+	tests := []struct {
+		name     string
+		manifest *Manifest
+		valid    bool
+		error    bool
+		statuses map[string]string
+	}{
+		{
+			name: "Matching manifest",
+			manifest: &Manifest{Files: map[string]string{
+				"backup/index.yaml":            testChecksum("name: c1\n"),
+				"backup/container/rootfs/file": testChecksum("hello"),
+			}},
+			valid: true,
+			statuses: map[string]string{
+				"backup/index.yaml":            "ok",
+				"backup/container/rootfs/file": "ok",
+			},
+		},
+		{
+			name: "Mismatched hash",
+			manifest: &Manifest{Files: map[string]string{
+				"backup/index.yaml":            testChecksum("name: c1\n"),
+				"backup/container/rootfs/file": testChecksum("world"),
+			}},
+			valid: false,
+			statuses: map[string]string{
+				"backup/index.yaml":            "ok",
+				"backup/container/rootfs/file": "mismatch",
+			},
+		},
+		{
+			name: "Missing file",
+			manifest: &Manifest{Files: map[string]string{
+				"backup/index.yaml":            testChecksum("name: c1\n"),
+				"backup/container/rootfs/file": testChecksum("hello"),
+				"backup/container/rootfs/gone": testChecksum("gone"),
+			}},
+			valid: false,
+			statuses: map[string]string{
+				"backup/index.yaml":            "ok",
+				"backup/container/rootfs/file": "ok",
+				"backup/container/rootfs/gone": "missing",
+			},
+		},
+		{
+			name: "Unexpected file",
+			manifest: &Manifest{Files: map[string]string{
+				"backup/index.yaml": testChecksum("name: c1\n"),
+			}},
+			valid: false,
+			statuses: map[string]string{
+				"backup/index.yaml":            "ok",
+				"backup/container/rootfs/file": "unexpected",
+			},
+		},
+		{
+			name:     "No manifest",
+			valid:    false,
+			error:    true,
+			statuses: map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := Verify(testBackupTarball(t, files, test.manifest), t.TempDir())
+			require.NoError(t, err)
+
+			assert.Equal(t, test.valid, result.Valid)
+			assert.Equal(t, test.error, result.Error != "")
+
+			statuses := map[string]string{}
+			for _, file := range result.Files {
+				statuses[file.Path] = file.Status
+			}
+
+			assert.Equal(t, test.statuses, statuses)
+		})
+	}
+}
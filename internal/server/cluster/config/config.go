@@ -74,6 +74,21 @@ func (c *Config) HTTPSAllowedCredentials() bool {
 	return c.m.GetBool("core.https_allowed_credentials")
 }
 
+// ClusterSpreadFailureDomains returns whether instances of the same spread group must be placed in different failure domains.
+func (c *Config) ClusterSpreadFailureDomains() bool {
+	return c.m.GetBool("cluster.spread_failure_domains")
+}
+
+// ClusterFailback returns whether evacuated instances are automatically moved back to their original cluster member.
+func (c *Config) ClusterFailback() bool {
+	return c.m.GetBool("cluster.failback")
+}
+
+// ClusterEvacuateWorkers returns the maximum number of cluster members queried concurrently during evacuation planning.
+func (c *Config) ClusterEvacuateWorkers() int {
+	return int(c.m.GetInt64("cluster.evacuate_workers"))
+}
+
 // TrustCACertificates returns whether client certificates are checked
 // against a CA.
 func (c *Config) TrustCACertificates() bool {
@@ -177,11 +192,36 @@ func (c *Config) InstancesNICHostname() string {
 	return c.m.GetString("instances.nic.host_name")
 }
 
+// InstancesAutostartParallelism returns the number of instances started concurrently on daemon startup.
+func (c *Config) InstancesAutostartParallelism() int {
+	return int(c.m.GetInt64("instances.autostart.parallelism"))
+}
+
+// InstancesAutostartNetworkTimeout returns how long to wait for networks to be initialized before starting instances.
+func (c *Config) InstancesAutostartNetworkTimeout() time.Duration {
+	return time.Duration(c.m.GetInt64("instances.autostart.network_timeout")) * time.Second
+}
+
+// InstancesConsoleRecord returns whether instance console sessions must be recorded.
+func (c *Config) InstancesConsoleRecord() bool {
+	return c.m.GetBool("instances.console.record")
+}
+
+// InstancesConsoleRecordExpiryDays returns the number of days after which console recordings are removed.
+func (c *Config) InstancesConsoleRecordExpiryDays() int64 {
+	return c.m.GetInt64("instances.console.record_expiry")
+}
+
 // InstancesPlacementScriptlet returns the instances placement scriptlet source code.
 func (c *Config) InstancesPlacementScriptlet() string {
 	return c.m.GetString("instances.placement.scriptlet")
 }
 
+// LoggingCollector returns the URL and minimum log level of the external log collector.
+func (c *Config) LoggingCollector() (string, string) {
+	return c.m.GetString("logging.collector.url"), c.m.GetString("logging.collector.loglevel")
+}
+
 // LokiServer returns all the Loki settings needed to connect to a server.
 func (c *Config) LokiServer() (string, string, string, string, string, string, []string, []string) {
 	var types []string
@@ -356,6 +396,27 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Threshold when to evacuate an offline cluster member
 	"cluster.healing_threshold": {Type: config.Int64, Default: "0"},
 
+	// gendoc:generate(entity=server, group=cluster, key=cluster.failback)
+	// When enabled, instances which were evacuated from a cluster member are automatically migrated back
+	// to it once it has been restored and is online again.
+	// Members with {config:option}`cluster-cluster:scheduler.instance` set to `manual` are skipped.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to move evacuated instances back to their original member
+	"cluster.failback": {Type: config.Bool, Default: "false"},
+
+	// gendoc:generate(entity=server, group=cluster, key=cluster.spread_failure_domains)
+	// When enabled, creating an instance is refused if it would put all the instances sharing its
+	// {config:option}`instance-miscellaneous:cluster.spread_group` in the same failure domain.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to spread instances of the same group across failure domains
+	"cluster.spread_failure_domains": {Type: config.Bool, Default: "false"},
+
 	// gendoc:generate(entity=server, group=cluster, key=cluster.join_token_expiry)
 	//
 	// ---
@@ -385,6 +446,17 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Number of database stand-by members
 	"cluster.max_standby": {Type: config.Int64, Default: "2", Validator: maxStandByValidator},
 
+	// gendoc:generate(entity=server, group=cluster, key=cluster.evacuate_workers)
+	// When an instance placement scriptlet is set, the resources of the other online cluster members are
+	// gathered once at the start of an evacuation, with up to this many members queried at once.
+	// Set to `0` to have the scriptlet query the members one at a time instead.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `10`
+	//  shortdesc: Number of cluster members queried concurrently during evacuation
+	"cluster.evacuate_workers": {Type: config.Int64, Default: "10", Validator: validate.Optional(validate.IsInRange(0, 100))},
+
 	// gendoc:generate(entity=server, group=core, key=core.metrics_authentication)
 	//
 	// ---
@@ -540,6 +612,48 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: When an unused cached remote image is flushed
 	"images.remote_cache_expiry": {Type: config.Int64, Default: "10"},
 
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.autostart.parallelism)
+	// Instances with the same {config:option}`instance-boot:boot.autostart.priority` are started
+	// concurrently, up to this number at a time. Instances with a higher priority are always
+	// started before those with a lower one.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `1`
+	//  shortdesc: Number of instances started concurrently on daemon startup
+	"instances.autostart.parallelism": {Type: config.Int64, Default: "1", Validator: validate.Optional(validate.IsInRange(1, 1024))},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.autostart.network_timeout)
+	// When set, the daemon waits up to this number of seconds for all managed networks to be
+	// initialized before starting instances on startup.
+	// To start instances without waiting, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: How long to wait for networks before starting instances
+	"instances.autostart.network_timeout": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 3600))},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.console.record)
+	// When enabled, console sessions of all instances are recorded in the asciicast format and users are told about it when attaching.
+	// The VGA console can't be used as it can't be recorded.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to record instance console sessions
+	"instances.console.record": {Type: config.Bool, Default: "false"},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.console.record_expiry)
+	// Recordings which are older than this number of days are removed daily from the cluster member storing them.
+	// To keep the recordings forever, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: When console recordings expire
+	"instances.console.record_expiry": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 36500))},
+
 	// gendoc:generate(entity=server, group=miscellaneous, key=instances.nic.host_name)
 	// Possible values are `random` and `mac`.
 	//
@@ -561,6 +675,23 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Instance placement scriptlet for automatic instance placement
 	"instances.placement.scriptlet": {Validator: validate.Optional(scriptletLoad.InstancePlacementValidate)},
 
+	// gendoc:generate(entity=server, group=logging, key=logging.collector.url)
+	// Log records are sent in batches, as a JSON array in the body of a `POST` request.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: URL of the HTTP log collector
+	"logging.collector.url": {Validator: validate.Optional(validate.IsRequestURL)},
+
+	// gendoc:generate(entity=server, group=logging, key=logging.collector.loglevel)
+	//
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `info`
+	//  shortdesc: Minimum log level to send to the log collector
+	"logging.collector.loglevel": {Validator: logLevelValidator, Default: logrus.InfoLevel.String()},
+
 	// gendoc:generate(entity=server, group=loki, key=loki.auth.username)
 	//
 	// ---
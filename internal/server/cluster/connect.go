@@ -287,3 +287,22 @@ func HasConnectivity(networkCert *localtls.CertInfo, serverCert *localtls.CertIn
 
 	return false
 }
+
+// HasConnectivityWithRetry probes the member with the given address for connectivity, trying up to the
+// given number of times with an increasing delay between attempts so that transient failures are tolerated.
+func HasConnectivityWithRetry(networkCert *localtls.CertInfo, serverCert *localtls.CertInfo, address string, attempts int) bool {
+	delay := 250 * time.Millisecond
+
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		if HasConnectivity(networkCert, serverCert, address) {
+			return true
+		}
+	}
+
+	return false
+}
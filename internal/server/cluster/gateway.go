@@ -125,6 +125,9 @@ type Gateway struct {
 	heartbeatCancelLock       sync.Mutex
 	HeartbeatLock             sync.Mutex
 
+	// Online status of the members as of the previous heartbeat round, used to report changes.
+	heartbeatMembersOnline map[int64]bool
+
 	// NodeStore wrapper.
 	store *dqliteNodeStore
 
@@ -902,6 +905,13 @@ func (g *Gateway) isLeader() (bool, error) {
 // ErrNotLeader signals that a node not the leader.
 var ErrNotLeader = fmt.Errorf("Not leader")
 
+// RaftNodes returns information about the cluster members that are currently part
+// of the raft cluster, as configured in the raft log. It returns ErrNotLeader if
+// this node is not the leader.
+func (g *Gateway) RaftNodes() ([]db.RaftNode, error) {
+	return g.currentRaftNodes()
+}
+
 // Return information about the cluster members that a currently part of the raft
 // cluster, as configured in the raft log. It returns an error if this node is
 // not the leader.
@@ -1,7 +1,6 @@
 package cluster
 
 import (
-	"github.com/lxc/incus/v6/internal/server/db"
 	localtls "github.com/lxc/incus/v6/shared/tls"
 )
 
@@ -19,8 +18,3 @@ func (g *Gateway) ServerCert() *localtls.CertInfo {
 func (g *Gateway) NetworkCert() *localtls.CertInfo {
 	return g.networkCert
 }
-
-// RaftNodes returns the nodes currently part of the raft cluster.
-func (g *Gateway) RaftNodes() ([]db.RaftNode, error) {
-	return g.currentRaftNodes()
-}
@@ -15,7 +15,9 @@ import (
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/query"
 	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/internal/server/task"
 	"github.com/lxc/incus/v6/internal/server/warnings"
 	"github.com/lxc/incus/v6/shared/api"
@@ -313,6 +315,8 @@ func (g *Gateway) heartbeat(ctx context.Context, mode heartbeatMode) {
 	raftNodes, err := g.currentRaftNodes()
 	if err != nil {
 		if errors.Is(err, ErrNotLeader) {
+			// Forget about the members status so that stale changes aren't reported if we become leader again.
+			g.heartbeatMembersOnline = nil
 			return
 		}
 
@@ -491,6 +495,9 @@ func (g *Gateway) heartbeat(ctx context.Context, mode heartbeatMode) {
 		return
 	}
 
+	// Report the members going offline or coming back online.
+	g.heartbeatMembersStatus(s, hbState)
+
 	// If full node state was sent and node refresh task is specified.
 	if g.HeartbeatNodeHook != nil {
 		g.HeartbeatNodeHook(hbState, true, unavailableMembers)
@@ -510,6 +517,45 @@ func (g *Gateway) heartbeat(ctx context.Context, mode heartbeatMode) {
 	}
 }
 
+// heartbeatMembersStatus emits a lifecycle event for each member whose online status changed since the
+// previous heartbeat round. The first round after becoming leader only records the current status.
+func (g *Gateway) heartbeatMembersStatus(s *state.State, hbState *APIHeartbeat) {
+	threshold := g.HeartbeatOfflineThreshold
+	if threshold <= 0 {
+		threshold = time.Duration(db.DefaultOfflineThreshold) * time.Second
+	}
+
+	offlineTime := time.Now().UTC().Add(-threshold)
+
+	membersOnline := make(map[int64]bool, len(hbState.Members))
+	for _, member := range hbState.Members {
+		online := member.LastHeartbeat.After(offlineTime)
+		membersOnline[member.ID] = online
+
+		wasOnline, found := g.heartbeatMembersOnline[member.ID]
+		if !found || wasOnline == online {
+			continue
+		}
+
+		action := lifecycle.ClusterMemberOnline
+		if online {
+			logger.Info("Cluster member is back online", logger.Ctx{"member": member.Name, "address": member.Address})
+		} else {
+			action = lifecycle.ClusterMemberOffline
+			logger.Warn("Cluster member went offline", logger.Ctx{"member": member.Name, "address": member.Address, "lastHeartbeat": member.LastHeartbeat})
+		}
+
+		if s != nil && s.Events != nil {
+			s.Events.SendLifecycle("", action.Event(member.Name, nil, map[string]any{
+				"last_heartbeat":    member.LastHeartbeat,
+				"offline_threshold": threshold.String(),
+			}))
+		}
+	}
+
+	g.heartbeatMembersOnline = membersOnline
+}
+
 // HeartbeatNode performs a single heartbeat request against the node with the given address.
 func HeartbeatNode(taskCtx context.Context, address string, networkCert *localtls.CertInfo, serverCert *localtls.CertInfo, heartbeatData *APIHeartbeat) error {
 	logger.Debug("Sending heartbeat request", logger.Ctx{"address": address})
@@ -0,0 +1,57 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/events"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// Lifecycle events are only emitted for the members whose online status changed since the previous round.
+func TestHeartbeatMembersStatus(t *testing.T) {
+	emitted := []string{}
+
+	s := &state.State{
+		Events: events.NewServer(false, false, func(event api.Event) {
+			lifecycleEvent := api.EventLifecycle{}
+			err := json.Unmarshal(event.Metadata, &lifecycleEvent)
+			require.NoError(t, err)
+
+			emitted = append(emitted, lifecycleEvent.Action+" "+lifecycleEvent.Source)
+		}),
+	}
+
+	g := &Gateway{HeartbeatOfflineThreshold: 20 * time.Second}
+
+	online := time.Now().UTC()
+	offline := online.Add(-time.Minute)
+
+	hbState := func(heartbeats map[int64]time.Time) *APIHeartbeat {
+		members := map[int64]APIHeartbeatMember{}
+		for id, lastHeartbeat := range heartbeats {
+			members[id] = APIHeartbeatMember{ID: id, Name: fmt.Sprintf("server%02d", id), LastHeartbeat: lastHeartbeat}
+		}
+
+		return &APIHeartbeat{Members: members}
+	}
+
+	// The first round only records the status of the members.
+	g.heartbeatMembersStatus(s, hbState(map[int64]time.Time{1: online, 2: offline}))
+	assert.Empty(t, emitted)
+
+	// A member going offline and another one coming back online.
+	g.heartbeatMembersStatus(s, hbState(map[int64]time.Time{1: offline, 2: online}))
+	assert.ElementsMatch(t, []string{"cluster-member-offline /1.0/cluster/members/server01", "cluster-member-online /1.0/cluster/members/server02"}, emitted)
+
+	// Unchanged members and new members aren't reported.
+	emitted = []string{}
+	g.heartbeatMembersStatus(s, hbState(map[int64]time.Time{1: offline, 2: online, 3: offline}))
+	assert.Empty(t, emitted)
+}
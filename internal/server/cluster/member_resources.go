@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/resources"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// memberResourcesTimeout is how long to wait for each cluster member's resources.
+const memberResourcesTimeout = 5 * time.Second
+
+// MembersResources gathers a summary of the resources of the given cluster members, indexed by member name.
+// All members are queried at the same time and those which can't be reached in time are left out.
+func MembersResources(ctx context.Context, s *state.State, members []db.NodeInfo) map[string]*api.ClusterMemberResources {
+	result := make(map[string]*api.ClusterMemberResources, len(members))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, member := range members {
+		wg.Add(1)
+		go func(member db.NodeInfo) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, memberResourcesTimeout)
+			defer cancel()
+
+			// Run the query separately so that an unresponsive member doesn't hold the others.
+			type reply struct {
+				resources *api.ClusterMemberResources
+				err       error
+			}
+
+			replies := make(chan reply, 1)
+			go func() {
+				memberResources, err := memberResources(s, member)
+				replies <- reply{resources: memberResources, err: err}
+			}()
+
+			var r reply
+			select {
+			case r = <-replies:
+			case <-ctx.Done():
+				r.err = ctx.Err()
+			}
+
+			if r.err != nil {
+				logger.Warn("Failed getting cluster member resources", logger.Ctx{"member": member.Name, "err": r.err})
+				return
+			}
+
+			mu.Lock()
+			result[member.Name] = r.resources
+			mu.Unlock()
+		}(member)
+	}
+
+	wg.Wait()
+
+	return result
+}
+
+// memberResources returns the resources summary of a single cluster member.
+func memberResources(s *state.State, member db.NodeInfo) (*api.ClusterMemberResources, error) {
+	var res *api.Resources
+	var loadAvgs []float64
+	var err error
+
+	if member.Name == s.ServerName {
+		res, err = resources.GetResources()
+		if err != nil {
+			return nil, err
+		}
+
+		loadAvgs, err = getLoadAvgs()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var client incus.InstanceServer
+
+		client, err = Connect(member.Address, s.Endpoints.NetworkCert(), s.ServerCert(), nil, true)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = client.GetServerResources()
+		if err != nil {
+			return nil, err
+		}
+
+		memberState, _, err := client.GetClusterMemberState(member.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		loadAvgs = memberState.SysInfo.LoadAverages
+	}
+
+	memberResources := &api.ClusterMemberResources{
+		CPUTotal:    res.CPU.Total,
+		MemoryTotal: res.Memory.Total,
+		MemoryUsed:  res.Memory.Used,
+	}
+
+	if len(loadAvgs) > 0 {
+		memberResources.CPUUsed = loadAvgs[0]
+	}
+
+	return memberResources, nil
+}
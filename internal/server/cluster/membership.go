@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -575,14 +577,19 @@ func Join(state *state.State, gateway *Gateway, networkCert *localtls.CertInfo,
 
 	// Generate partial heartbeat request containing just a raft node list.
 	if state.Endpoints != nil {
-		NotifyHeartbeat(state, gateway)
+		err = NotifyHeartbeat(state, gateway)
+		if err != nil {
+			logger.Warn("Failed to notify all members of the change", logger.Ctx{"err": err})
+		}
 	}
 
 	return nil
 }
 
 // NotifyHeartbeat attempts to send a heartbeat to all other members to notify them of a new or changed member.
-func NotifyHeartbeat(state *state.State, gateway *Gateway) {
+// Offline members are skipped. It returns an error if the notification couldn't be sent to some of the online
+// members, those will only pick up the change with the next regular heartbeat.
+func NotifyHeartbeat(state *state.State, gateway *Gateway) error {
 	// If a heartbeat round is already running (and implicitly this means we are the leader), then cancel it
 	// so we can distribute the fresh member state info.
 	heartbeatCancel := gateway.HearbeatCancelFunc()
@@ -618,7 +625,7 @@ func NotifyHeartbeat(state *state.State, gateway *Gateway) {
 	})
 	if err != nil {
 		logger.Warn("Failed to get current raft members", logger.Ctx{"err": err, "local": localClusterAddress})
-		return
+		return fmt.Errorf("Failed to get current raft members: %w", err)
 	}
 
 	var members []db.NodeInfo
@@ -632,13 +639,15 @@ func NotifyHeartbeat(state *state.State, gateway *Gateway) {
 	})
 	if err != nil {
 		logger.Warn("Failed to get current cluster members", logger.Ctx{"err": err, "local": localClusterAddress})
-		return
+		return fmt.Errorf("Failed to get current cluster members: %w", err)
 	}
 
 	// Setup a full-state notification heartbeat.
 	hbState.Update(true, raftNodes, members, gateway.HeartbeatOfflineThreshold)
 
 	var wg sync.WaitGroup
+	var failedMu sync.Mutex
+	failed := []string{}
 
 	// Refresh local event listeners.
 	wg.Add(1)
@@ -654,15 +663,33 @@ func NotifyHeartbeat(state *state.State, gateway *Gateway) {
 			continue
 		}
 
+		// Offline members will get the change with the first heartbeat they receive once back.
+		if member.IsOffline(gateway.HeartbeatOfflineThreshold) {
+			continue
+		}
+
 		wg.Add(1)
 		go func(address string) {
-			_ = HeartbeatNode(context.Background(), address, state.Endpoints.NetworkCert(), state.ServerCert(), hbState)
+			err := HeartbeatNode(context.Background(), address, state.Endpoints.NetworkCert(), state.ServerCert(), hbState)
+			if err != nil {
+				failedMu.Lock()
+				failed = append(failed, address)
+				failedMu.Unlock()
+			}
+
 			wg.Done()
 		}(member.Address)
 	}
 
 	// Wait until all members have been notified (or at least have had a change to be notified).
 	wg.Wait()
+
+	if len(failed) > 0 {
+		slices.Sort(failed)
+		return fmt.Errorf("Failed to notify cluster members %s", strings.Join(failed, ", "))
+	}
+
+	return nil
 }
 
 // Rebalance the raft cluster, trying to see if we have a spare online node
@@ -682,23 +709,104 @@ func Rebalance(state *state.State, gateway *Gateway, unavailableMembers []string
 		return "", nil, fmt.Errorf("Get current raft nodes: %w", err)
 	}
 
-	roles, err := newRolesChanges(state, gateway, nodes, unavailableMembers)
+	// Retry the connectivity probes so that a transient failure doesn't get a member demoted.
+	candidateAddress, role, err := adjustRole(state, gateway, nodes, unavailableMembers, RebalanceConnectivityAttempts)
 	if err != nil {
 		return "", nil, err
 	}
 
-	role, candidates := roles.Adjust(gateway.info.ID)
-
-	if role == -1 {
+	if candidateAddress == "" {
 		// No node to promote
 		return "", nodes, nil
 	}
 
 	localClusterAddress := state.LocalConfig.ClusterAddress()
+	logger.Info("Found cluster member whose role needs to be changed", logger.Ctx{"candidateAddress": candidateAddress, "newRole": role, "local": localClusterAddress})
+
+	return candidateAddress, nodes, nil
+}
+
+// RebalanceConnectivityAttempts is how many times a member is probed before being considered unreachable when rebalancing roles.
+const RebalanceConnectivityAttempts = 3
+
+// RebalanceChange represents a role change planned by a rebalance.
+type RebalanceChange struct {
+	Name    string
+	Address string
+	OldRole db.RaftRole
+	NewRole db.RaftRole
+}
+
+// RebalancePlan computes all the role changes a rebalance would currently make, without applying any of them.
+func RebalancePlan(state *state.State, gateway *Gateway, unavailableMembers []string) ([]RebalanceChange, error) {
+	changes := []RebalanceChange{}
+
+	// If we're a standalone node, do nothing.
+	if gateway.memoryDial != nil {
+		return changes, nil
+	}
+
+	nodes, err := gateway.currentRaftNodes()
+	if err != nil {
+		return nil, fmt.Errorf("Get current raft nodes: %w", err)
+	}
+
+	// Each round only changes the role of a single member, repeat until the roles are settled.
+	// Bound the number of rounds in case the adjustments never converge.
+	for i := 0; i < len(nodes)*2; i++ {
+		oldRoles := make(map[string]db.RaftRole, len(nodes))
+		for _, node := range nodes {
+			oldRoles[node.Address] = node.Role
+		}
+
+		candidateAddress, role, err := adjustRole(state, gateway, nodes, unavailableMembers, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if candidateAddress == "" {
+			break
+		}
+
+		for _, node := range nodes {
+			if node.Address == candidateAddress {
+				changes = append(changes, RebalanceChange{
+					Name:    node.Name,
+					Address: node.Address,
+					OldRole: oldRoles[node.Address],
+					NewRole: role,
+				})
+
+				break
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// adjustRole finds the member whose role should change next and updates it in the given list of nodes.
+// Each member is probed up to the given number of attempts. An empty address is returned if no change is needed.
+func adjustRole(state *state.State, gateway *Gateway, nodes []db.RaftNode, unavailableMembers []string, attempts int) (string, db.RaftRole, error) {
+	roles, err := newRolesChanges(state, gateway, nodes, unavailableMembers, attempts)
+	if err != nil {
+		return "", -1, err
+	}
+
+	role, candidates := roles.Adjust(gateway.info.ID)
+
+	if role == -1 {
+		// The roles are settled, check whether the voters could be spread across more failure domains.
+		role, candidates = spreadVoters(roles)
+		if role == -1 {
+			return "", -1, nil
+		}
+	} else if role == db.RaftSpare {
+		sortVotersDemotion(roles, candidates)
+	}
 
 	// Check if we have a spare node that we can promote to the missing role.
 	candidateAddress := candidates[0].Address
-	logger.Info("Found cluster member whose role needs to be changed", logger.Ctx{"candidateAddress": candidateAddress, "newRole": role, "local": localClusterAddress})
 
 	for i, node := range nodes {
 		if node.Address == candidateAddress {
@@ -707,7 +815,7 @@ func Rebalance(state *state.State, gateway *Gateway, unavailableMembers []string
 		}
 	}
 
-	return candidateAddress, nodes, nil
+	return candidateAddress, role, nil
 }
 
 // Assign a new role to the local dqlite node.
@@ -895,7 +1003,10 @@ assign:
 
 	// Generate partial heartbeat request containing just a raft node list.
 	if state.Endpoints != nil {
-		NotifyHeartbeat(state, gateway)
+		err = NotifyHeartbeat(state, gateway)
+		if err != nil {
+			logger.Warn("Failed to notify all members of the change", logger.Ctx{"err": err})
+		}
 	}
 
 	return nil
@@ -1001,7 +1112,7 @@ func Handover(state *state.State, gateway *Gateway, address string) (string, []d
 		return "", nil, fmt.Errorf("No dqlite node has address %s: %w", address, err)
 	}
 
-	roles, err := newRolesChanges(state, gateway, nodes, nil)
+	roles, err := newRolesChanges(state, gateway, nodes, nil, 1)
 	if err != nil {
 		return "", nil, err
 	}
@@ -1021,8 +1132,9 @@ func Handover(state *state.State, gateway *Gateway, address string) (string, []d
 	return "", nil, nil
 }
 
-// Build an app.RolesChanges object feeded with the current cluster state.
-func newRolesChanges(state *state.State, gateway *Gateway, nodes []db.RaftNode, unavailableMembers []string) (*app.RolesChanges, error) {
+// Build an app.RolesChanges object feeded with the current cluster state, probing each member's connectivity
+// up to the given number of attempts.
+func newRolesChanges(state *state.State, gateway *Gateway, nodes []db.RaftNode, unavailableMembers []string, attempts int) (*app.RolesChanges, error) {
 	var domains map[string]uint64
 	err := state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		var err error
@@ -1041,7 +1153,7 @@ func newRolesChanges(state *state.State, gateway *Gateway, nodes []db.RaftNode,
 	cluster := map[client.NodeInfo]*client.NodeMetadata{}
 
 	for _, node := range nodes {
-		if !slices.Contains(unavailableMembers, node.Address) && HasConnectivity(gateway.networkCert, gateway.state().ServerCert(), node.Address) {
+		if !slices.Contains(unavailableMembers, node.Address) && HasConnectivityWithRetry(gateway.networkCert, gateway.state().ServerCert(), node.Address, attempts) {
 			cluster[node.NodeInfo] = &client.NodeMetadata{
 				FailureDomain: domains[node.Address],
 			}
@@ -1061,6 +1173,82 @@ func newRolesChanges(state *state.State, gateway *Gateway, nodes []db.RaftNode,
 	return roles, nil
 }
 
+// spreadVoters looks for an online member which would bring a new failure domain to the voters when some of the
+// voters share the same failure domain. Promoting it leads to one voter too many, which then gets demoted from
+// one of the shared failure domains (see sortVotersDemotion).
+func spreadVoters(roles *app.RolesChanges) (db.RaftRole, []client.NodeInfo) {
+	voterDomains := map[uint64]int{}
+	voters := 0
+	for node, metadata := range roles.State {
+		if node.Role != db.RaftVoter || metadata == nil {
+			continue
+		}
+
+		voterDomains[metadata.FailureDomain]++
+		voters++
+	}
+
+	// Nothing to do if the voters are already in distinct failure domains.
+	if voters < 2 || len(voterDomains) == voters {
+		return -1, nil
+	}
+
+	candidates := []client.NodeInfo{}
+	for node, metadata := range roles.State {
+		if node.Role == db.RaftVoter || metadata == nil {
+			continue
+		}
+
+		_, found := voterDomains[metadata.FailureDomain]
+		if found {
+			continue
+		}
+
+		candidates = append(candidates, node)
+	}
+
+	if len(candidates) == 0 {
+		return -1, nil
+	}
+
+	// Prefer stand-by members as they already have a copy of the database.
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Role != candidates[j].Role {
+			return candidates[i].Role == db.RaftStandBy
+		}
+
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	return db.RaftVoter, candidates
+}
+
+// sortVotersDemotion orders the voters about to be demoted so that those sharing their failure domain with
+// another voter come first, keeping the remaining voters spread across failure domains.
+func sortVotersDemotion(roles *app.RolesChanges, candidates []client.NodeInfo) {
+	voterDomains := map[uint64]int{}
+	for node, metadata := range roles.State {
+		if node.Role != db.RaftVoter || metadata == nil {
+			continue
+		}
+
+		voterDomains[metadata.FailureDomain]++
+	}
+
+	shared := func(node client.NodeInfo) bool {
+		metadata := roles.State[node]
+		if node.Role != db.RaftVoter || metadata == nil {
+			return false
+		}
+
+		return voterDomains[metadata.FailureDomain] > 1
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return shared(candidates[i]) && !shared(candidates[j])
+	})
+}
+
 // Purge removes a node entirely from the cluster database.
 func Purge(c *db.Cluster, name string) error {
 	logger.Debugf("Remove node %s from the database", name)
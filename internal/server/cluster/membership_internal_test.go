@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/cowsql/go-cowsql/app"
+	"github.com/cowsql/go-cowsql/client"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+)
+
+// testRolesChanges returns the roles of the given members, indexed by their ID along with their failure domain.
+// Members in the failure domain -1 are offline.
+func testRolesChanges(members map[uint64]db.RaftRole, domains map[uint64]int) (*app.RolesChanges, map[uint64]client.NodeInfo) {
+	roles := &app.RolesChanges{State: map[client.NodeInfo]*client.NodeMetadata{}}
+	nodes := map[uint64]client.NodeInfo{}
+
+	for id, role := range members {
+		node := client.NodeInfo{ID: id, Role: role}
+		nodes[id] = node
+
+		if domains[id] < 0 {
+			roles.State[node] = nil
+			continue
+		}
+
+		roles.State[node] = &client.NodeMetadata{FailureDomain: uint64(domains[id])}
+	}
+
+	return roles, nodes
+}
+
+func TestSpreadVoters(t *testing.T) {
+	tests := []struct {
+		name       string
+		members    map[uint64]db.RaftRole
+		domains    map[uint64]int
+		role       db.RaftRole
+		candidates []uint64
+	}{
+		{
+			name:    "Voters already spread",
+			members: map[uint64]db.RaftRole{1: db.RaftVoter, 2: db.RaftVoter, 3: db.RaftVoter, 4: db.RaftStandBy},
+			domains: map[uint64]int{1: 1, 2: 2, 3: 3, 4: 4},
+			role:    -1,
+		},
+		{
+			name:    "No member in another failure domain",
+			members: map[uint64]db.RaftRole{1: db.RaftVoter, 2: db.RaftVoter, 3: db.RaftVoter, 4: db.RaftStandBy},
+			domains: map[uint64]int{1: 1, 2: 1, 3: 2, 4: 2},
+			role:    -1,
+		},
+		{
+			name:       "Stand-by members preferred",
+			members:    map[uint64]db.RaftRole{1: db.RaftVoter, 2: db.RaftVoter, 3: db.RaftVoter, 4: db.RaftSpare, 5: db.RaftStandBy},
+			domains:    map[uint64]int{1: 1, 2: 1, 3: 2, 4: 3, 5: 3},
+			role:       db.RaftVoter,
+			candidates: []uint64{5, 4},
+		},
+		{
+			name:    "Offline members ignored",
+			members: map[uint64]db.RaftRole{1: db.RaftVoter, 2: db.RaftVoter, 3: db.RaftVoter, 4: db.RaftStandBy},
+			domains: map[uint64]int{1: 1, 2: 1, 3: 2, 4: -1},
+			role:    -1,
+		},
+		{
+			name:    "Single voter",
+			members: map[uint64]db.RaftRole{1: db.RaftVoter, 2: db.RaftSpare},
+			domains: map[uint64]int{1: 1, 2: 2},
+			role:    -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			roles, _ := testRolesChanges(test.members, test.domains)
+
+			role, candidates := spreadVoters(roles)
+			assert.Equal(t, test.role, role)
+
+			ids := []uint64{}
+			for _, candidate := range candidates {
+				ids = append(ids, candidate.ID)
+			}
+
+			if test.candidates == nil {
+				test.candidates = []uint64{}
+			}
+
+			assert.Equal(t, test.candidates, ids)
+		})
+	}
+}
+
+func TestSortVotersDemotion(t *testing.T) {
+	members := map[uint64]db.RaftRole{1: db.RaftVoter, 2: db.RaftVoter, 3: db.RaftVoter, 4: db.RaftVoter}
+	domains := map[uint64]int{1: 1, 2: 2, 3: 3, 4: 3}
+
+	roles, nodes := testRolesChanges(members, domains)
+
+	candidates := []client.NodeInfo{nodes[1], nodes[3], nodes[2], nodes[4]}
+	sortVotersDemotion(roles, candidates)
+
+	// The voters sharing their failure domain come first, the others keep their order.
+	ids := []uint64{}
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.ID)
+	}
+
+	assert.Equal(t, []uint64{3, 4, 1, 2}, ids)
+}
@@ -157,6 +157,52 @@ func TestBootstrap(t *testing.T) {
 	assert.True(t, enabled)
 }
 
+// Planning a rebalance doesn't change any role, and there's nothing to change on a single member cluster.
+func TestRebalancePlan(t *testing.T) {
+	state, cleanup := state.NewTestState(t)
+	defer cleanup()
+
+	serverCert := localtls.TestingKeyPair()
+	state.ServerCert = func() *localtls.CertInfo { return serverCert }
+
+	gateway := newGateway(t, state.DB.Node, serverCert, state)
+	defer func() { _ = gateway.Shutdown() }()
+
+	// A standalone server has nothing to rebalance.
+	changes, err := cluster.RebalancePlan(state, gateway, nil)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	mux := http.NewServeMux()
+	server := newServer(serverCert, mux)
+	defer server.Close()
+
+	address := server.Listener.Addr().String()
+	f := &membershipFixtures{t: t, state: state}
+	f.ClusterAddress(address)
+
+	err = cluster.Bootstrap(state, gateway, "buzz")
+	require.NoError(t, err)
+
+	trustedCerts := func() map[certificate.Type]map[string]x509.Certificate {
+		return nil
+	}
+
+	for path, handler := range gateway.HandlerFuncs(nil, trustedCerts) {
+		mux.HandleFunc(path, handler)
+	}
+
+	changes, err = cluster.RebalancePlan(state, gateway, nil)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// The member is still the only voter.
+	nodes, err := gateway.RaftNodes()
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	assert.Equal(t, db.RaftVoter, nodes[0].Role)
+}
+
 // If pre-conditions are not met, a descriptive error is returned.
 func TestAccept_UnmetPreconditions(t *testing.T) {
 	cases := []struct {
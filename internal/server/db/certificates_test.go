@@ -4,13 +4,16 @@ package db_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lxc/incus/v6/internal/server/certificate"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/shared/api"
 )
 
 func TestGetCertificate(t *testing.T) {
@@ -25,3 +28,41 @@ func TestGetCertificate(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, cert.Fingerprint, "foobar")
 }
+
+func TestSwapCertificate(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	oldCert := cluster.Certificate{
+		Fingerprint: "old",
+		Type:        certificate.TypeClient,
+		Name:        "foo",
+		Certificate: "old-pem",
+		Restricted:  true,
+		Description: "bar",
+	}
+
+	_, err := cluster.CreateCertificateWithProjects(ctx, tx.Tx(), oldCert, []string{"default"})
+	require.NoError(t, err)
+
+	id, err := cluster.SwapCertificate(ctx, tx.Tx(), "old", cluster.Certificate{Fingerprint: "new", Certificate: "new-pem"})
+	require.NoError(t, err)
+
+	_, err = cluster.GetCertificate(ctx, tx.Tx(), "old")
+	assert.True(t, api.StatusErrorCheck(err, http.StatusNotFound))
+
+	cert, err := cluster.GetCertificate(ctx, tx.Tx(), "new")
+	require.NoError(t, err)
+	assert.Equal(t, int(id), cert.ID)
+	assert.Equal(t, "new-pem", cert.Certificate)
+	assert.Equal(t, "foo", cert.Name)
+	assert.Equal(t, "bar", cert.Description)
+	assert.Equal(t, certificate.TypeClient, cert.Type)
+	assert.True(t, cert.Restricted)
+
+	projects, err := cluster.GetCertificateProjects(ctx, tx.Tx(), cert.ID)
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	assert.Equal(t, "default", projects[0].Name)
+}
@@ -19,6 +19,7 @@ func ClusterGroupToAPI(clusterGroup *cluster.ClusterGroup, nodes []string) *api.
 		ClusterGroupPut: api.ClusterGroupPut{
 			Description: clusterGroup.Description,
 			Members:     nodes,
+			Config:      clusterGroup.Config,
 		},
 		ClusterGroupPost: api.ClusterGroupPost{
 			Name: clusterGroup.Name,
@@ -38,6 +39,17 @@ WHERE cluster_groups.name = ?`
 	return query.SelectStrings(ctx, c.tx, q, groupName)
 }
 
+// GetClusterGroupInstances returns the instances placed through the given cluster group, as "<project>/<name>".
+func (c *ClusterTx) GetClusterGroupInstances(ctx context.Context, groupName string) ([]string, error) {
+	q := `SELECT projects.name || '/' || instances.name FROM instances_config
+JOIN instances ON instances.id = instances_config.instance_id
+JOIN projects ON projects.id = instances.project_id
+WHERE instances_config.key = 'volatile.cluster.group' AND instances_config.value = ?
+ORDER BY projects.name, instances.name`
+
+	return query.SelectStrings(ctx, c.tx, q, groupName)
+}
+
 // GetClusterGroupURIs returns all available ClusterGroup URIs.
 // generator: ClusterGroup URIs
 func (c *ClusterTx) GetClusterGroupURIs(ctx context.Context, filter cluster.ClusterGroupFilter) ([]string, error) {
@@ -119,3 +131,39 @@ WHERE nodes.name = ?`
 
 	return query.SelectStrings(ctx, c.tx, q, nodeName)
 }
+
+// GetClusterGroupsConfig returns the configuration a member with the given configuration inherits from the given cluster groups.
+// Keys set by the member itself aren't inherited, any other key set to different values by the groups is a conflict.
+func (c *ClusterTx) GetClusterGroupsConfig(ctx context.Context, groups []string, memberConfig map[string]string) (map[string]string, error) {
+	config := map[string]string{}
+	origins := map[string]string{}
+
+	for _, group := range groups {
+		groupID, err := cluster.GetClusterGroupID(ctx, c.tx, group)
+		if err != nil {
+			return nil, err
+		}
+
+		groupConfig, err := cluster.GetClusterGroupConfig(ctx, c.tx, int(groupID))
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range groupConfig {
+			_, ok := memberConfig[k]
+			if ok {
+				continue
+			}
+
+			origin, ok := origins[k]
+			if ok && config[k] != v {
+				return nil, fmt.Errorf("Cluster groups %q and %q set different values for %q", origin, group, k)
+			}
+
+			config[k] = v
+			origins[k] = group
+		}
+	}
+
+	return config, nil
+}
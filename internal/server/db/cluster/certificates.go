@@ -77,6 +77,7 @@ func (cert *Certificate) ToAPI(ctx context.Context, tx *sql.Tx) (*api.Certificat
 	resp.Certificate = cert.Certificate
 	resp.Name = cert.Name
 	resp.Restricted = cert.Restricted
+	resp.AllProjects = !cert.Restricted
 	resp.Type = cert.ToAPIType()
 	resp.Description = cert.Description
 
@@ -146,3 +147,45 @@ func CreateCertificateWithProjects(ctx context.Context, tx *sql.Tx, cert Certifi
 
 	return id, err
 }
+
+// SwapCertificate replaces the certificate with the given fingerprint by a new one.
+// The new certificate takes over the name, description, type, restriction and projects of the old one,
+// which is then deleted. Only the Fingerprint and Certificate fields of newCert are used.
+// When run in a single transaction, this never leaves both or neither certificates behind.
+func SwapCertificate(ctx context.Context, tx *sql.Tx, oldFingerprint string, newCert Certificate) (int64, error) {
+	oldCert, err := GetCertificate(ctx, tx, oldFingerprint)
+	if err != nil {
+		return -1, fmt.Errorf("Failed loading certificate %q: %w", oldFingerprint, err)
+	}
+
+	projects, err := GetCertificateProjects(ctx, tx, oldCert.ID)
+	if err != nil {
+		return -1, fmt.Errorf("Failed loading projects of certificate %q: %w", oldFingerprint, err)
+	}
+
+	projectNames := make([]string, 0, len(projects))
+	for _, project := range projects {
+		projectNames = append(projectNames, project.Name)
+	}
+
+	cert := Certificate{
+		Fingerprint: newCert.Fingerprint,
+		Certificate: newCert.Certificate,
+		Type:        oldCert.Type,
+		Name:        oldCert.Name,
+		Restricted:  oldCert.Restricted,
+		Description: oldCert.Description,
+	}
+
+	id, err := CreateCertificateWithProjects(ctx, tx, cert, projectNames)
+	if err != nil {
+		return -1, fmt.Errorf("Failed creating certificate %q: %w", newCert.Fingerprint, err)
+	}
+
+	err = DeleteCertificate(ctx, tx, oldFingerprint)
+	if err != nil {
+		return -1, fmt.Errorf("Failed deleting certificate %q: %w", oldFingerprint, err)
+	}
+
+	return id, nil
+}
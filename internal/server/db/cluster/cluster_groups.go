@@ -15,21 +15,22 @@ import (
 //go:generate mapper stmt -e cluster_group delete-by-Name table=cluster_groups
 //go:generate mapper stmt -e cluster_group update table=cluster_groups
 //
-//go:generate mapper method -i -e cluster_group GetMany
+//go:generate mapper method -i -e cluster_group GetMany references=Config
 //go:generate mapper method -i -e cluster_group GetOne
 //go:generate mapper method -i -e cluster_group ID
 //go:generate mapper method -i -e cluster_group Exists
 //go:generate mapper method -i -e cluster_group Rename
-//go:generate mapper method -i -e cluster_group Create
-//go:generate mapper method -i -e cluster_group Update
+//go:generate mapper method -i -e cluster_group Create references=Config
+//go:generate mapper method -i -e cluster_group Update references=Config
 //go:generate mapper method -i -e cluster_group DeleteOne-by-Name
 
 // ClusterGroup is a value object holding db-related details about a cluster group.
 type ClusterGroup struct {
 	ID          int
 	Name        string
-	Description string   `db:"coalesce=''"`
-	Nodes       []string `db:"ignore"`
+	Description string            `db:"coalesce=''"`
+	Nodes       []string          `db:"ignore"`
+	Config      map[string]string `db:"ignore"`
 }
 
 // ClusterGroupFilter specifies potential query parameter fields.
@@ -44,6 +45,7 @@ func (c *ClusterGroup) ToAPI() (*api.ClusterGroup, error) {
 		ClusterGroupPut: api.ClusterGroupPut{
 			Description: c.Description,
 			Members:     c.Nodes,
+			Config:      c.Config,
 		},
 		ClusterGroupPost: api.ClusterGroupPost{
 			Name: c.Name,
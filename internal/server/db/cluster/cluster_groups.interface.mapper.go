@@ -9,6 +9,10 @@ import (
 
 // ClusterGroupGenerated is an interface of generated methods for ClusterGroup.
 type ClusterGroupGenerated interface {
+	// GetClusterGroupConfig returns all available ClusterGroup Config
+	// generator: cluster_group GetMany
+	GetClusterGroupConfig(ctx context.Context, tx *sql.Tx, clusterGroupID int, filters ...ConfigFilter) (map[string]string, error)
+
 	// GetClusterGroups returns all available cluster_groups.
 	// generator: cluster_group GetMany
 	GetClusterGroups(ctx context.Context, tx *sql.Tx, filters ...ClusterGroupFilter) ([]ClusterGroup, error)
@@ -29,6 +33,10 @@ type ClusterGroupGenerated interface {
 	// generator: cluster_group Rename
 	RenameClusterGroup(ctx context.Context, tx *sql.Tx, name string, to string) error
 
+	// CreateClusterGroupConfig adds new cluster_group Config to the database.
+	// generator: cluster_group Create
+	CreateClusterGroupConfig(ctx context.Context, tx *sql.Tx, clusterGroupID int64, config map[string]string) error
+
 	// CreateClusterGroup adds a new cluster_group to the database.
 	// generator: cluster_group Create
 	CreateClusterGroup(ctx context.Context, tx *sql.Tx, object ClusterGroup) (int64, error)
@@ -37,6 +45,10 @@ type ClusterGroupGenerated interface {
 	// generator: cluster_group Update
 	UpdateClusterGroup(ctx context.Context, tx *sql.Tx, name string, object ClusterGroup) error
 
+	// UpdateClusterGroupConfig updates the cluster_group Config matching the given key parameters.
+	// generator: cluster_group Update
+	UpdateClusterGroupConfig(ctx context.Context, tx *sql.Tx, clusterGroupID int64, config map[string]string) error
+
 	// DeleteClusterGroup deletes the cluster_group matching the given key parameters.
 	// generator: cluster_group DeleteOne-by-Name
 	DeleteClusterGroup(ctx context.Context, tx *sql.Tx, name string) error
@@ -29,6 +29,14 @@ CREATE TABLE "cluster_groups" (
     description TEXT NOT NULL,
     UNIQUE (name)
 );
+CREATE TABLE clusters_groups_config (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	cluster_group_id INTEGER NOT NULL,
+	key TEXT NOT NULL,
+	value TEXT NOT NULL,
+	UNIQUE (cluster_group_id, key),
+	FOREIGN KEY (cluster_group_id) REFERENCES cluster_groups (id) ON DELETE CASCADE
+);
 CREATE TABLE config (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     key TEXT NOT NULL,
@@ -114,6 +122,7 @@ CREATE TABLE "instances" (
     FOREIGN KEY (node_id) REFERENCES "nodes" (id) ON DELETE CASCADE,
     FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
 );
+CREATE INDEX instances_architecture_idx ON instances (architecture);
 CREATE TABLE "instances_backups" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     instance_id INTEGER NOT NULL,
@@ -361,6 +370,15 @@ CREATE TABLE "networks_zones_records_config" (
 	UNIQUE (network_zone_record_id, key),
 	FOREIGN KEY (network_zone_record_id) REFERENCES "networks_zones_records" (id) ON DELETE CASCADE
 );
+CREATE TABLE networks_zones_records_health (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	network_zone_record_id INTEGER NOT NULL,
+	value TEXT NOT NULL,
+	healthy INTEGER NOT NULL,
+	last_check DATETIME NOT NULL,
+	UNIQUE (network_zone_record_id, value),
+	FOREIGN KEY (network_zone_record_id) REFERENCES "networks_zones_records" (id) ON DELETE CASCADE
+);
 CREATE TABLE "nodes" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     name TEXT NOT NULL,
@@ -372,6 +390,7 @@ CREATE TABLE "nodes" (
     state INTEGER NOT NULL DEFAULT 0,
     arch INTEGER NOT NULL DEFAULT 0 CHECK (arch > 0),
     failure_domain_id INTEGER DEFAULT NULL REFERENCES nodes_failure_domains (id) ON DELETE SET NULL,
+    cordoned INTEGER NOT NULL DEFAULT 0,
     UNIQUE (name),
     UNIQUE (address)
 );
@@ -649,5 +668,5 @@ CREATE TABLE "warnings" (
 );
 CREATE UNIQUE INDEX warnings_unique_node_id_project_id_entity_type_code_entity_id_type_code ON warnings(IFNULL(node_id, -1), IFNULL(project_id, -1), entity_type_code, entity_id, type_code);
 
-INSERT INTO schema (version, updated_at) VALUES (73, strftime("%s"))
+INSERT INTO schema (version, updated_at) VALUES (77, strftime("%s"))
 `
@@ -110,6 +110,73 @@ var updates = map[int]schema.Update{
 	71: updateFromV70,
 	72: updateFromV71,
 	73: updateFromV72,
+	74: updateFromV73,
+	75: updateFromV74,
+	76: updateFromV75,
+	77: updateFromV76,
+}
+
+// updateFromV76 adds the health check results of network zone record entries.
+func updateFromV76(ctx context.Context, tx *sql.Tx) error {
+	q := `
+CREATE TABLE networks_zones_records_health (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	network_zone_record_id INTEGER NOT NULL,
+	value TEXT NOT NULL,
+	healthy INTEGER NOT NULL,
+	last_check DATETIME NOT NULL,
+	UNIQUE (network_zone_record_id, value),
+	FOREIGN KEY (network_zone_record_id) REFERENCES "networks_zones_records" (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding network zone record health table: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV75 adds a flag preventing new instances from being placed on a member.
+func updateFromV75(ctx context.Context, tx *sql.Tx) error {
+	q := `ALTER TABLE nodes ADD COLUMN cordoned INTEGER NOT NULL DEFAULT 0;`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding cordoned column to nodes: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV74 adds configuration to cluster groups.
+func updateFromV74(ctx context.Context, tx *sql.Tx) error {
+	q := `
+CREATE TABLE clusters_groups_config (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	cluster_group_id INTEGER NOT NULL,
+	key TEXT NOT NULL,
+	value TEXT NOT NULL,
+	UNIQUE (cluster_group_id, key),
+	FOREIGN KEY (cluster_group_id) REFERENCES cluster_groups (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding cluster group configuration: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV73 adds an index on the architecture of instances.
+func updateFromV73(ctx context.Context, tx *sql.Tx) error {
+	q := `CREATE INDEX instances_architecture_idx ON instances (architecture);`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding instances architecture index: %w", err)
+	}
+
+	return nil
 }
 
 // updateFromV72 removes the openfga.store.model_id server config key.
@@ -17,6 +17,7 @@ import (
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/osarch"
 )
 
 // InstanceArgs is a value object holding all db-related details about an instance.
@@ -153,8 +154,9 @@ type Instance struct {
 // GetInstancesByMemberAddress returns the instances associated to each cluster member address.
 // The member address of instances running on the local member is set to the empty string, to distinguish it from
 // remote nodes. Instances whose member is down are added to the special address "0.0.0.0".
-func (c *ClusterTx) GetInstancesByMemberAddress(ctx context.Context, offlineThreshold time.Duration, projects []string, instType instancetype.Type) (map[string][]Instance, error) {
-	args := make([]any, 0, 2) // Expect up to 2 filters.
+// If architecture isn't osarch.ARCH_UNKNOWN, only instances of that architecture are returned.
+func (c *ClusterTx) GetInstancesByMemberAddress(ctx context.Context, offlineThreshold time.Duration, projects []string, instType instancetype.Type, architecture int) (map[string][]Instance, error) {
+	args := make([]any, 0, 3) // Expect up to 3 filters.
 	var q strings.Builder
 
 	q.WriteString(`SELECT
@@ -178,6 +180,12 @@ func (c *ClusterTx) GetInstancesByMemberAddress(ctx context.Context, offlineThre
 		args = append(args, instType)
 	}
 
+	// Architecture filter.
+	if architecture != osarch.ARCH_UNKNOWN {
+		q.WriteString(" AND instances.architecture = ?")
+		args = append(args, architecture)
+	}
+
 	q.WriteString(" ORDER BY instances.id")
 
 	rows, err := c.tx.QueryContext(ctx, q.String(), args...)
@@ -216,6 +224,39 @@ func (c *ClusterTx) GetInstancesByMemberAddress(ctx context.Context, offlineThre
 	return memberAddressInstances, nil
 }
 
+// GetInstanceMembersWithConfig returns the address of the cluster member of each instance in the project
+// having the given config key set to the given value, indexed by instance name.
+func (c *ClusterTx) GetInstanceMembersWithConfig(ctx context.Context, project string, key string, value string) (map[string]string, error) {
+	q := `
+SELECT instances.name, nodes.address
+  FROM instances
+  JOIN projects ON projects.id = instances.project_id
+  JOIN nodes ON nodes.id = instances.node_id
+  JOIN instances_config ON instances_config.instance_id = instances.id
+ WHERE projects.name = ? AND instances_config.key = ? AND instances_config.value = ?
+`
+
+	members := map[string]string{}
+	err := query.Scan(ctx, c.tx, q, func(scan func(dest ...any) error) error {
+		var name string
+		var address string
+
+		err := scan(&name, &address)
+		if err != nil {
+			return err
+		}
+
+		members[name] = address
+
+		return nil
+	}, project, key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
 // ErrInstanceListStop used as return value from InstanceList's instanceFunc when prematurely stopping the search.
 var ErrInstanceListStop = fmt.Errorf("search stopped")
 
@@ -15,6 +15,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/osarch"
 )
 
 func TestContainerList(t *testing.T) {
@@ -426,7 +427,7 @@ func TestGetInstancesByMemberAddress(t *testing.T) {
 	addContainer(t, tx, nodeID2, "c4")
 
 	instType := instancetype.Container
-	result, err := tx.GetInstancesByMemberAddress(context.Background(), time.Duration(db.DefaultOfflineThreshold)*time.Second, []string{"default"}, instType)
+	result, err := tx.GetInstancesByMemberAddress(context.Background(), time.Duration(db.DefaultOfflineThreshold)*time.Second, []string{"default"}, instType, osarch.ARCH_UNKNOWN)
 	require.NoError(t, err)
 	assert.Equal(
 		t,
@@ -437,6 +438,32 @@ func TestGetInstancesByMemberAddress(t *testing.T) {
 		}, result)
 }
 
+// Containers can be filtered by architecture.
+func TestGetInstancesByMemberAddress_Architecture(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	nodeID2, err := tx.CreateNode("node2", "1.2.3.4:666")
+	require.NoError(t, err)
+
+	addContainer(t, tx, 1, "c1")
+	addContainer(t, tx, nodeID2, "c2")
+
+	stmt := `
+INSERT INTO instances(node_id, name, architecture, type, project_id, description) VALUES (?, ?, ?, ?, 1, '')
+`
+	_, err = tx.Tx().Exec(stmt, nodeID2, "c3", osarch.ARCH_64BIT_ARMV8_LITTLE_ENDIAN, instancetype.Container)
+	require.NoError(t, err)
+
+	result, err := tx.GetInstancesByMemberAddress(context.Background(), time.Duration(db.DefaultOfflineThreshold)*time.Second, []string{"default"}, instancetype.Any, osarch.ARCH_64BIT_ARMV8_LITTLE_ENDIAN)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		map[string][]db.Instance{
+			"1.2.3.4:666": {{ID: 3, Project: api.ProjectDefaultName, Name: "c3", Location: "node2"}},
+		}, result)
+}
+
 func TestGetInstancePool(t *testing.T) {
 	dbCluster, cleanup := db.NewTestCluster(t)
 	defer cleanup()
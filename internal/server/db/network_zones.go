@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/lxc/incus/v6/internal/server/db/query"
 	"github.com/lxc/incus/v6/shared/api"
@@ -484,3 +485,66 @@ func (c *ClusterTx) DeleteNetworkZoneRecord(ctx context.Context, id int64) error
 
 	return err
 }
+
+// NetworkZoneRecordHealth is the outcome of the last health check of a network zone record entry.
+type NetworkZoneRecordHealth struct {
+	Healthy   bool
+	LastCheck time.Time
+}
+
+// GetNetworkZoneRecordsHealth returns the health check results of the entries of the zone's records,
+// indexed by record name and entry value.
+func (c *ClusterTx) GetNetworkZoneRecordsHealth(ctx context.Context, zone int64) (map[string]map[string]NetworkZoneRecordHealth, error) {
+	q := `
+		SELECT networks_zones_records.name, networks_zones_records_health.value, networks_zones_records_health.healthy, networks_zones_records_health.last_check
+		FROM networks_zones_records_health
+		JOIN networks_zones_records ON networks_zones_records.id = networks_zones_records_health.network_zone_record_id
+		WHERE networks_zones_records.network_zone_id=?
+	`
+
+	results := map[string]map[string]NetworkZoneRecordHealth{}
+
+	err := query.Scan(ctx, c.tx, q, func(scan func(dest ...any) error) error {
+		var name, value string
+		var health NetworkZoneRecordHealth
+
+		err := scan(&name, &value, &health.Healthy, &health.LastCheck)
+		if err != nil {
+			return err
+		}
+
+		if results[name] == nil {
+			results[name] = map[string]NetworkZoneRecordHealth{}
+		}
+
+		results[name][value] = health
+
+		return nil
+	}, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// SetNetworkZoneRecordHealth stores the health check result of a network zone record entry.
+func (c *ClusterTx) SetNetworkZoneRecordHealth(ctx context.Context, zone int64, name string, value string, health NetworkZoneRecordHealth) error {
+	_, err := c.tx.ExecContext(ctx, `
+		INSERT INTO networks_zones_records_health (network_zone_record_id, value, healthy, last_check)
+		SELECT id, ?, ?, ? FROM networks_zones_records WHERE network_zone_id=? AND name=?
+		ON CONFLICT (network_zone_record_id, value) DO UPDATE SET healthy=excluded.healthy, last_check=excluded.last_check
+	`, value, health.Healthy, health.LastCheck, zone, name)
+
+	return err
+}
+
+// DeleteNetworkZoneRecordHealth deletes the health check result of a network zone record entry.
+func (c *ClusterTx) DeleteNetworkZoneRecordHealth(ctx context.Context, zone int64, name string, value string) error {
+	_, err := c.tx.ExecContext(ctx, `
+		DELETE FROM networks_zones_records_health
+		WHERE value=? AND network_zone_record_id IN (SELECT id FROM networks_zones_records WHERE network_zone_id=? AND name=?)
+	`, value, zone, name)
+
+	return err
+}
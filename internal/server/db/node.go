@@ -6,6 +6,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
 	"net/http"
 	"slices"
 	"strconv"
@@ -68,10 +69,31 @@ type NodeInfo struct {
 	Roles         []ClusterRole     // List of cluster roles
 	Architecture  int               // Node architecture
 	State         int               // Node state
+	Cordoned      bool              // Whether new instances are prevented from being placed on the node
 	Config        map[string]string // Configuration for the node
+	GroupsConfig  map[string]string // Configuration inherited from the cluster groups
 	Groups        []string          // Cluster groups
 }
 
+// ExpandedConfig returns the node's configuration, including the defaults inherited from its cluster groups.
+func (n NodeInfo) ExpandedConfig() map[string]string {
+	config := make(map[string]string, len(n.GroupsConfig)+len(n.Config))
+	maps.Copy(config, n.GroupsConfig)
+	maps.Copy(config, n.Config)
+
+	return config
+}
+
+// SchedulerPriority returns the scheduler.priority of the node, defaulting to 0.
+func (n NodeInfo) SchedulerPriority() int {
+	priority, err := strconv.Atoi(n.ExpandedConfig()["scheduler.priority"])
+	if err != nil {
+		return 0
+	}
+
+	return priority
+}
+
 // IsOffline returns true if the last successful heartbeat time of the node is
 // older than the given threshold.
 func (n NodeInfo) IsOffline(threshold time.Duration) bool {
@@ -86,6 +108,7 @@ type NodeInfoArgs struct {
 	OfflineThreshold     time.Duration
 	MaxMemberVersion     [2]int
 	RaftNodes            []RaftNode
+	MemberResources      map[string]*api.ClusterMemberResources
 }
 
 // ToAPI returns an API entry.
@@ -143,6 +166,8 @@ func (n NodeInfo) ToAPI(ctx context.Context, tx *ClusterTx, args NodeInfoArgs) (
 	}
 
 	result.FailureDomain = failureDomain
+	result.Cordoned = n.Cordoned
+	result.Resources = args.MemberResources[n.Name]
 
 	// Set state and message.
 	result.Status = "Online"
@@ -477,8 +502,41 @@ JOIN cluster_groups ON cluster_groups.id = nodes_cluster_groups.group_id`
 		return nil, err
 	}
 
+	// Get the configuration inherited from the node groups
+	sql = `SELECT nodes_cluster_groups.node_id, clusters_groups_config.key, clusters_groups_config.value FROM nodes_cluster_groups
+JOIN clusters_groups_config ON clusters_groups_config.cluster_group_id = nodes_cluster_groups.group_id
+ORDER BY nodes_cluster_groups.group_id`
+	nodeGroupsConfig := map[int64]map[string]string{}
+
+	err = query.Scan(ctx, c.Tx(), sql, func(scan func(dest ...any) error) error {
+		var nodeID int64
+		var key string
+		var value string
+
+		err := scan(&nodeID, &key, &value)
+		if err != nil {
+			return err
+		}
+
+		if nodeGroupsConfig[nodeID] == nil {
+			nodeGroupsConfig[nodeID] = map[string]string{}
+		}
+
+		// Conflicting group values are rejected when set, keep the first one found.
+		_, ok := nodeGroupsConfig[nodeID][key]
+		if !ok {
+			nodeGroupsConfig[nodeID][key] = value
+		}
+
+		return nil
+	})
+	if err != nil && err.Error() != "no such table: clusters_groups_config" {
+		// Don't fail on a missing table, we need to handle updates
+		return nil, err
+	}
+
 	// Get the node entries
-	sql = "SELECT id, name, address, description, schema, api_extensions, heartbeat, arch, state FROM nodes "
+	sql = "SELECT id, name, address, description, schema, api_extensions, heartbeat, arch, state, cordoned FROM nodes "
 
 	if pending {
 		// Include only pending nodes
@@ -500,7 +558,7 @@ JOIN cluster_groups ON cluster_groups.id = nodes_cluster_groups.group_id`
 	nodes := []NodeInfo{}
 	err = query.Scan(ctx, c.tx, sql, func(scan func(dest ...any) error) error {
 		node := NodeInfo{}
-		err := scan(&node.ID, &node.Name, &node.Address, &node.Description, &node.Schema, &node.APIExtensions, &node.Heartbeat, &node.Architecture, &node.State)
+		err := scan(&node.ID, &node.Name, &node.Address, &node.Description, &node.Schema, &node.APIExtensions, &node.Heartbeat, &node.Architecture, &node.State, &node.Cordoned)
 		if err != nil {
 			return err
 		}
@@ -543,6 +601,15 @@ JOIN cluster_groups ON cluster_groups.id = nodes_cluster_groups.group_id`
 		}
 	}
 
+	for i := range nodes {
+		data, ok := nodeGroupsConfig[nodes[i].ID]
+		if !ok {
+			nodes[i].GroupsConfig = map[string]string{}
+		} else {
+			nodes[i].GroupsConfig = data
+		}
+	}
+
 	return nodes, nil
 }
 
@@ -774,6 +841,25 @@ func (c *ClusterTx) UpdateNodeStatus(id int64, state int) error {
 	return nil
 }
 
+// UpdateNodeCordoned sets whether new instances are prevented from being placed on the node with the given ID.
+func (c *ClusterTx) UpdateNodeCordoned(id int64, cordoned bool) error {
+	result, err := c.tx.Exec("UPDATE nodes SET cordoned=? WHERE id=?", cordoned, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n != 1 {
+		return fmt.Errorf("Query updated %d rows instead of 1", n)
+	}
+
+	return nil
+}
+
 // GetNodeFailureDomain returns the failure domain associated with the node with the given ID.
 func (c *ClusterTx) GetNodeFailureDomain(ctx context.Context, id int64) (string, error) {
 	stmt := `
@@ -1058,18 +1144,20 @@ func (c *ClusterTx) GetCandidateMembers(ctx context.Context, allMembers []NodeIn
 	var candidateMembers []NodeInfo
 
 	for _, member := range allMembers {
-		// Skip pending, evacuated or offline members.
-		if member.State != ClusterMemberStateCreated || member.IsOffline(offlineThreshold) {
+		// Skip pending, evacuated, cordoned or offline members.
+		if member.State != ClusterMemberStateCreated || member.Cordoned || member.IsOffline(offlineThreshold) {
 			continue
 		}
 
+		memberConfig := member.ExpandedConfig()
+
 		// Skip manually targeted members.
-		if member.Config["scheduler.instance"] == "manual" {
+		if memberConfig["scheduler.instance"] == "manual" {
 			continue
 		}
 
 		// Skip group-only members if targeted cluster group doesn't match.
-		if member.Config["scheduler.instance"] == "group" && !slices.Contains(member.Groups, targetClusterGroup) {
+		if memberConfig["scheduler.instance"] == "group" && !slices.Contains(member.Groups, targetClusterGroup) {
 			continue
 		}
 
@@ -1117,28 +1205,68 @@ func (c *ClusterTx) GetCandidateMembers(ctx context.Context, allMembers []NodeIn
 	return candidateMembers, nil
 }
 
+// GetNodesInstanceCount returns the number of instances located on each cluster member, indexed by member ID.
+// Members without any instance aren't included.
+func (c *ClusterTx) GetNodesInstanceCount(ctx context.Context) (map[int64]int, error) {
+	return c.countByNode(ctx, "SELECT node_id, COUNT(*) FROM instances GROUP BY node_id")
+}
+
+// countByNode runs the given query returning pairs of member ID and count, and returns the counts indexed by member ID.
+func (c *ClusterTx) countByNode(ctx context.Context, stmt string, args ...any) (map[int64]int, error) {
+	counts := map[int64]int{}
+
+	err := query.Scan(ctx, c.tx, stmt, func(scan func(dest ...any) error) error {
+		var nodeID int64
+		var count int
+
+		err := scan(&nodeID, &count)
+		if err != nil {
+			return err
+		}
+
+		counts[nodeID] = count
+
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 // GetNodeWithLeastInstances returns the name of the member with the least number of instances that are either
 // already created or being created with an operation.
 func (c *ClusterTx) GetNodeWithLeastInstances(ctx context.Context, members []NodeInfo) (*NodeInfo, error) {
+	return c.GetNodeWithLeastInstancesPlanned(ctx, members, nil)
+}
+
+// GetNodeWithLeastInstancesPlanned works like GetNodeWithLeastInstances but also counts the instances planned
+// to be moved to each member, indexed by member ID.
+// Members having the same number of instances are told apart by their scheduler.priority, the highest winning.
+func (c *ClusterTx) GetNodeWithLeastInstancesPlanned(ctx context.Context, members []NodeInfo, planned map[int64]int) (*NodeInfo, error) {
 	var member *NodeInfo
 	var lowestInstanceCount = -1
+	var highestPriority int
 
-	for i := range members {
-		// Fetch the number of instances already created on this member.
-		created, err := query.Count(ctx, c.tx, "instances", "node_id=?", members[i].ID)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to get instances count: %w", err)
-		}
+	// Fetch the number of instances already created on each member.
+	created, err := c.GetNodesInstanceCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get instances count: %w", err)
+	}
 
-		// Fetch the number of instances currently being created on this member.
-		pending, err := query.Count(ctx, c.tx, "operations", "node_id=? AND type=?", members[i].ID, operationtype.InstanceCreate)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to get pending instances count: %w", err)
-		}
+	// Fetch the number of instances currently being created on each member.
+	pending, err := c.countByNode(ctx, "SELECT node_id, COUNT(*) FROM operations WHERE type=? GROUP BY node_id", operationtype.InstanceCreate)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get pending instances count: %w", err)
+	}
 
-		memberInstanceCount := created + pending
-		if lowestInstanceCount == -1 || memberInstanceCount < lowestInstanceCount {
+	for i := range members {
+		memberInstanceCount := created[members[i].ID] + pending[members[i].ID] + planned[members[i].ID]
+		memberPriority := members[i].SchedulerPriority()
+		if lowestInstanceCount == -1 || memberInstanceCount < lowestInstanceCount || (memberInstanceCount == lowestInstanceCount && memberPriority > highestPriority) {
 			lowestInstanceCount = memberInstanceCount
+			highestPriority = memberPriority
 			member = &members[i]
 		}
 	}
@@ -330,6 +330,61 @@ INSERT INTO instances (id, node_id, name, architecture, type, project_id, descri
 	assert.Equal(t, "buzz", member.Name)
 }
 
+// If the nodes have the same number of instances, return the one with the
+// highest scheduler priority.
+func TestGetNodeWithLeastInstances_Priority(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	id, err := tx.CreateNode("buzz", "1.2.3.4:666")
+	require.NoError(t, err)
+
+	allMembers, err := tx.GetNodes(context.Background())
+	require.NoError(t, err)
+
+	member, err := tx.GetNodeWithLeastInstances(context.Background(), allMembers)
+	require.NoError(t, err)
+	assert.Equal(t, "none", member.Name)
+
+	err = tx.UpdateNodeConfig(context.Background(), id, map[string]string{"scheduler.priority": "10"})
+	require.NoError(t, err)
+
+	allMembers, err = tx.GetNodes(context.Background())
+	require.NoError(t, err)
+
+	member, err = tx.GetNodeWithLeastInstances(context.Background(), allMembers)
+	require.NoError(t, err)
+	assert.Equal(t, "buzz", member.Name)
+}
+
+func TestGetNodesInstanceCount(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	id, err := tx.CreateNode("buzz", "1.2.3.4:666")
+	require.NoError(t, err)
+
+	// Add two instances to the default node (ID 1) and one to the new node.
+	_, err = tx.Tx().Exec(`
+INSERT INTO instances (id, node_id, name, architecture, type, project_id, description) VALUES (1, 1, 'foo', 1, 1, 1, '')
+`)
+	require.NoError(t, err)
+
+	_, err = tx.Tx().Exec(`
+INSERT INTO instances (id, node_id, name, architecture, type, project_id, description) VALUES (2, 1, 'bar', 1, 1, 1, '')
+`)
+	require.NoError(t, err)
+
+	_, err = tx.Tx().Exec(`
+INSERT INTO instances (id, node_id, name, architecture, type, project_id, description) VALUES (3, ?, 'egg', 1, 1, 1, '')
+`, id)
+	require.NoError(t, err)
+
+	counts, err := tx.GetNodesInstanceCount(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int{1: 2, id: 1}, counts)
+}
+
 // If there are nodes, and one of them is offline, return the name of the
 // online node, even if the offline one has more instances.
 func TestGetNodeWithLeastInstances_OfflineNode(t *testing.T) {
@@ -388,6 +443,40 @@ INSERT INTO operations (id, uuid, node_id, type, project_id) VALUES (1, 'abc', 1
 	assert.Equal(t, "buzz", member.Name)
 }
 
+// Drained nodes aren't candidates for new instances, until they get uncordoned.
+func TestGetCandidateMembers_Cordoned(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	id, err := tx.CreateNode("buzz", "1.2.3.4:666")
+	require.NoError(t, err)
+
+	err = tx.UpdateNodeCordoned(id, true)
+	require.NoError(t, err)
+
+	node, err := tx.GetNodeByName(context.Background(), "buzz")
+	require.NoError(t, err)
+	assert.True(t, node.Cordoned)
+
+	allMembers, err := tx.GetNodes(context.Background())
+	require.NoError(t, err)
+
+	members, err := tx.GetCandidateMembers(context.Background(), allMembers, nil, "", nil, time.Duration(db.DefaultOfflineThreshold)*time.Second)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, "none", members[0].Name)
+
+	err = tx.UpdateNodeCordoned(id, false)
+	require.NoError(t, err)
+
+	allMembers, err = tx.GetNodes(context.Background())
+	require.NoError(t, err)
+
+	members, err = tx.GetCandidateMembers(context.Background(), allMembers, nil, "", nil, time.Duration(db.DefaultOfflineThreshold)*time.Second)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+}
+
 // If specific architectures were selected, return only nodes with those
 // architectures.
 func TestGetNodeWithLeastInstances_Architecture(t *testing.T) {
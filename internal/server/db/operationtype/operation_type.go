@@ -79,6 +79,10 @@ const (
 	BucketBackupRemove
 	BucketBackupRename
 	BucketBackupRestore
+	ClusterMemberDrain
+	ClusterMemberUncordon
+	ClusterFailback
+	BackupVerify
 )
 
 // Description return a human-readable description of the operation type.
@@ -210,6 +214,14 @@ func (t Type) Description() string {
 		return "Renaming bucket backup"
 	case BucketBackupRestore:
 		return "Restoring bucket backup"
+	case ClusterMemberDrain:
+		return "Draining cluster member"
+	case ClusterMemberUncordon:
+		return "Uncordoning cluster member"
+	case ClusterFailback:
+		return "Failing back cluster instances"
+	case BackupVerify:
+		return "Verifying instance backup"
 	default:
 		return "Executing operation"
 	}
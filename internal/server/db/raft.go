@@ -35,6 +35,9 @@ const (
 // GetRaftNodes returns information about all cluster members that are members of the
 // dqlite Raft cluster (possibly including the local member). If this server
 // is not running in clustered mode, an empty list is returned.
+//
+// The cluster member name is stored alongside each raft node and is returned as well.
+// Use GetRaftMembers to also get the other details of the matching cluster members.
 func (n *NodeTx) GetRaftNodes(ctx context.Context) ([]RaftNode, error) {
 	nodes := []RaftNode{}
 
@@ -57,6 +60,69 @@ func (n *NodeTx) GetRaftNodes(ctx context.Context) ([]RaftNode, error) {
 	return nodes, nil
 }
 
+// RaftMember holds a raft node along with the cluster member it belongs to.
+type RaftMember struct {
+	RaftNode
+
+	// Member is nil if no cluster member matches the raft node.
+	Member *NodeInfo
+}
+
+// GetRaftMembers returns the raft nodes joined with the cluster members they belong to.
+//
+// Raft nodes live in the local database while cluster members live in the cluster
+// database, so they're loaded separately and joined with JoinRaftMembers.
+func GetRaftMembers(ctx context.Context, nodeDB *Node, clusterDB *Cluster) ([]RaftMember, error) {
+	var raftNodes []RaftNode
+	err := nodeDB.Transaction(ctx, func(ctx context.Context, tx *NodeTx) error {
+		var err error
+
+		raftNodes, err = tx.GetRaftNodes(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var members []NodeInfo
+	err = clusterDB.Transaction(ctx, func(ctx context.Context, tx *ClusterTx) error {
+		var err error
+
+		members, err = tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return JoinRaftMembers(raftNodes, members), nil
+}
+
+// JoinRaftMembers matches the given raft nodes with the given cluster members by address.
+// Raft nodes recorded without a member name get the one of their matching member.
+func JoinRaftMembers(raftNodes []RaftNode, members []NodeInfo) []RaftMember {
+	membersByAddress := make(map[string]*NodeInfo, len(members))
+	for i := range members {
+		membersByAddress[members[i].Address] = &members[i]
+	}
+
+	raftMembers := make([]RaftMember, 0, len(raftNodes))
+	for _, node := range raftNodes {
+		raftMember := RaftMember{RaftNode: node, Member: membersByAddress[node.Address]}
+		if raftMember.Member != nil && raftMember.Name == "" {
+			raftMember.Name = raftMember.Member.Name
+		}
+
+		raftMembers = append(raftMembers, raftMember)
+	}
+
+	return raftMembers
+}
+
 // GetRaftNodeAddresses returns the addresses of all servers that are members of
 // the dqlite Raft cluster (possibly including the local member). If this server
 // is not running in clustered mode, an empty list is returned.
@@ -138,3 +138,31 @@ func TestReplaceRaftNodes(t *testing.T) {
 
 	assert.Equal(t, nodes, newNodes)
 }
+
+// Join raft nodes with the cluster members they belong to.
+func TestJoinRaftMembers(t *testing.T) {
+	raftNodes := []db.RaftNode{
+		{NodeInfo: client.NodeInfo{ID: 1, Address: "1.2.3.4:666", Role: db.RaftVoter}, Name: "node1"},
+		{NodeInfo: client.NodeInfo{ID: 2, Address: "5.6.7.8:666", Role: db.RaftStandBy}},
+		{NodeInfo: client.NodeInfo{ID: 3, Address: "9.9.9.9:666", Role: db.RaftSpare}, Name: "gone"},
+	}
+
+	members := []db.NodeInfo{
+		{ID: 1, Name: "node1", Address: "1.2.3.4:666", State: db.ClusterMemberStateCreated},
+		{ID: 2, Name: "node2", Address: "5.6.7.8:666", State: db.ClusterMemberStateEvacuated},
+	}
+
+	raftMembers := db.JoinRaftMembers(raftNodes, members)
+	require.Len(t, raftMembers, 3)
+
+	assert.Equal(t, "node1", raftMembers[0].Name)
+	require.NotNil(t, raftMembers[0].Member)
+	assert.Equal(t, int64(1), raftMembers[0].Member.ID)
+
+	assert.Equal(t, "node2", raftMembers[1].Name)
+	require.NotNil(t, raftMembers[1].Member)
+	assert.Equal(t, db.ClusterMemberStateEvacuated, raftMembers[1].Member.State)
+
+	assert.Equal(t, "gone", raftMembers[2].Name)
+	assert.Nil(t, raftMembers[2].Member)
+}
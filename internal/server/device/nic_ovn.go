@@ -162,6 +162,11 @@ func (d *nicOVN) validateConfig(instConf instance.ConfigReader) error {
 		if ip.Equal(net.ParseIP(d.config["ipv4.address"])) {
 			return fmt.Errorf("IP address %q is assigned to parent managed network device %q", d.config["ipv4.address"], d.config["parent"])
 		}
+
+		// IP should not be the source address of the network's load balancer health checks.
+		if network.OVNLoadBalancerHealthCheckSource(subnet).Equal(net.ParseIP(d.config["ipv4.address"])) {
+			return fmt.Errorf("IP address %q is reserved for load balancer health checks on network %q", d.config["ipv4.address"], d.config["network"])
+		}
 	}
 
 	if d.config["ipv6.address"] != "" {
@@ -190,6 +195,12 @@ func (d *nicOVN) validateConfig(instConf instance.ConfigReader) error {
 		if ip.Equal(net.ParseIP(d.config["ipv6.address"])) {
 			return fmt.Errorf("IP address %q is assigned to parent managed network device %q", d.config["ipv6.address"], d.config["parent"])
 		}
+
+		// IP should not be the source address of the network's load balancer health checks.
+		// OVN only allocates EUI64 addresses dynamically, so static addresses are the only ones which can clash.
+		if network.OVNLoadBalancerHealthCheckSource(subnet).Equal(net.ParseIP(d.config["ipv6.address"])) {
+			return fmt.Errorf("IP address %q is reserved for load balancer health checks on network %q", d.config["ipv6.address"], d.config["network"])
+		}
 	}
 
 	// Apply network level config options to device config before validation.
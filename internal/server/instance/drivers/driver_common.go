@@ -380,6 +380,13 @@ func (d *common) ConsoleBufferLogPath() string {
 	return filepath.Join(d.LogPath(), "console.log")
 }
 
+// ConsoleRecordingsPath returns the instance's console recordings path.
+// Recordings capture the console input, so they're kept away from the instance's log files.
+func (d *common) ConsoleRecordingsPath() string {
+	name := project.Instance(d.project.Name, d.name)
+	return internalUtil.VarPath("console-recordings", name)
+}
+
 // DevicesPath returns the instance's devices path.
 func (d *common) DevicesPath() string {
 	name := project.Instance(d.project.Name, d.name)
@@ -613,7 +620,7 @@ func (d *common) restartCommon(inst instance.Instance, timeout time.Duration) er
 }
 
 // rebuildCommon handles the common part of instance rebuilds.
-func (d *common) rebuildCommon(inst instance.Instance, img *api.Image, op *operations.Operation) error {
+func (d *common) rebuildCommon(inst instance.Instance, img *api.Image, resetIdentity bool, op *operations.Operation) error {
 	instLocalConfig := d.localConfig
 
 	// Reset the "image.*" keys.
@@ -637,6 +644,19 @@ func (d *common) rebuildCommon(inst instance.Instance, img *api.Image, op *opera
 	delete(instLocalConfig, "volatile.idmap.next")
 	delete(instLocalConfig, "volatile.last_state.idmap")
 
+	// The instance identity is preserved unless asked otherwise.
+	if resetIdentity {
+		instLocalConfig["volatile.uuid"] = uuid.New().String()
+		instLocalConfig["volatile.uuid.generation"] = instLocalConfig["volatile.uuid"]
+
+		// MAC addresses get generated again on next start.
+		for k := range instLocalConfig {
+			if strings.HasPrefix(k, "volatile.") && strings.HasSuffix(k, ".hwaddr") {
+				delete(instLocalConfig, k)
+			}
+		}
+	}
+
 	pool, err := d.getStoragePool()
 	if err != nil {
 		return err
@@ -2931,8 +2931,8 @@ func (d *lxc) Restart(timeout time.Duration) error {
 }
 
 // Rebuild rebuilds the instance using the supplied image fingerprint as source.
-func (d *lxc) Rebuild(img *api.Image, op *operations.Operation) error {
-	return d.rebuildCommon(d, img, op)
+func (d *lxc) Rebuild(img *api.Image, resetIdentity bool, op *operations.Operation) error {
+	return d.rebuildCommon(d, img, resetIdentity, op)
 }
 
 // onStopNS is triggered by LXC's stop hook once a container is shutdown but before the container's
@@ -3721,6 +3721,9 @@ func (d *lxc) cleanup() {
 
 	// Remove the shmounts path
 	_ = os.RemoveAll(d.ShmountsPath())
+
+	// Remove the console recordings
+	_ = os.RemoveAll(d.ConsoleRecordingsPath())
 }
 
 // Delete deletes the instance.
@@ -4003,6 +4006,19 @@ func (d *lxc) Rename(newName string, applyTemplateTrigger bool) error {
 			return fmt.Errorf("Failed renaming instance: %w", err)
 		}
 	}
+
+	// Rename the console recordings path.
+	if !d.IsSnapshot() {
+		newRecordingsPath := internalUtil.VarPath("console-recordings", project.Instance(d.Project().Name, newName))
+		_ = os.RemoveAll(newRecordingsPath)
+		if util.PathExists(d.ConsoleRecordingsPath()) {
+			err := os.Rename(d.ConsoleRecordingsPath(), newRecordingsPath)
+			if err != nil {
+				d.logger.Error("Failed renaming instance", ctxMap)
+				return fmt.Errorf("Failed renaming instance: %w", err)
+			}
+		}
+	}
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -7145,7 +7161,7 @@ func (d *lxc) ConsoleLog(opts liblxc.ConsoleLogOptions) (string, error) {
 }
 
 // Exec executes a command inside the instance.
-func (d *lxc) Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, stderr *os.File) (instance.Cmd, error) {
+func (d *lxc) Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, stderr *os.File, extraFiles []*os.File) (instance.Cmd, error) {
 	// Generate the LXC config if missing.
 	configPath := filepath.Join(d.RunPath(), "lxc.conf")
 	if !util.PathExists(configPath) {
@@ -7196,6 +7212,8 @@ func (d *lxc) Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, st
 		args = append(args, "0")
 	}
 
+	args = append(args, fmt.Sprintf("%d", len(extraFiles)))
+
 	args = append(args, "--")
 	args = append(args, "env")
 	args = append(args, envSlice...)
@@ -7231,21 +7249,22 @@ func (d *lxc) Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, st
 
 	// Setup communication PIPE
 	rStatus, wStatus, err := os.Pipe()
-	defer func() { _ = rStatus.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	cmd.ExtraFiles = []*os.File{stdin, stdout, stderr, wStatus}
+	cmd.ExtraFiles = append([]*os.File{stdin, stdout, stderr, wStatus}, extraFiles...)
 	err = cmd.Start()
 	_ = wStatus.Close()
 	if err != nil {
+		_ = rStatus.Close()
 		return nil, err
 	}
 
 	attachedPid := linux.ReadPid(rStatus)
 	if attachedPid <= 0 {
 		_ = cmd.Wait()
+		_ = rStatus.Close()
 		d.logger.Error("Failed to retrieve PID of executing child process")
 		return nil, fmt.Errorf("Failed to retrieve PID of executing child process")
 	}
@@ -7254,9 +7273,11 @@ func (d *lxc) Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, st
 
 	d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceExec.Event(d, logger.Ctx{"command": req.Command}))
 
+	// The status pipe is kept open to retrieve the wait status of the command once it ends.
 	instCmd := &lxcCmd{
 		cmd:              &cmd,
 		attachedChildPid: int(attachedPid),
+		status:           rStatus,
 	}
 
 	return instCmd, nil
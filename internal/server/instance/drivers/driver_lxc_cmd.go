@@ -1,6 +1,8 @@
 package drivers
 
 import (
+	"encoding/binary"
+	"os"
 	"os/exec"
 
 	"golang.org/x/sys/unix"
@@ -13,6 +15,8 @@ import (
 type lxcCmd struct {
 	attachedChildPid int
 	cmd              *exec.Cmd
+	status           *os.File
+	waitStatus       unix.WaitStatus
 }
 
 // PID returns the attached child's process ID.
@@ -35,6 +39,16 @@ func (c *lxcCmd) Signal(sig unix.Signal) error {
 func (c *lxcCmd) Wait() (int, error) {
 	exitStatus, err := linux.ExitStatus(c.cmd.Wait())
 
+	// The raw wait status of the command is reported by forkexec before it exits.
+	var status int32
+	readErr := binary.Read(c.status, binary.NativeEndian, &status)
+	_ = c.status.Close()
+	if readErr == nil {
+		c.waitStatus = unix.WaitStatus(status)
+	} else {
+		c.waitStatus = execWaitStatus(exitStatus, 0)
+	}
+
 	// Convert special exit statuses into errors.
 	switch exitStatus {
 	case 127:
@@ -46,6 +60,11 @@ func (c *lxcCmd) Wait() (int, error) {
 	return exitStatus, err
 }
 
+// WaitStatus returns the raw wait status of the command once it has ended.
+func (c *lxcCmd) WaitStatus() unix.WaitStatus {
+	return c.waitStatus
+}
+
 // WindowResize resizes the running command's window.
 func (c *lxcCmd) WindowResize(fd, winchWidth, winchHeight int) error {
 	err := linux.SetPtySize(fd, winchWidth, winchHeight)
@@ -56,3 +75,12 @@ func (c *lxcCmd) WindowResize(fd, winchWidth, winchHeight int) error {
 	logger.Debugf(`Set window size "%dx%d" of PID "%d"`, winchWidth, winchHeight, c.PID())
 	return nil
 }
+
+// execWaitStatus builds a wait status from the exit status of a command and the signal which terminated it (if any).
+func execWaitStatus(exitStatus int, signal int) unix.WaitStatus {
+	if signal > 0 {
+		return unix.WaitStatus(signal)
+	}
+
+	return unix.WaitStatus((exitStatus & 0xff) << 8)
+}
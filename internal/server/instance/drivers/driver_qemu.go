@@ -789,8 +789,8 @@ func (d *qemu) Restart(timeout time.Duration) error {
 }
 
 // Rebuild rebuilds the instance using the supplied image fingerprint as source.
-func (d *qemu) Rebuild(img *api.Image, op *operations.Operation) error {
-	return d.rebuildCommon(d, img, op)
+func (d *qemu) Rebuild(img *api.Image, resetIdentity bool, op *operations.Operation) error {
+	return d.rebuildCommon(d, img, resetIdentity, op)
 }
 
 func (d *qemu) ovmfPath() string {
@@ -5120,6 +5120,19 @@ func (d *qemu) Rename(newName string, applyTemplateTrigger bool) error {
 		}
 	}
 
+	// Rename the console recordings path.
+	if !d.IsSnapshot() {
+		newRecordingsPath := internalUtil.VarPath("console-recordings", project.Instance(d.Project().Name, newName))
+		_ = os.RemoveAll(newRecordingsPath)
+		if util.PathExists(d.ConsoleRecordingsPath()) {
+			err := os.Rename(d.ConsoleRecordingsPath(), newRecordingsPath)
+			if err != nil {
+				d.logger.Error("Failed renaming instance", ctxMap)
+				return err
+			}
+		}
+	}
+
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -5866,6 +5879,9 @@ func (d *qemu) cleanup() {
 
 	// Remove the shmounts path
 	_ = os.RemoveAll(d.ShmountsPath())
+
+	// Remove the console recordings
+	_ = os.RemoveAll(d.ConsoleRecordingsPath())
 }
 
 // cleanupDevices performs any needed device cleanup steps when instance is stopped.
@@ -7486,7 +7502,11 @@ func (d *qemu) Console(protocol string) (*os.File, chan error, error) {
 }
 
 // Exec a command inside the instance.
-func (d *qemu) Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, stderr *os.File) (instance.Cmd, error) {
+func (d *qemu) Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, stderr *os.File, extraFiles []*os.File) (instance.Cmd, error) {
+	if len(extraFiles) > 0 {
+		return nil, fmt.Errorf("Additional file descriptors aren't supported for virtual machines")
+	}
+
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -24,6 +24,7 @@ type qemuCmd struct {
 	controlSendCh    chan api.InstanceExecControl
 	controlResCh     chan error
 	cleanupFunc      func()
+	waitStatus       unix.WaitStatus
 }
 
 // PID returns the attached child's process ID.
@@ -66,6 +67,10 @@ func (c *qemuCmd) Wait() (int, error) {
 		if ok {
 			exitStatus = int(exitStatusRaw)
 
+			// Older agents don't report the signal which terminated the command.
+			signalRaw, _ := opAPI.Metadata["signal"].(float64)
+			c.waitStatus = execWaitStatus(exitStatus, int(signalRaw))
+
 			// Convert special exit statuses into errors.
 			switch exitStatus {
 			case 127:
@@ -96,6 +101,11 @@ func (c *qemuCmd) Wait() (int, error) {
 	return exitStatus, nil
 }
 
+// WaitStatus returns the raw wait status of the command once it has ended.
+func (c *qemuCmd) WaitStatus() unix.WaitStatus {
+	return c.waitStatus
+}
+
 // WindowResize resizes the running command's window.
 func (c *qemuCmd) WindowResize(fd, winchWidth, winchHeight int) error {
 	command := api.InstanceExecControl{
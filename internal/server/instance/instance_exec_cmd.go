@@ -7,6 +7,7 @@ import (
 // Cmd represents a local or remote command being run.
 type Cmd interface {
 	Wait() (int, error)
+	WaitStatus() unix.WaitStatus
 	PID() int
 	Signal(s unix.Signal) error
 	WindowResize(fd, winchWidth, winchHeight int) error
@@ -80,7 +80,7 @@ type Instance interface {
 	Start(stateful bool) error
 	Stop(stateful bool) error
 	Restart(timeout time.Duration) error
-	Rebuild(img *api.Image, op *operations.Operation) error
+	Rebuild(img *api.Image, resetIdentity bool, op *operations.Operation) error
 	Unfreeze() error
 	RegisterDevices()
 
@@ -111,7 +111,7 @@ type Instance interface {
 
 	// Console - Allocate and run a console tty or a spice Unix socket.
 	Console(protocol string) (*os.File, chan error, error)
-	Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, stderr *os.File) (Cmd, error)
+	Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, stderr *os.File, extraFiles []*os.File) (Cmd, error)
 
 	// Status
 	Render(options ...func(response any) error) (any, any, error)
@@ -150,6 +150,7 @@ type Instance interface {
 	StatePath() string
 	LogFilePath() string
 	ConsoleBufferLogPath() string
+	ConsoleRecordingsPath() string
 	LogPath() string
 	RunPath() string
 	DevicesPath() string
@@ -11,6 +11,8 @@ type ClusterMemberAction string
 // All supported lifecycle events for cluster members.
 const (
 	ClusterMemberAdded   = ClusterMemberAction(api.EventLifecycleClusterMemberAdded)
+	ClusterMemberOffline = ClusterMemberAction(api.EventLifecycleClusterMemberOffline)
+	ClusterMemberOnline  = ClusterMemberAction(api.EventLifecycleClusterMemberOnline)
 	ClusterMemberRemoved = ClusterMemberAction(api.EventLifecycleClusterMemberRemoved)
 	ClusterMemberUpdated = ClusterMemberAction(api.EventLifecycleClusterMemberUpdated)
 	ClusterMemberRenamed = ClusterMemberAction(api.EventLifecycleClusterMemberRenamed)
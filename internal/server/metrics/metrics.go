@@ -113,7 +113,7 @@ func (m *MetricSet) String() string {
 			metricTypeName = "gauge"
 		} else if strings.HasSuffix(MetricNames[metricType], "_total") || strings.HasSuffix(MetricNames[metricType], "_seconds") {
 			metricTypeName = "counter"
-		} else if strings.HasSuffix(MetricNames[metricType], "_bytes") {
+		} else if strings.HasSuffix(MetricNames[metricType], "_bytes") || strings.HasSuffix(MetricNames[metricType], "_percent") {
 			metricTypeName = "gauge"
 		}
 
@@ -56,3 +56,13 @@ func TestMetricSet_FilterSamples(t *testing.T) {
 		require.Contains(t, hasKeys, "project")
 	}
 }
+
+func TestMetricSet_StringPercentGauge(t *testing.T) {
+	m := NewMetricSet(map[string]string{"pool": "default"})
+	m.AddSamples(LVMThinpoolDataPercent, Sample{Value: 42.5})
+
+	out := m.String()
+
+	require.Contains(t, out, "# TYPE incus_storage_pool_lvm_data_percent gauge\n")
+	require.Contains(t, out, `incus_storage_pool_lvm_data_percent{pool="default"} 42.5`)
+}
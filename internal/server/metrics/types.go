@@ -144,6 +144,14 @@ const (
 	GoOtherSysBytes
 	// GoNextGCBytes represents the number of heap bytes when next garbage collection will take place.
 	GoNextGCBytes
+	// LVMThinpoolDataPercent represents the percentage of the LVM thinpool data space in use.
+	LVMThinpoolDataPercent
+	// LVMThinpoolMetadataPercent represents the percentage of the LVM thinpool metadata space in use.
+	LVMThinpoolMetadataPercent
+	// LVMThinpoolSizeBytes represents the size in bytes of the LVM thinpool.
+	LVMThinpoolSizeBytes
+	// LVMThinpoolUsedBytes represents the number of bytes in use in the LVM thinpool.
+	LVMThinpoolUsedBytes
 )
 
 // MetricNames associates a metric type to its name.
@@ -180,6 +188,10 @@ var MetricNames = map[MetricType]string{
 	GoStackInuseBytes:           "incus_go_stack_inuse_bytes",
 	GoStackSysBytes:             "incus_go_stack_sys_bytes",
 	GoSysBytes:                  "incus_go_sys_bytes",
+	LVMThinpoolDataPercent:      "incus_storage_pool_lvm_data_percent",
+	LVMThinpoolMetadataPercent:  "incus_storage_pool_lvm_metadata_percent",
+	LVMThinpoolSizeBytes:        "incus_storage_pool_lvm_thinpool_size_bytes",
+	LVMThinpoolUsedBytes:        "incus_storage_pool_lvm_thinpool_used_bytes",
 	MemoryActiveAnonBytes:       "incus_memory_Active_anon_bytes",
 	MemoryActiveFileBytes:       "incus_memory_Active_file_bytes",
 	MemoryActiveBytes:           "incus_memory_Active_bytes",
@@ -248,6 +260,10 @@ var MetricHeaders = map[MetricType]string{
 	GoStackInuseBytes:           "# HELP incus_go_stack_inuse_bytes Number of bytes in use by the stack allocator.",
 	GoStackSysBytes:             "# HELP incus_go_stack_sys_bytes Number of bytes obtained from system for stack allocator.",
 	GoSysBytes:                  "# HELP incus_go_sys_bytes Number of bytes obtained from system.",
+	LVMThinpoolDataPercent:      "# HELP incus_storage_pool_lvm_data_percent The percentage of the LVM thinpool data space in use.",
+	LVMThinpoolMetadataPercent:  "# HELP incus_storage_pool_lvm_metadata_percent The percentage of the LVM thinpool metadata space in use.",
+	LVMThinpoolSizeBytes:        "# HELP incus_storage_pool_lvm_thinpool_size_bytes The size of the LVM thinpool in bytes.",
+	LVMThinpoolUsedBytes:        "# HELP incus_storage_pool_lvm_thinpool_used_bytes The number of bytes in use in the LVM thinpool.",
 	MemoryActiveAnonBytes:       "# HELP incus_memory_Active_anon_bytes The amount of anonymous memory on active LRU list.",
 	MemoryActiveFileBytes:       "# HELP incus_memory_Active_file_bytes The amount of file-backed memory on active LRU list.",
 	MemoryActiveBytes:           "# HELP incus_memory_Active_bytes The amount of memory on active LRU list.",
@@ -1177,14 +1177,30 @@ func (n *common) loadBalancerValidate(listenAddress net.IP, forward *api.Network
 		}
 	}
 
-	// Look for any unknown config fields.
-	for k := range forward.Config {
+	// Validate config.
+	rules := map[string]func(value string) error{
+		"healthcheck":               validate.Optional(validate.IsBool),
+		"healthcheck.interval":      validate.Optional(validate.IsUint32),
+		"healthcheck.timeout":       validate.Optional(validate.IsUint32),
+		"healthcheck.success_count": validate.Optional(validate.IsUint32),
+		"healthcheck.failure_count": validate.Optional(validate.IsUint32),
+	}
+
+	for k, v := range forward.Config {
 		// User keys are not validated.
 		if internalInstance.IsUserConfig(k) {
 			continue
 		}
 
-		return nil, fmt.Errorf("Invalid option %q", k)
+		validator, found := rules[k]
+		if !found {
+			return nil, fmt.Errorf("Invalid option %q", k)
+		}
+
+		err := validator(v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for %q: %w", k, err)
+		}
 	}
 
 	// Validate port rules.
@@ -1322,6 +1338,11 @@ func (n *common) LoadBalancerDelete(listenAddress string, clientType request.Cli
 	return ErrNotImplemented
 }
 
+// LoadBalancerState returns ErrNotImplemented for drivers that do not support load balancers.
+func (n *common) LoadBalancerState(loadBalancer api.NetworkLoadBalancer) (*api.NetworkLoadBalancerState, error) {
+	return nil, ErrNotImplemented
+}
+
 // loadBalancerBGPSetupPrefixes exports external load balancer addresses as prefixes.
 func (n *common) loadBalancerBGPSetupPrefixes() error {
 	var listenAddresses map[int64]string
@@ -27,6 +27,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/dnsmasq/dhcpalloc"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/ip"
 	"github.com/lxc/incus/v6/internal/server/locking"
@@ -1912,7 +1913,7 @@ func (n *ovn) validateUplinkNetwork(p *api.Project, uplinkNetworkName string) (s
 
 // getDHCPv4Reservations returns list DHCP IPv4 reservations from NICs connected to this network.
 func (n *ovn) getDHCPv4Reservations() ([]iprange.Range, error) {
-	routerIntPortIPv4, _, err := n.parseRouterIntPortIPv4Net()
+	routerIntPortIPv4, routerIntPortIPv4Net, err := n.parseRouterIntPortIPv4Net()
 	if err != nil {
 		return nil, fmt.Errorf("Failed parsing router's internal port IPv4 Net for DHCP reservation: %w", err)
 	}
@@ -1923,6 +1924,11 @@ func (n *ovn) getDHCPv4Reservations() ([]iprange.Range, error) {
 		dhcpReserveIPv4s = []iprange.Range{{Start: routerIntPortIPv4}}
 	}
 
+	// Keep the source address of load balancer health checks away from the instances.
+	if routerIntPortIPv4Net != nil {
+		dhcpReserveIPv4s = append(dhcpReserveIPv4s, iprange.Range{Start: OVNLoadBalancerHealthCheckSource(routerIntPortIPv4Net)})
+	}
+
 	err = UsedByInstanceDevices(n.state, n.Project(), n.Name(), n.Type(), func(inst db.InstanceArgs, nicName string, nicConfig map[string]string) error {
 		ip := net.ParseIP(nicConfig["ipv4.address"])
 		if ip != nil {
@@ -3060,6 +3066,14 @@ func (n *ovn) Update(newNetwork api.NetworkPut, targetNode string, clientType re
 			return err
 		}
 
+		// Health checks are sent from an address of the network's subnets, so refresh them when those change.
+		if slices.Contains(changedKeys, "ipv4.address") || slices.Contains(changedKeys, "ipv6.address") {
+			err = n.loadBalancerReconcileHealthChecks()
+			if err != nil {
+				return fmt.Errorf("Failed reconciling load balancer health checks: %w", err)
+			}
+		}
+
 		// Work out which ACLs have been added and removed.
 		oldACLs := util.SplitNTrimSpace(oldNetwork.Config["security.acls"], ",", -1, true)
 		newACLs := util.SplitNTrimSpace(newNetwork.Config["security.acls"], ",", -1, true)
@@ -4939,6 +4953,93 @@ func (n *ovn) loadBalancerFlattenVIPs(listenAddress net.IP, portMaps []*loadBala
 	return vips
 }
 
+// OVNLoadBalancerHealthCheckSource returns the address of the subnet from which load balancer health checks are
+// sent, it's reserved so that it's never used by an instance.
+func OVNLoadBalancerHealthCheckSource(subnet *net.IPNet) net.IP {
+	return dhcpalloc.GetIP(subnet, -2)
+}
+
+// loadBalancerSetupHealthCheck enables health checking on the VIPs if requested by the load balancer config.
+func (n *ovn) loadBalancerSetupHealthCheck(vips []networkOVN.OVNLoadBalancerVIP, config map[string]string) error {
+	if util.IsFalseOrEmpty(config["healthcheck"]) {
+		return nil
+	}
+
+	if len(vips) == 0 {
+		return nil
+	}
+
+	// The checks are sent from the last address of the subnet matching the listen address family.
+	var routerIntPortNet *net.IPNet
+	var err error
+	if vips[0].ListenAddress.To4() != nil {
+		_, routerIntPortNet, err = n.parseRouterIntPortIPv4Net()
+		if err != nil {
+			return err
+		}
+
+		if routerIntPortNet == nil {
+			return fmt.Errorf("Health checks on IPv4 load balancers require the network to have an IPv4 subnet")
+		}
+	} else {
+		_, routerIntPortNet, err = n.parseRouterIntPortIPv6Net()
+		if err != nil {
+			return err
+		}
+
+		if routerIntPortNet == nil {
+			return fmt.Errorf("Health checks on IPv6 load balancers require the network to have an IPv6 subnet")
+		}
+	}
+
+	healthCheck := networkOVN.OVNLoadBalancerHealthCheck{
+		Interval:      5,
+		Timeout:       20,
+		SuccessCount:  3,
+		FailureCount:  3,
+		SourceAddress: OVNLoadBalancerHealthCheckSource(routerIntPortNet),
+	}
+
+	for k, v := range map[string]*uint64{
+		"healthcheck.interval":      &healthCheck.Interval,
+		"healthcheck.timeout":       &healthCheck.Timeout,
+		"healthcheck.success_count": &healthCheck.SuccessCount,
+		"healthcheck.failure_count": &healthCheck.FailureCount,
+	} {
+		if config[k] == "" {
+			continue
+		}
+
+		*v, err = strconv.ParseUint(config[k], 10, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid value for %q: %w", k, err)
+		}
+	}
+
+	// Find the logical switch ports of the targets so the checks can reach them.
+	portIPs, err := n.state.OVNNB.LogicalSwitchIPs(n.getIntSwitchName())
+	if err != nil {
+		return fmt.Errorf("Failed getting internal switch port IPs: %w", err)
+	}
+
+	ipPorts := make(map[string]networkOVN.OVNSwitchPort)
+	for portName, ips := range portIPs {
+		for _, ip := range ips {
+			ipPorts[ip.String()] = portName
+		}
+	}
+
+	for i := range vips {
+		vips[i].HealthCheck = &healthCheck
+
+		for j := range vips[i].Targets {
+			vips[i].Targets[j].LogicalPort = ipPorts[vips[i].Targets[j].Address.String()]
+		}
+	}
+
+	return nil
+}
+
 // LoadBalancerCreate creates a network load balancer.
 func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clientType request.ClientType) error {
 	revert := revert.New()
@@ -5054,6 +5155,11 @@ func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clie
 
 		vips := n.loadBalancerFlattenVIPs(net.ParseIP(loadBalancer.ListenAddress), portMaps)
 
+		err = n.loadBalancerSetupHealthCheck(vips, loadBalancer.Config)
+		if err != nil {
+			return err
+		}
+
 		err = n.state.OVNNB.LoadBalancerApply(n.getLoadBalancerName(loadBalancer.ListenAddress), []networkOVN.OVNRouter{n.getRouterName()}, []networkOVN.OVNSwitch{n.getIntSwitchName()}, vips...)
 		if err != nil {
 			return fmt.Errorf("Failed applying OVN load balancer: %w", err)
@@ -5106,6 +5212,59 @@ func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clie
 	return nil
 }
 
+// loadBalancerForwardingEqual returns whether two load balancers forward the same ports to the same backends.
+func loadBalancerForwardingEqual(a *api.NetworkLoadBalancerPut, b *api.NetworkLoadBalancerPut) bool {
+	if !slices.Equal(a.Backends, b.Backends) {
+		return false
+	}
+
+	return slices.EqualFunc(a.Ports, b.Ports, func(portA api.NetworkLoadBalancerPort, portB api.NetworkLoadBalancerPort) bool {
+		return portA.Protocol == portB.Protocol && portA.ListenPort == portB.ListenPort && slices.Equal(portA.TargetBackend, portB.TargetBackend)
+	})
+}
+
+// loadBalancerReconcileHealthChecks brings the health checks of all the network's load balancers in line with their
+// configuration, only changing what differs in OVN.
+func (n *ovn) loadBalancerReconcileHealthChecks() error {
+	memberSpecific := false // OVN doesn't support per-member load balancers.
+
+	var loadBalancers map[int64]*api.NetworkLoadBalancer
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		loadBalancers, err = tx.GetNetworkLoadBalancers(ctx, n.ID(), memberSpecific)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading network load balancers: %w", err)
+	}
+
+	lbVIPs := make(map[networkOVN.OVNLoadBalancer][]networkOVN.OVNLoadBalancerVIP, len(loadBalancers))
+	for _, loadBalancer := range loadBalancers {
+		portMaps, err := n.loadBalancerValidate(net.ParseIP(loadBalancer.ListenAddress), &loadBalancer.NetworkLoadBalancerPut)
+		if err != nil {
+			return err
+		}
+
+		vips := n.loadBalancerFlattenVIPs(net.ParseIP(loadBalancer.ListenAddress), portMaps)
+
+		err = n.loadBalancerSetupHealthCheck(vips, loadBalancer.Config)
+		if err != nil {
+			return err
+		}
+
+		lbVIPs[n.getLoadBalancerName(loadBalancer.ListenAddress)] = vips
+	}
+
+	if len(lbVIPs) == 0 {
+		return nil
+	}
+
+	return n.state.OVNNB.LoadBalancerReconcileHealthChecks(context.TODO(), lbVIPs)
+}
+
 // LoadBalancerUpdate updates a network load balancer.
 func (n *ovn) LoadBalancerUpdate(listenAddress string, req api.NetworkLoadBalancerPut, clientType request.ClientType) error {
 	revert := revert.New()
@@ -5154,19 +5313,46 @@ func (n *ovn) LoadBalancerUpdate(listenAddress string, req api.NetworkLoadBalanc
 
 		vips := n.loadBalancerFlattenVIPs(net.ParseIP(newLoadBalancer.ListenAddress), portMaps)
 
-		err = n.state.OVNNB.LoadBalancerApply(n.getLoadBalancerName(newLoadBalancer.ListenAddress), []networkOVN.OVNRouter{n.getRouterName()}, []networkOVN.OVNSwitch{n.getIntSwitchName()}, vips...)
+		err = n.loadBalancerSetupHealthCheck(vips, newLoadBalancer.Config)
 		if err != nil {
-			return fmt.Errorf("Failed applying OVN load balancer: %w", err)
+			return err
+		}
+
+		if loadBalancerForwardingEqual(&curLoadBalancer.NetworkLoadBalancerPut, &newLoadBalancer.NetworkLoadBalancerPut) {
+			// Only the configuration changed, so avoid recreating the load balancer and only update its health checks.
+			err = n.state.OVNNB.LoadBalancerReconcileHealthChecks(context.TODO(), map[networkOVN.OVNLoadBalancer][]networkOVN.OVNLoadBalancerVIP{n.getLoadBalancerName(newLoadBalancer.ListenAddress): vips})
+			if err != nil {
+				return fmt.Errorf("Failed reconciling OVN load balancer health checks: %w", err)
+			}
+		} else {
+			err = n.state.OVNNB.LoadBalancerApply(n.getLoadBalancerName(newLoadBalancer.ListenAddress), []networkOVN.OVNRouter{n.getRouterName()}, []networkOVN.OVNSwitch{n.getIntSwitchName()}, vips...)
+			if err != nil {
+				return fmt.Errorf("Failed applying OVN load balancer: %w", err)
+			}
 		}
 
 		revert.Add(func() {
 			// Apply old settings to OVN on failure.
 			portMaps, err := n.loadBalancerValidate(net.ParseIP(curLoadBalancer.ListenAddress), &curLoadBalancer.NetworkLoadBalancerPut)
-			if err == nil {
-				vips := n.loadBalancerFlattenVIPs(net.ParseIP(curLoadBalancer.ListenAddress), portMaps)
-				_ = n.state.OVNNB.LoadBalancerApply(n.getLoadBalancerName(curLoadBalancer.ListenAddress), []networkOVN.OVNRouter{n.getRouterName()}, []networkOVN.OVNSwitch{n.getIntSwitchName()}, vips...)
-				_ = n.forwardBGPSetupPrefixes()
+			if err != nil {
+				n.logger.Warn("Failed restoring OVN load balancer", logger.Ctx{"listenAddress": curLoadBalancer.ListenAddress, "err": err})
+				return
+			}
+
+			vips := n.loadBalancerFlattenVIPs(net.ParseIP(curLoadBalancer.ListenAddress), portMaps)
+
+			// Restore the load balancer without health checks rather than not at all.
+			err = n.loadBalancerSetupHealthCheck(vips, curLoadBalancer.Config)
+			if err != nil {
+				n.logger.Warn("Failed restoring OVN load balancer health checks", logger.Ctx{"listenAddress": curLoadBalancer.ListenAddress, "err": err})
+			}
+
+			err = n.state.OVNNB.LoadBalancerApply(n.getLoadBalancerName(curLoadBalancer.ListenAddress), []networkOVN.OVNRouter{n.getRouterName()}, []networkOVN.OVNSwitch{n.getIntSwitchName()}, vips...)
+			if err != nil {
+				n.logger.Warn("Failed restoring OVN load balancer", logger.Ctx{"listenAddress": curLoadBalancer.ListenAddress, "err": err})
 			}
+
+			_ = n.forwardBGPSetupPrefixes()
 		})
 
 		err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -5270,6 +5456,75 @@ func (n *ovn) LoadBalancerDelete(listenAddress string, clientType request.Client
 	return nil
 }
 
+// LoadBalancerState returns the health of the load balancer backends as reported by OVN.
+func (n *ovn) LoadBalancerState(loadBalancer api.NetworkLoadBalancer) (*api.NetworkLoadBalancerState, error) {
+	portMaps, err := n.loadBalancerValidate(net.ParseIP(loadBalancer.ListenAddress), &loadBalancer.NetworkLoadBalancerPut)
+	if err != nil {
+		return nil, err
+	}
+
+	vips := n.loadBalancerFlattenVIPs(net.ParseIP(loadBalancer.ListenAddress), portMaps)
+
+	// Find the logical ports of the backends, the health checks are tied to them.
+	err = n.loadBalancerSetupHealthCheck(vips, loadBalancer.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	healthCheck := util.IsTrue(loadBalancer.Config["healthcheck"])
+
+	backendHealth := make(map[string]api.NetworkLoadBalancerStateBackendHealth, len(loadBalancer.Backends))
+	for _, backend := range loadBalancer.Backends {
+		health := api.NetworkLoadBalancerStateBackendHealth{
+			Address: backend.TargetAddress,
+			Ports:   []api.NetworkLoadBalancerStateBackendHealthPort{},
+		}
+
+		// Track the target ports already seen as several listen ports may share one.
+		seenPorts := make(map[string]struct{})
+
+		for _, vip := range vips {
+			for _, target := range vip.Targets {
+				if target.Address.String() != backend.TargetAddress {
+					continue
+				}
+
+				portKey := fmt.Sprintf("%s/%d", vip.Protocol, target.Port)
+				_, found := seenPorts[portKey]
+				if found {
+					continue
+				}
+
+				seenPorts[portKey] = struct{}{}
+
+				port := api.NetworkLoadBalancerStateBackendHealthPort{
+					Protocol: vip.Protocol,
+					Port:     int(target.Port),
+					Status:   "unknown",
+				}
+
+				// Only health checked backends have a status in OVN.
+				if healthCheck && target.LogicalPort != "" {
+					status, err := n.state.OVNSB.GetServiceMonitorStatus(context.TODO(), target.LogicalPort, target.Address, vip.Protocol, target.Port)
+					if err != nil && err != networkOVN.ErrNotFound {
+						return nil, fmt.Errorf("Failed getting health of backend %q: %w", backend.Name, err)
+					}
+
+					if status != "" {
+						port.Status = status
+					}
+				}
+
+				health.Ports = append(health.Ports, port)
+			}
+		}
+
+		backendHealth[backend.Name] = health
+	}
+
+	return &api.NetworkLoadBalancerState{BackendHealth: backendHealth}, nil
+}
+
 // Leases returns a list of leases for the OVN network. Those are directly extracted from the OVN database.
 func (n *ovn) Leases(projectName string, clientType request.ClientType) ([]api.NetworkLease, error) {
 	var err error
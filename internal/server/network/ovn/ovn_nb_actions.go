@@ -3,7 +3,10 @@ package ovn
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -78,6 +81,7 @@ const ovnExtIDIncusSwitchPort = "incus_switch_port"
 const ovnExtIDIncusProjectID = "incus_project_id"
 const ovnExtIDIncusPortGroup = "incus_port_group"
 const ovnExtIDIncusLocation = "incus_location"
+const ovnExtIDIncusHealthCheck = "incus_health_check"
 
 // OVNIPv6RAOpts IPv6 router advertisements options that can be applied to a router.
 type OVNIPv6RAOpts struct {
@@ -139,8 +143,18 @@ type OVNACLRule struct {
 
 // OVNLoadBalancerTarget represents an OVN load balancer Virtual IP target.
 type OVNLoadBalancerTarget struct {
-	Address net.IP
-	Port    uint64
+	Address     net.IP
+	Port        uint64
+	LogicalPort OVNSwitchPort // Optional, if empty the target isn't health checked.
+}
+
+// OVNLoadBalancerHealthCheck represents the health check settings of an OVN load balancer Virtual IP.
+type OVNLoadBalancerHealthCheck struct {
+	Interval      uint64 // Seconds between two checks.
+	Timeout       uint64 // Seconds before a check is considered failed.
+	SuccessCount  uint64 // Number of successful checks before a target is considered online.
+	FailureCount  uint64 // Number of failed checks before a target is considered offline.
+	SourceAddress net.IP // Unused address on the target's subnet from which checks are sent.
 }
 
 // OVNLoadBalancerVIP represents a OVN load balancer Virtual IP entry.
@@ -149,6 +163,7 @@ type OVNLoadBalancerVIP struct {
 	ListenAddress net.IP
 	ListenPort    uint64
 	Targets       []OVNLoadBalancerTarget
+	HealthCheck   *OVNLoadBalancerHealthCheck // Optional, if set the targets are health checked.
 }
 
 // OVNRouterRoute represents a static route added to a logical router.
@@ -2172,7 +2187,339 @@ func (o *NB) LoadBalancerApply(loadBalancerName OVNLoadBalancer, routers []OVNRo
 		}
 	}
 
-	_, err := o.nbctl(args...)
+	// Add the health checks to the load balancers in the same transaction so they're never left without them.
+	healthChecks := newLoadBalancerHealthChecks()
+	err := healthChecks.add(loadBalancerName, vips)
+	if err != nil {
+		return err
+	}
+
+	args = healthChecks.appendArgs(args)
+
+	_, err = o.nbctl(args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// loadBalancerHealthChecks represents the desired health check state of a set of load balancers.
+// Health check rows are keyed by load balancer and VIP as several load balancers, attached to different
+// routers, may use the same VIP.
+type loadBalancerHealthChecks struct {
+	options      map[string]map[string]string // Health check options keyed by row key.
+	vips         map[string]string            // Health checked VIP keyed by row key.
+	lbRows       map[string][]string          // Health check row keys keyed by load balancer name.
+	portMappings map[string]map[string]string // Target port mappings keyed by load balancer name.
+}
+
+// newLoadBalancerHealthChecks returns an empty desired health check state.
+func newLoadBalancerHealthChecks() *loadBalancerHealthChecks {
+	return &loadBalancerHealthChecks{
+		options:      map[string]map[string]string{},
+		vips:         map[string]string{},
+		lbRows:       map[string][]string{},
+		portMappings: map[string]map[string]string{},
+	}
+}
+
+// loadBalancerHealthCheckKey returns the key identifying the health check row of the VIP of a load balancer.
+func loadBalancerHealthCheckKey(loadBalancerName OVNLoadBalancer, vip string) string {
+	return fmt.Sprintf("%s/%s", loadBalancerName, vip)
+}
+
+// add records the health checks of the VIPs of the specified load balancer.
+// Health checks with the same VIP are shared between its TCP and UDP load balancers.
+func (hc *loadBalancerHealthChecks) add(loadBalancerName OVNLoadBalancer, vips []OVNLoadBalancerVIP) error {
+	lbTCPName := fmt.Sprintf("%s-tcp", loadBalancerName)
+	lbUDPName := fmt.Sprintf("%s-udp", loadBalancerName)
+
+	for _, r := range vips {
+		if r.HealthCheck == nil {
+			continue
+		}
+
+		if r.ListenPort <= 0 {
+			return fmt.Errorf("Health checks require a listen port")
+		}
+
+		if r.HealthCheck.SourceAddress == nil {
+			return fmt.Errorf("Missing health check source address")
+		}
+
+		isIPv6 := r.ListenAddress.To4() == nil
+		if isIPv6 != (r.HealthCheck.SourceAddress.To4() == nil) {
+			return fmt.Errorf("Health check source address %q doesn't match the family of listen address %q", r.HealthCheck.SourceAddress.String(), r.ListenAddress.String())
+		}
+
+		lbName := lbTCPName
+		if r.Protocol == "udp" {
+			lbName = lbUDPName
+		}
+
+		// IPv6 VIPs are written in their bracketed form, e.g. "[2001:db8::1]:80".
+		vip := net.JoinHostPort(r.ListenAddress.String(), fmt.Sprintf("%d", r.ListenPort))
+		options := map[string]string{
+			"interval":      fmt.Sprintf("%d", r.HealthCheck.Interval),
+			"timeout":       fmt.Sprintf("%d", r.HealthCheck.Timeout),
+			"success_count": fmt.Sprintf("%d", r.HealthCheck.SuccessCount),
+			"failure_count": fmt.Sprintf("%d", r.HealthCheck.FailureCount),
+		}
+
+		key := loadBalancerHealthCheckKey(loadBalancerName, vip)
+
+		existingOptions, found := hc.options[key]
+		if found && !maps.Equal(existingOptions, options) {
+			return fmt.Errorf("Conflicting health check settings for VIP %q", vip)
+		}
+
+		hc.options[key] = options
+		hc.vips[key] = vip
+
+		if !slices.Contains(hc.lbRows[lbName], key) {
+			hc.lbRows[lbName] = append(hc.lbRows[lbName], key)
+		}
+
+		// Map the targets to the logical switch ports the checks are sent through.
+		if hc.portMappings[lbName] == nil {
+			hc.portMappings[lbName] = map[string]string{}
+		}
+
+		for _, target := range r.Targets {
+			if target.LogicalPort == "" {
+				continue
+			}
+
+			// OVN expects IPv6 addresses to be bracketed in the port mappings.
+			if isIPv6 {
+				hc.portMappings[lbName][fmt.Sprintf("[%s]", target.Address.String())] = fmt.Sprintf("%s:[%s]", target.LogicalPort, r.HealthCheck.SourceAddress.String())
+			} else {
+				hc.portMappings[lbName][target.Address.String()] = fmt.Sprintf("%s:%s", target.LogicalPort, r.HealthCheck.SourceAddress.String())
+			}
+		}
+	}
+
+	return nil
+}
+
+// appendArgs adds the commands creating the health check rows and attaching them to their newly created
+// load balancers to args. Any row previously used by the load balancers was garbage collected along with them,
+// so new rows are always created.
+func (hc *loadBalancerHealthChecks) appendArgs(args []string) []string {
+	keys := make([]string, 0, len(hc.options))
+	for key := range hc.options {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	rowIDs := make(map[string]string, len(keys))
+	for i, key := range keys {
+		rowIDs[key] = fmt.Sprintf("@health_check%d", i)
+
+		args = append(args, "--", fmt.Sprintf("--id=%s", rowIDs[key]), "create", "load_balancer_health_check",
+			fmt.Sprintf("vip=%s", strconv.Quote(hc.vips[key])),
+			fmt.Sprintf("external_ids:%s=%s", ovnExtIDIncusHealthCheck, strconv.Quote(key)),
+		)
+
+		optionKeys := make([]string, 0, len(hc.options[key]))
+		for k := range hc.options[key] {
+			optionKeys = append(optionKeys, k)
+		}
+
+		sort.Strings(optionKeys)
+
+		for _, k := range optionKeys {
+			args = append(args, fmt.Sprintf("options:%s=%s", k, strconv.Quote(hc.options[key][k])))
+		}
+	}
+
+	lbNames := make([]string, 0, len(hc.lbRows))
+	for lbName := range hc.lbRows {
+		lbNames = append(lbNames, lbName)
+	}
+
+	sort.Strings(lbNames)
+
+	for _, lbName := range lbNames {
+		for _, key := range hc.lbRows[lbName] {
+			args = append(args, "--", "add", "load_balancer", lbName, "health_check", rowIDs[key])
+		}
+
+		targets := make([]string, 0, len(hc.portMappings[lbName]))
+		for target := range hc.portMappings[lbName] {
+			targets = append(targets, target)
+		}
+
+		if len(targets) == 0 {
+			continue
+		}
+
+		sort.Strings(targets)
+
+		args = append(args, "--", "set", "load_balancer", lbName)
+		for _, target := range targets {
+			args = append(args, fmt.Sprintf("ip_port_mappings:%s=%s", strconv.Quote(target), strconv.Quote(hc.portMappings[lbName][target])))
+		}
+	}
+
+	return args
+}
+
+// rowOperations returns the operations needed to create or update the health check rows to their desired
+// state, along with the UUID (or named UUID) of each row.
+// The existing rows are the ones managed by Incus, keyed by the row key recorded in their external IDs.
+func (hc *loadBalancerHealthChecks) rowOperations(client ovsClient.Client, existing map[string]ovnNB.LoadBalancerHealthCheck) ([]ovsdb.Operation, map[string]string, error) {
+	operations := []ovsdb.Operation{}
+	healthCheckUUIDs := make(map[string]string, len(hc.options))
+
+	keys := make([]string, 0, len(hc.options))
+	for key := range hc.options {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for i, key := range keys {
+		options := hc.options[key]
+
+		healthCheck, found := existing[key]
+		if found {
+			healthCheckUUIDs[key] = healthCheck.UUID
+
+			// Only touch the existing row if its options changed.
+			if maps.Equal(healthCheck.Options, options) {
+				continue
+			}
+
+			healthCheck.Options = options
+			updateOps, err := client.Where(&healthCheck).Update(&healthCheck, &healthCheck.Options)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			operations = append(operations, updateOps...)
+			continue
+		}
+
+		healthCheck = ovnNB.LoadBalancerHealthCheck{
+			UUID:        fmt.Sprintf("health_check_%d", i),
+			Vip:         hc.vips[key],
+			Options:     options,
+			ExternalIDs: map[string]string{ovnExtIDIncusHealthCheck: key},
+		}
+
+		createOps, err := client.Create(&healthCheck)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		operations = append(operations, createOps...)
+		healthCheckUUIDs[key] = healthCheck.UUID
+	}
+
+	return operations, healthCheckUUIDs, nil
+}
+
+// LoadBalancerReconcileHealthChecks brings the health checks of the specified existing load balancers to the
+// state described by their VIPs, passing no VIPs removes all the health checks of a load balancer.
+//
+// Rather than recreating everything, health check rows are only created or changed when they differ from the
+// desired state. Rows are matched through the key recorded in their external IDs and rows no longer referenced
+// by any load balancer are garbage collected by OVN. The load balancer columns are set to their desired value
+// rather than adjusted from the cached state, so a stale cache can't leave extra health checks behind, and
+// references to rows which vanished in the meantime fail the whole transaction.
+func (o *NB) LoadBalancerReconcileHealthChecks(ctx context.Context, loadBalancers map[OVNLoadBalancer][]OVNLoadBalancerVIP) error {
+	desired := newLoadBalancerHealthChecks()
+	lbNames := make([]string, 0, len(loadBalancers)*2)
+
+	for loadBalancerName, vips := range loadBalancers {
+		err := desired.add(loadBalancerName, vips)
+		if err != nil {
+			return fmt.Errorf("Failed computing health checks of load balancer %q: %w", loadBalancerName, err)
+		}
+
+		lbNames = append(lbNames, fmt.Sprintf("%s-tcp", loadBalancerName), fmt.Sprintf("%s-udp", loadBalancerName))
+	}
+
+	sort.Strings(lbNames)
+
+	// Index the health check rows managed by Incus.
+	healthChecks := []ovnNB.LoadBalancerHealthCheck{}
+	err := o.client.WhereCache(func(healthCheck *ovnNB.LoadBalancerHealthCheck) bool {
+		return healthCheck.ExternalIDs[ovnExtIDIncusHealthCheck] != ""
+	}).List(ctx, &healthChecks)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]ovnNB.LoadBalancerHealthCheck, len(healthChecks))
+	for _, healthCheck := range healthChecks {
+		existing[healthCheck.ExternalIDs[ovnExtIDIncusHealthCheck]] = healthCheck
+	}
+
+	operations, healthCheckUUIDs, err := desired.rowOperations(o.client, existing)
+	if err != nil {
+		return err
+	}
+
+	for _, lbName := range lbNames {
+		lbs := []ovnNB.LoadBalancer{}
+		err := o.client.WhereCache(func(loadBalancer *ovnNB.LoadBalancer) bool {
+			return loadBalancer.Name == lbName
+		}).List(ctx, &lbs)
+		if err != nil {
+			return err
+		}
+
+		if len(lbs) == 0 {
+			// Load balancers are only created for the protocols in use.
+			if len(desired.lbRows[lbName]) > 0 {
+				return fmt.Errorf("Load balancer %q doesn't exist", lbName)
+			}
+
+			continue
+		}
+
+		wantUUIDs := make([]string, 0, len(desired.lbRows[lbName]))
+		for _, key := range desired.lbRows[lbName] {
+			wantUUIDs = append(wantUUIDs, healthCheckUUIDs[key])
+		}
+
+		wantPortMappings := desired.portMappings[lbName]
+		if wantPortMappings == nil {
+			wantPortMappings = map[string]string{}
+		}
+
+		loadBalancer := ovnNB.LoadBalancer{
+			HealthCheck:    wantUUIDs,
+			IPPortMappings: wantPortMappings,
+		}
+
+		updateOps, err := o.client.WhereAll(&loadBalancer, ovsModel.Condition{
+			Field:    &loadBalancer.Name,
+			Function: ovsdb.ConditionEqual,
+			Value:    lbName,
+		}).Update(&loadBalancer, &loadBalancer.HealthCheck, &loadBalancer.IPPortMappings)
+		if err != nil {
+			return err
+		}
+
+		operations = append(operations, updateOps...)
+	}
+
+	if len(operations) == 0 {
+		return nil // Nothing to change.
+	}
+
+	// Apply the changes.
+	resp, err := o.client.Transact(ctx, operations...)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,105 @@
+package ovn
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBalancerHealthChecksAdd(t *testing.T) {
+	healthCheck := &OVNLoadBalancerHealthCheck{Interval: 5, Timeout: 20, SuccessCount: 3, FailureCount: 3, SourceAddress: net.ParseIP("10.0.0.254")}
+
+	vip := func(protocol string, port uint64, target string, logicalPort OVNSwitchPort, hc *OVNLoadBalancerHealthCheck) OVNLoadBalancerVIP {
+		return OVNLoadBalancerVIP{
+			Protocol:      protocol,
+			ListenAddress: net.ParseIP("192.0.2.10"),
+			ListenPort:    port,
+			Targets:       []OVNLoadBalancerTarget{{Address: net.ParseIP(target), Port: port, LogicalPort: logicalPort}},
+			HealthCheck:   hc,
+		}
+	}
+
+	t.Run("Same VIP on different load balancers", func(t *testing.T) {
+		hc := newLoadBalancerHealthChecks()
+
+		require.NoError(t, hc.add("incus-net1-lb-192.0.2.10", []OVNLoadBalancerVIP{vip("tcp", 80, "10.0.0.1", "port1", healthCheck)}))
+
+		otherHealthCheck := *healthCheck
+		otherHealthCheck.Interval = 10
+		require.NoError(t, hc.add("incus-net2-lb-192.0.2.10", []OVNLoadBalancerVIP{vip("tcp", 80, "10.0.0.1", "port2", &otherHealthCheck)}))
+
+		assert.Len(t, hc.options, 2)
+		assert.Equal(t, []string{"incus-net1-lb-192.0.2.10/192.0.2.10:80"}, hc.lbRows["incus-net1-lb-192.0.2.10-tcp"])
+		assert.Equal(t, []string{"incus-net2-lb-192.0.2.10/192.0.2.10:80"}, hc.lbRows["incus-net2-lb-192.0.2.10-tcp"])
+		assert.Equal(t, "5", hc.options["incus-net1-lb-192.0.2.10/192.0.2.10:80"]["interval"])
+		assert.Equal(t, "10", hc.options["incus-net2-lb-192.0.2.10/192.0.2.10:80"]["interval"])
+		assert.Equal(t, "192.0.2.10:80", hc.vips["incus-net2-lb-192.0.2.10/192.0.2.10:80"])
+		assert.Equal(t, map[string]string{"10.0.0.1": "port1:10.0.0.254"}, hc.portMappings["incus-net1-lb-192.0.2.10-tcp"])
+		assert.Equal(t, map[string]string{"10.0.0.1": "port2:10.0.0.254"}, hc.portMappings["incus-net2-lb-192.0.2.10-tcp"])
+	})
+
+	t.Run("Shared between TCP and UDP", func(t *testing.T) {
+		hc := newLoadBalancerHealthChecks()
+
+		require.NoError(t, hc.add("lb", []OVNLoadBalancerVIP{vip("tcp", 53, "10.0.0.1", "port1", healthCheck), vip("udp", 53, "10.0.0.1", "port1", healthCheck)}))
+
+		assert.Len(t, hc.options, 1)
+		assert.Equal(t, []string{"lb/192.0.2.10:53"}, hc.lbRows["lb-tcp"])
+		assert.Equal(t, []string{"lb/192.0.2.10:53"}, hc.lbRows["lb-udp"])
+	})
+
+	t.Run("Conflicting settings on one load balancer", func(t *testing.T) {
+		hc := newLoadBalancerHealthChecks()
+
+		otherHealthCheck := *healthCheck
+		otherHealthCheck.Timeout = 30
+
+		err := hc.add("lb", []OVNLoadBalancerVIP{vip("tcp", 53, "10.0.0.1", "port1", healthCheck), vip("udp", 53, "10.0.0.1", "port1", &otherHealthCheck)})
+		assert.Error(t, err)
+	})
+
+	t.Run("VIPs without health check", func(t *testing.T) {
+		hc := newLoadBalancerHealthChecks()
+
+		require.NoError(t, hc.add("lb", []OVNLoadBalancerVIP{vip("tcp", 80, "10.0.0.1", "port1", nil)}))
+		assert.Empty(t, hc.options)
+		assert.Empty(t, hc.lbRows)
+	})
+
+	t.Run("Mismatching source address family", func(t *testing.T) {
+		hc := newLoadBalancerHealthChecks()
+
+		otherHealthCheck := *healthCheck
+		otherHealthCheck.SourceAddress = net.ParseIP("fd42::fffe")
+
+		err := hc.add("lb", []OVNLoadBalancerVIP{vip("tcp", 80, "10.0.0.1", "port1", &otherHealthCheck)})
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadBalancerHealthChecksAppendArgs(t *testing.T) {
+	healthCheck := &OVNLoadBalancerHealthCheck{Interval: 5, Timeout: 20, SuccessCount: 3, FailureCount: 3, SourceAddress: net.ParseIP("10.0.0.254")}
+
+	hc := newLoadBalancerHealthChecks()
+	require.NoError(t, hc.add("lb", []OVNLoadBalancerVIP{{
+		Protocol:      "tcp",
+		ListenAddress: net.ParseIP("192.0.2.10"),
+		ListenPort:    80,
+		Targets:       []OVNLoadBalancerTarget{{Address: net.ParseIP("10.0.0.1"), Port: 80, LogicalPort: "port1"}, {Address: net.ParseIP("10.0.0.2"), Port: 80}},
+		HealthCheck:   healthCheck,
+	}}))
+
+	args := hc.appendArgs([]string{"lb-add", "lb-tcp"})
+	assert.Equal(t, []string{
+		"lb-add", "lb-tcp",
+		"--", "--id=@health_check0", "create", "load_balancer_health_check", `vip="192.0.2.10:80"`, `external_ids:incus_health_check="lb/192.0.2.10:80"`,
+		`options:failure_count="3"`, `options:interval="5"`, `options:success_count="3"`, `options:timeout="20"`,
+		"--", "add", "load_balancer", "lb-tcp", "health_check", "@health_check0",
+		"--", "set", "load_balancer", "lb-tcp", `ip_port_mappings:"10.0.0.1"="port1:10.0.0.254"`,
+	}, args)
+
+	// Nothing is added without health checks.
+	assert.Equal(t, []string{"lb-add"}, newLoadBalancerHealthChecks().appendArgs([]string{"lb-add"}))
+}
@@ -3,6 +3,7 @@ package ovn
 import (
 	"context"
 	"fmt"
+	"net"
 
 	ovnSB "github.com/lxc/incus/v6/internal/server/network/ovn/schema/ovn-sb"
 )
@@ -35,3 +36,41 @@ func (o *SB) GetLogicalRouterPortActiveChassisHostname(ctx context.Context, ovnR
 
 	return chassis.Hostname, nil
 }
+
+// serviceMonitorMatches returns whether the service monitor checks the specified backend.
+// Backends are matched on their logical port too as the same address and port may be used on several networks.
+func serviceMonitorMatches(serviceMonitor *ovnSB.ServiceMonitor, logicalPort OVNSwitchPort, address net.IP, protocol string, port uint64) bool {
+	if serviceMonitor.LogicalPort != string(logicalPort) {
+		return false
+	}
+
+	if !address.Equal(net.ParseIP(serviceMonitor.IP)) || serviceMonitor.Port != int(port) {
+		return false
+	}
+
+	// OVN defaults to TCP when no protocol is set.
+	monitorProtocol := ovnSB.ServiceMonitorProtocolTCP
+	if serviceMonitor.Protocol != nil {
+		monitorProtocol = *serviceMonitor.Protocol
+	}
+
+	return monitorProtocol == protocol
+}
+
+// GetServiceMonitorStatus returns the status reported by OVN for the health check of the specified backend.
+func (o *SB) GetServiceMonitorStatus(ctx context.Context, logicalPort OVNSwitchPort, address net.IP, protocol string, port uint64) (string, error) {
+	serviceMonitors := []ovnSB.ServiceMonitor{}
+
+	err := o.client.WhereCache(func(serviceMonitor *ovnSB.ServiceMonitor) bool {
+		return serviceMonitorMatches(serviceMonitor, logicalPort, address, protocol, port)
+	}).List(ctx, &serviceMonitors)
+	if err != nil {
+		return "", err
+	}
+
+	if len(serviceMonitors) == 0 || serviceMonitors[0].Status == nil {
+		return "", ErrNotFound
+	}
+
+	return *serviceMonitors[0].Status, nil
+}
@@ -0,0 +1,51 @@
+package ovn
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ovnSB "github.com/lxc/incus/v6/internal/server/network/ovn/schema/ovn-sb"
+)
+
+func TestServiceMonitorMatches(t *testing.T) {
+	udp := ovnSB.ServiceMonitorProtocolUDP
+
+	serviceMonitor := &ovnSB.ServiceMonitor{
+		IP:          "10.0.0.10",
+		LogicalPort: "incus-net1-instance-1234-eth0",
+		Port:        80,
+	}
+
+	tests := []struct {
+		name        string
+		monitor     *ovnSB.ServiceMonitor
+		logicalPort OVNSwitchPort
+		address     string
+		protocol    string
+		port        uint64
+		match       bool
+	}{
+		{name: "Same backend", monitor: serviceMonitor, logicalPort: "incus-net1-instance-1234-eth0", address: "10.0.0.10", protocol: "tcp", port: 80, match: true},
+		{name: "Same address on another network", monitor: serviceMonitor, logicalPort: "incus-net2-instance-5678-eth0", address: "10.0.0.10", protocol: "tcp", port: 80, match: false},
+		{name: "Other address", monitor: serviceMonitor, logicalPort: "incus-net1-instance-1234-eth0", address: "10.0.0.11", protocol: "tcp", port: 80, match: false},
+		{name: "Other port", monitor: serviceMonitor, logicalPort: "incus-net1-instance-1234-eth0", address: "10.0.0.10", protocol: "tcp", port: 443, match: false},
+		{name: "Other protocol", monitor: serviceMonitor, logicalPort: "incus-net1-instance-1234-eth0", address: "10.0.0.10", protocol: "udp", port: 80, match: false},
+		{
+			name:        "Explicit protocol",
+			monitor:     &ovnSB.ServiceMonitor{IP: "fd42::10", LogicalPort: "incus-net1-instance-1234-eth0", Port: 53, Protocol: &udp},
+			logicalPort: "incus-net1-instance-1234-eth0",
+			address:     "fd42::10",
+			protocol:    "udp",
+			port:        53,
+			match:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.match, serviceMonitorMatches(test.monitor, test.logicalPort, net.ParseIP(test.address), test.protocol, test.port))
+		})
+	}
+}
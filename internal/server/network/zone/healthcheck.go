@@ -0,0 +1,289 @@
+package zone
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// healthCheckDefaultInterval is the default number of seconds between two health checks of an entry.
+const healthCheckDefaultInterval = 30
+
+// healthCheckTimeout is how long a single health check may take.
+const healthCheckTimeout = 5 * time.Second
+
+// healthCheckEnabled returns whether the entry is subject to the record's health check.
+// Only address entries can be health checked.
+func healthCheckEnabled(record api.NetworkZoneRecord, entry api.NetworkZoneRecordEntry) bool {
+	return record.Config["healthcheck.type"] != "" && (entry.Type == "A" || entry.Type == "AAAA")
+}
+
+// healthCheckValidateAddress checks that the address can be health checked.
+// Loopback, link-local, unspecified and multicast addresses are refused as probing them would reach the
+// servers themselves or their local networks rather than the record's backends.
+func healthCheckValidateAddress(address string) error {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return fmt.Errorf("Invalid IP address %q", address)
+	}
+
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("Address %q can't be health checked", address)
+	}
+
+	return nil
+}
+
+// healthyEntries returns the entries of the record which should be served, given the health check results
+// of its entries indexed by value.
+// Entries failing their health check are left out, unless all of them are failing in which case they
+// are all served, as withholding the whole record wouldn't help clients.
+func healthyEntries(record api.NetworkZoneRecord, results map[string]db.NetworkZoneRecordHealth) []api.NetworkZoneRecordEntry {
+	if record.Config["healthcheck.type"] == "" {
+		return record.Entries
+	}
+
+	entries := make([]api.NetworkZoneRecordEntry, 0, len(record.Entries))
+	checked := 0
+	for _, entry := range record.Entries {
+		if healthCheckEnabled(record, entry) {
+			checked++
+
+			// Entries which haven't been checked yet are considered healthy.
+			result, found := results[entry.Value]
+			if found && !result.Healthy {
+				continue
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if checked > 0 && len(entries) == len(record.Entries)-checked {
+		return record.Entries
+	}
+
+	return entries
+}
+
+// healthCheckEntry runs the record's health check against the entry's address.
+func healthCheckEntry(ctx context.Context, config map[string]string, address string) error {
+	err := healthCheckValidateAddress(address)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	hostPort := net.JoinHostPort(address, config["healthcheck.port"])
+
+	switch config["healthcheck.type"] {
+	case "tcp":
+		var dialer net.Dialer
+
+		conn, err := dialer.DialContext(ctx, "tcp", hostPort)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	case "http":
+		path := config["healthcheck.path"]
+		if path == "" {
+			path = "/"
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s%s", hostPort, path), nil)
+		if err != nil {
+			return err
+		}
+
+		client := &http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		_ = resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return fmt.Errorf("Unexpected status code %d", resp.StatusCode)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("Unsupported health check type %q", config["healthcheck.type"])
+}
+
+// HealthCheck runs the due health checks of all network zone records and stores their results.
+// It's only meant to be run by the cluster leader, the results being shared through the database.
+func HealthCheck(ctx context.Context, s *state.State) error {
+	var zoneNames map[string]string
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		zoneNames, err = tx.GetNetworkZones(ctx)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading network zones: %w", err)
+	}
+
+	type check struct {
+		zoneID     int64
+		zoneName   string
+		recordName string
+		config     map[string]string
+		address    string
+		previous   *db.NetworkZoneRecordHealth
+		err        error
+	}
+
+	type staleResult struct {
+		zoneID     int64
+		recordName string
+		value      string
+	}
+
+	now := time.Now()
+	checks := []*check{}
+	stale := []staleResult{}
+
+	for zoneName := range zoneNames {
+		// Don't let a broken zone prevent checking the records of the others.
+		zone, err := LoadByName(s, zoneName)
+		if err != nil {
+			logger.Warn("Failed loading network zone for health checks", logger.Ctx{"zone": zoneName, "err": err})
+			continue
+		}
+
+		records, err := zone.GetRecords()
+		if err != nil {
+			logger.Warn("Failed loading network zone records for health checks", logger.Ctx{"zone": zoneName, "err": err})
+			continue
+		}
+
+		var results map[string]map[string]db.NetworkZoneRecordHealth
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			results, err = tx.GetNetworkZoneRecordsHealth(ctx, zone.ID())
+
+			return err
+		})
+		if err != nil {
+			logger.Warn("Failed loading network zone health check results", logger.Ctx{"zone": zoneName, "err": err})
+			continue
+		}
+
+		current := map[string]map[string]bool{}
+
+		for _, record := range records {
+			interval := time.Duration(healthCheckDefaultInterval) * time.Second
+			if record.Config["healthcheck.interval"] != "" {
+				seconds, err := strconv.ParseUint(record.Config["healthcheck.interval"], 10, 32)
+				if err == nil {
+					interval = time.Duration(seconds) * time.Second
+				}
+			}
+
+			current[record.Name] = map[string]bool{}
+
+			for _, entry := range record.Entries {
+				if !healthCheckEnabled(record, entry) {
+					continue
+				}
+
+				err := healthCheckValidateAddress(entry.Value)
+				if err != nil {
+					logger.Warn("Skipping health check of network zone record entry", logger.Ctx{"zone": zoneName, "record": record.Name, "err": err})
+					continue
+				}
+
+				current[record.Name][entry.Value] = true
+
+				result, found := results[record.Name][entry.Value]
+				if found && now.Sub(result.LastCheck) < interval {
+					continue
+				}
+
+				c := &check{zoneID: zone.ID(), zoneName: zoneName, recordName: record.Name, config: record.Config, address: entry.Value}
+				if found {
+					c.previous = &result
+				}
+
+				checks = append(checks, c)
+			}
+		}
+
+		// Forget about entries which are no longer health checked.
+		for recordName, values := range results {
+			for value := range values {
+				if !current[recordName][value] {
+					stale = append(stale, staleResult{zoneID: zone.ID(), recordName: recordName, value: value})
+				}
+			}
+		}
+	}
+
+	// Run the checks in parallel so that unresponsive backends don't delay the others.
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c *check) {
+			defer wg.Done()
+
+			c.err = healthCheckEntry(ctx, c.config, c.address)
+		}(c)
+	}
+
+	wg.Wait()
+
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		for _, c := range checks {
+			err := tx.SetNetworkZoneRecordHealth(ctx, c.zoneID, c.recordName, c.address, db.NetworkZoneRecordHealth{Healthy: c.err == nil, LastCheck: now})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, r := range stale {
+			err := tx.DeleteNetworkZoneRecordHealth(ctx, r.zoneID, r.recordName, r.value)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed storing health check results: %w", err)
+	}
+
+	for _, c := range checks {
+		entry := c.zoneName + "/" + c.recordName + "/" + c.address
+		if c.err != nil && (c.previous == nil || c.previous.Healthy) {
+			logger.Warn("Network zone record entry failed its health check", logger.Ctx{"entry": entry, "err": c.err})
+		} else if c.err == nil && c.previous != nil && !c.previous.Healthy {
+			logger.Info("Network zone record entry passed its health check", logger.Ctx{"entry": entry})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package zone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestHealthyEntries(t *testing.T) {
+	entries := []api.NetworkZoneRecordEntry{
+		{Type: "A", Value: "192.0.2.1"},
+		{Type: "A", Value: "192.0.2.2"},
+		{Type: "TXT", Value: "hello"},
+	}
+
+	tests := []struct {
+		name     string
+		config   map[string]string
+		results  map[string]db.NetworkZoneRecordHealth
+		expected []string
+	}{
+		{
+			name:     "Health check disabled",
+			config:   map[string]string{},
+			results:  map[string]db.NetworkZoneRecordHealth{"192.0.2.1": {Healthy: false}},
+			expected: []string{"192.0.2.1", "192.0.2.2", "hello"},
+		},
+		{
+			name:     "Unchecked entries are served",
+			config:   map[string]string{"healthcheck.type": "tcp"},
+			results:  nil,
+			expected: []string{"192.0.2.1", "192.0.2.2", "hello"},
+		},
+		{
+			name:     "Failing entry is left out",
+			config:   map[string]string{"healthcheck.type": "tcp"},
+			results:  map[string]db.NetworkZoneRecordHealth{"192.0.2.1": {Healthy: false}, "192.0.2.2": {Healthy: true}},
+			expected: []string{"192.0.2.2", "hello"},
+		},
+		{
+			name:     "All entries failing are served",
+			config:   map[string]string{"healthcheck.type": "http"},
+			results:  map[string]db.NetworkZoneRecordHealth{"192.0.2.1": {Healthy: false}, "192.0.2.2": {Healthy: false}},
+			expected: []string{"192.0.2.1", "192.0.2.2", "hello"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record := api.NetworkZoneRecord{
+				Name:                 "www",
+				NetworkZoneRecordPut: api.NetworkZoneRecordPut{Config: test.config, Entries: entries},
+			}
+
+			values := []string{}
+			for _, entry := range healthyEntries(record, test.results) {
+				values = append(values, entry.Value)
+			}
+
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestHealthCheckValidateAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		valid   bool
+	}{
+		{address: "192.0.2.1", valid: true},
+		{address: "2001:db8::1", valid: true},
+		{address: "127.0.0.1", valid: false},
+		{address: "::1", valid: false},
+		{address: "169.254.1.1", valid: false},
+		{address: "fe80::1", valid: false},
+		{address: "0.0.0.0", valid: false},
+		{address: "224.0.0.1", valid: false},
+		{address: "not-an-ip", valid: false},
+	}
+
+	for _, test := range tests {
+		err := healthCheckValidateAddress(test.address)
+		if test.valid {
+			assert.NoError(t, err, test.address)
+		} else {
+			assert.Error(t, err, test.address)
+		}
+	}
+}
+
+func TestValidateRecordConfig_HealthCheck(t *testing.T) {
+	d := &zone{}
+
+	tests := []struct {
+		name    string
+		config  map[string]string
+		entries []api.NetworkZoneRecordEntry
+		valid   bool
+	}{
+		{
+			name:    "TCP check",
+			config:  map[string]string{"healthcheck.type": "tcp", "healthcheck.port": "443"},
+			entries: []api.NetworkZoneRecordEntry{{Type: "A", Value: "192.0.2.1"}},
+			valid:   true,
+		},
+		{
+			name:    "HTTP check with path",
+			config:  map[string]string{"healthcheck.type": "http", "healthcheck.port": "80", "healthcheck.path": "/health", "healthcheck.interval": "60"},
+			entries: []api.NetworkZoneRecordEntry{{Type: "AAAA", Value: "2001:db8::1"}},
+			valid:   true,
+		},
+		{
+			name:   "Unknown type",
+			config: map[string]string{"healthcheck.type": "icmp", "healthcheck.port": "80"},
+			valid:  false,
+		},
+		{
+			name:   "Missing port",
+			config: map[string]string{"healthcheck.type": "tcp"},
+			valid:  false,
+		},
+		{
+			name:   "Path on a TCP check",
+			config: map[string]string{"healthcheck.type": "tcp", "healthcheck.port": "80", "healthcheck.path": "/"},
+			valid:  false,
+		},
+		{
+			name:   "Relative path",
+			config: map[string]string{"healthcheck.type": "http", "healthcheck.port": "80", "healthcheck.path": "health"},
+			valid:  false,
+		},
+		{
+			name:   "Interval too short",
+			config: map[string]string{"healthcheck.type": "tcp", "healthcheck.port": "80", "healthcheck.interval": "1"},
+			valid:  false,
+		},
+		{
+			name:    "Loopback entry",
+			config:  map[string]string{"healthcheck.type": "tcp", "healthcheck.port": "22"},
+			entries: []api.NetworkZoneRecordEntry{{Type: "A", Value: "127.0.0.1"}},
+			valid:   false,
+		},
+		{
+			name:    "Loopback entry without health check",
+			config:  map[string]string{},
+			entries: []api.NetworkZoneRecordEntry{{Type: "A", Value: "127.0.0.1"}},
+			valid:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := d.validateRecordConfig(api.NetworkZoneRecordPut{Config: test.config, Entries: test.entries})
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -4,17 +4,24 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/miekg/dns"
 
 	"github.com/lxc/incus/v6/internal/server/cluster/request"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/validate"
 )
 
 func (d *zone) AddRecord(req api.NetworkZoneRecordsPost) error {
 	// Validate.
-	err := d.validateRecordConfig(req.NetworkZoneRecordPut)
+	err := d.validateRecordName(req.Name)
+	if err != nil {
+		return err
+	}
+
+	err = d.validateRecordConfig(req.NetworkZoneRecordPut)
 	if err != nil {
 		return err
 	}
@@ -153,15 +160,83 @@ func (d *zone) DeleteRecord(name string) error {
 	return nil
 }
 
+// validateRecordName checks the record name is valid.
+// A wildcard record is a record whose leftmost label is "*", it then applies to all the names below its
+// parent which don't have any record of their own.
+func (d *zone) validateRecordName(name string) error {
+	if name == "" {
+		return fmt.Errorf("Record name cannot be empty")
+	}
+
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if label == "" {
+			return fmt.Errorf("Record name %q has an empty label", name)
+		}
+
+		if !strings.Contains(label, "*") {
+			continue
+		}
+
+		if label != "*" {
+			return fmt.Errorf("Record name %q has a label partially made of a wildcard", name)
+		}
+
+		if i != 0 {
+			return fmt.Errorf("Record name %q has a wildcard which isn't its leftmost label", name)
+		}
+	}
+
+	_, ok := dns.IsDomainName(name + "." + d.info.Name + ".")
+	if !ok {
+		return fmt.Errorf("Record name %q isn't a valid DNS name", name)
+	}
+
+	return nil
+}
+
 // validateRecordConfig checks the config and rules are valid.
 func (d *zone) validateRecordConfig(info api.NetworkZoneRecordPut) error {
 	rules := map[string]func(value string) error{}
 
+	// Health check keys.
+	rules["healthcheck.type"] = validate.Optional(validate.IsOneOf("tcp", "http"))
+	rules["healthcheck.port"] = validate.Optional(validate.IsNetworkPort)
+	rules["healthcheck.path"] = validate.Optional(validate.IsAny)
+	rules["healthcheck.interval"] = validate.Optional(validate.IsInRange(10, 86400))
+
 	err := d.validateConfigMap(info.Config, rules)
 	if err != nil {
 		return err
 	}
 
+	if info.Config["healthcheck.type"] != "" && info.Config["healthcheck.port"] == "" {
+		return fmt.Errorf("The %q option is required when %q is set", "healthcheck.port", "healthcheck.type")
+	}
+
+	if info.Config["healthcheck.path"] != "" {
+		if info.Config["healthcheck.type"] != "http" {
+			return fmt.Errorf("The %q option can only be set for %q health checks", "healthcheck.path", "http")
+		}
+
+		if !strings.HasPrefix(info.Config["healthcheck.path"], "/") {
+			return fmt.Errorf("The %q option must be an absolute path", "healthcheck.path")
+		}
+	}
+
+	if info.Config["healthcheck.type"] != "" {
+		for _, entry := range info.Entries {
+			if entry.Type != "A" && entry.Type != "AAAA" {
+				continue
+			}
+
+			err := healthCheckValidateAddress(entry.Value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
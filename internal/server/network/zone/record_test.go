@@ -0,0 +1,42 @@
+package zone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestValidateRecordName(t *testing.T) {
+	d := &zone{info: &api.NetworkZone{Name: "example.net"}}
+
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "www", valid: true},
+		{name: "www.foo", valid: true},
+		{name: "*", valid: true},
+		{name: "*.foo", valid: true},
+		{name: "*.foo.bar", valid: true},
+		{name: "", valid: false},
+		{name: "www..foo", valid: false},
+		{name: "www.", valid: false},
+		{name: "*foo", valid: false},
+		{name: "foo*.bar", valid: false},
+		{name: "**.foo", valid: false},
+		{name: "foo.*", valid: false},
+		{name: "foo.*.bar", valid: false},
+		{name: "*.*.foo", valid: false},
+	}
+
+	for _, test := range tests {
+		err := d.validateRecordName(test.name)
+		if test.valid {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.Error(t, err, test.name)
+		}
+	}
+}
@@ -118,6 +118,27 @@ func (d *zone) usedBy(firstOnly bool) ([]string, error) {
 			}
 		}
 
+		// Find forward zones generating records in this zone.
+		zoneNames, err := tx.GetNetworkZonesByProject(ctx, d.projectName)
+		if err != nil {
+			return fmt.Errorf("Failed loading network zones for project %q: %w", d.projectName, err)
+		}
+
+		for _, zoneName := range zoneNames {
+			_, zoneInfo, err := tx.GetNetworkZoneByProject(ctx, d.projectName, zoneName)
+			if err != nil {
+				return fmt.Errorf("Failed to get network zone config for %q: %w", zoneName, err)
+			}
+
+			if zoneInfo.Config["reverse.ipv4"] == d.info.Name || zoneInfo.Config["reverse.ipv6"] == d.info.Name {
+				u := api.NewURL().Path(version.APIVersion, "network-zones", zoneName)
+				usedBy = append(usedBy, u.String())
+				if firstOnly {
+					return nil
+				}
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -167,6 +188,8 @@ func (d *zone) validateConfig(info *api.NetworkZonePut) error {
 	// Regular config keys.
 	rules["dns.nameservers"] = validate.IsListOf(validate.IsAny)
 	rules["network.nat"] = validate.Optional(validate.IsBool)
+	rules["reverse.ipv4"] = validate.Optional(d.validateReverseZone(ip4Arpa))
+	rules["reverse.ipv6"] = validate.Optional(d.validateReverseZone(ip6Arpa))
 
 	// Validate peer config.
 	for k := range info.Config {
@@ -199,6 +222,27 @@ func (d *zone) validateConfig(info *api.NetworkZonePut) error {
 	return nil
 }
 
+// validateReverseZone returns a validator checking that the value is the name of a reverse zone with the
+// given suffix in the zone's project.
+func (d *zone) validateReverseZone(suffix string) func(value string) error {
+	return func(value string) error {
+		if !strings.HasSuffix(value, suffix) {
+			return fmt.Errorf("Zone %q isn't a %q reverse zone", value, strings.TrimPrefix(suffix, "."))
+		}
+
+		if value == d.info.Name {
+			return fmt.Errorf("A zone cannot generate records for itself")
+		}
+
+		_, err := LoadByNameAndProject(d.state, d.projectName, value)
+		if err != nil {
+			return fmt.Errorf("Failed loading reverse zone %q: %w", value, err)
+		}
+
+		return nil
+	}
+}
+
 // validateConfigMap checks zone config map against rules.
 func (d *zone) validateConfigMap(config map[string]string, rules map[string]func(value string) error) error {
 	checkedFields := map[string]struct{}{}
@@ -462,14 +506,42 @@ func (d *zone) Content() (*strings.Builder, error) {
 		}
 	}
 
+	// Add the PTR records of the forward zones linked to this reverse zone.
+	isReverse := strings.HasSuffix(d.info.Name, ip4Arpa) || strings.HasSuffix(d.info.Name, ip6Arpa)
+	if isReverse {
+		for forwardZoneName, forwardZoneProjectName := range zoneProjects {
+			if forwardZoneProjectName != d.projectName {
+				continue
+			}
+
+			forwardRecords, err := d.forwardZoneRecords(forwardZoneName)
+			if err != nil {
+				return nil, err
+			}
+
+			records = append(records, forwardRecords...)
+		}
+	}
+
 	// Add the extra records.
 	extraRecords, err := d.GetRecords()
 	if err != nil {
 		return nil, err
 	}
 
+	var health map[string]map[string]db.NetworkZoneRecordHealth
+	err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		health, err = tx.GetNetworkZoneRecordsHealth(ctx, d.id)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	for _, extraRecord := range extraRecords {
-		for _, entry := range extraRecord.Entries {
+		// Leave out the entries failing their health check.
+		for _, entry := range healthyEntries(extraRecord, health[extraRecord.Name]) {
 			record := map[string]string{}
 			if entry.TTL > 0 {
 				record["ttl"] = fmt.Sprintf("%d", entry.TTL)
@@ -517,6 +589,72 @@ func (d *zone) Content() (*strings.Builder, error) {
 	return sb, nil
 }
 
+// forwardZoneRecords returns the PTR records matching the A and AAAA records of the forward zone if it is
+// linked to this reverse zone.
+func (d *zone) forwardZoneRecords(forwardZoneName string) ([]map[string]string, error) {
+	records := []map[string]string{}
+
+	if strings.HasSuffix(forwardZoneName, ip4Arpa) || strings.HasSuffix(forwardZoneName, ip6Arpa) {
+		return records, nil
+	}
+
+	forwardZone, err := LoadByNameAndProject(d.state, d.projectName, forwardZoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	forwardZoneConfig := forwardZone.Info().Config
+	if forwardZoneConfig["reverse.ipv4"] != d.info.Name && forwardZoneConfig["reverse.ipv6"] != d.info.Name {
+		return records, nil
+	}
+
+	forwardRecords, err := forwardZone.GetRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	return reverseRecords(d.info.Name, forwardZoneName, forwardRecords), nil
+}
+
+// reverseRecords returns the PTR records of the reverse zone matching the A and AAAA records of the forward zone.
+func reverseRecords(reverseZoneName string, forwardZoneName string, forwardRecords []api.NetworkZoneRecord) []map[string]string {
+	records := []map[string]string{}
+
+	for _, forwardRecord := range forwardRecords {
+		// Wildcard records don't match a single name.
+		if strings.HasPrefix(forwardRecord.Name, "*") {
+			continue
+		}
+
+		for _, entry := range forwardRecord.Entries {
+			if !strings.EqualFold(entry.Type, "A") && !strings.EqualFold(entry.Type, "AAAA") {
+				continue
+			}
+
+			// Only keep the addresses which belong to this zone.
+			reverseAddr := reverse(net.ParseIP(entry.Value))
+			if !strings.HasSuffix(reverseAddr, "."+reverseZoneName+".") {
+				continue
+			}
+
+			record := map[string]string{}
+			if entry.TTL > 0 {
+				record["ttl"] = fmt.Sprintf("%d", entry.TTL)
+			} else {
+				record["ttl"] = "300"
+			}
+
+			record["type"] = "PTR"
+			record["name"] = strings.TrimSuffix(reverseAddr, "."+reverseZoneName+".")
+			record["value"] = forwardRecord.Name + "." + forwardZoneName + "."
+
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
 // SOA returns just the DNS zone SOA record.
 func (d *zone) SOA() (*strings.Builder, error) {
 	// Get the nameservers.
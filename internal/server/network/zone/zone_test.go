@@ -0,0 +1,59 @@
+package zone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestReverseRecords(t *testing.T) {
+	newRecord := func(name string, entries ...api.NetworkZoneRecordEntry) api.NetworkZoneRecord {
+		return api.NetworkZoneRecord{Name: name, NetworkZoneRecordPut: api.NetworkZoneRecordPut{Entries: entries}}
+	}
+
+	forwardRecords := []api.NetworkZoneRecord{
+		newRecord("www",
+			api.NetworkZoneRecordEntry{Type: "A", Value: "192.0.2.10"},
+			api.NetworkZoneRecordEntry{Type: "AAAA", TTL: 3600, Value: "2001:db8::10"},
+			api.NetworkZoneRecordEntry{Type: "TXT", Value: "192.0.2.11"}),
+		newRecord("mail",
+			api.NetworkZoneRecordEntry{Type: "a", TTL: 60, Value: "192.0.2.20"},
+			api.NetworkZoneRecordEntry{Type: "A", Value: "198.51.100.20"}),
+		newRecord("*", api.NetworkZoneRecordEntry{Type: "A", Value: "192.0.2.30"}),
+	}
+
+	tests := []struct {
+		name    string
+		zone    string
+		records []map[string]string
+	}{
+		{
+			name: "IPv4 zone",
+			zone: "2.0.192.in-addr.arpa",
+			records: []map[string]string{
+				{"ttl": "300", "type": "PTR", "name": "10", "value": "www.example.net."},
+				{"ttl": "60", "type": "PTR", "name": "20", "value": "mail.example.net."},
+			},
+		},
+		{
+			name: "IPv6 zone",
+			zone: "8.b.d.0.1.0.0.2.ip6.arpa",
+			records: []map[string]string{
+				{"ttl": "3600", "type": "PTR", "name": "0.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0", "value": "www.example.net."},
+			},
+		},
+		{
+			name:    "No matching address",
+			zone:    "113.0.203.in-addr.arpa",
+			records: []map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.records, reverseRecords(test.zone, "example.net", forwardRecords))
+		})
+	}
+}
@@ -100,6 +100,11 @@ func (c *Config) StorageBucketsAddress() string {
 	return objectAddress
 }
 
+// ImagesPostDownloadHook returns the path to the executable to run after an image is downloaded.
+func (c *Config) ImagesPostDownloadHook() string {
+	return c.m.GetString("images.post_download_hook")
+}
+
 // StorageBackupsVolume returns the name of the pool/volume to use for storing backup tarballs.
 func (c *Config) StorageBackupsVolume() string {
 	return c.m.GetString("storage.backups_volume")
@@ -243,6 +248,23 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Whether to enable the syslog unixgram socket listener
 	"core.syslog_socket": {Validator: validate.Optional(validate.IsBool), Type: config.Bool},
 
+	// Hook to run on downloaded images
+
+	// gendoc:generate(entity=server, group=images, key=images.post_download_hook)
+	// Path to an executable run after an image was downloaded from a remote image server.
+	// It's given the path to the metadata file and, for split images, to the root file system file as arguments.
+	// The image fingerprint, type, architecture and source server are passed through the
+	// `INCUS_IMAGE_FINGERPRINT`, `INCUS_IMAGE_TYPE`, `INCUS_IMAGE_ARCHITECTURE` and `INCUS_IMAGE_SERVER` environment variables.
+	// A non-zero exit status, or not completing within 5 minutes, rejects the image.
+	// Any `KEY=VALUE` line printed on standard output is added to the image properties, unless the image already has that property.
+	// The hook runs as root with the privileges of the daemon on data from the image server, so it must be owned by root
+	// and not writable by other users, and it shouldn't trust the content of the image.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: Executable to run on downloaded images
+	"images.post_download_hook": {Validator: validate.Optional(validate.IsAbsFilePath)},
+
 	// Storage volumes to store backups/images on
 
 	// gendoc:generate(entity=server, group=miscellaneous, key=storage.backups_volume)
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"go.starlark.net/starlark"
 
+	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -23,7 +26,8 @@ import (
 )
 
 // InstancePlacementRun runs the instance placement scriptlet and returns the chosen cluster member target.
-func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State, req *apiScriptlet.InstancePlacement, candidateMembers []db.NodeInfo, leaderAddress string) (*db.NodeInfo, error) {
+// The optional memberResources are used instead of querying the members when the scriptlet asks for their resources.
+func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State, req *apiScriptlet.InstancePlacement, candidateMembers []db.NodeInfo, memberResources map[string]*api.Resources, leaderAddress string) (*db.NodeInfo, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -85,7 +89,16 @@ func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State,
 			return nil, err
 		}
 
-		var res *api.Resources
+		// Use the resources gathered ahead of running the scriptlet if available.
+		res, found := memberResources[memberName]
+		if found {
+			rv, err := StarlarkMarshal(res)
+			if err != nil {
+				return nil, fmt.Errorf("Marshalling member resources for %q failed: %w", memberName, err)
+			}
+
+			return rv, nil
+		}
 
 		// Get the local resource usage.
 		if memberName == s.ServerName {
@@ -362,3 +375,81 @@ func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State,
 
 	return targetMember, nil
 }
+
+// instancePlacementResourcesTimeout is how long to wait for each cluster member's resources.
+const instancePlacementResourcesTimeout = 5 * time.Second
+
+// InstancePlacementResources gathers the resources of the candidate members, querying up to workers members at once.
+// Members whose resources couldn't be retrieved in time are left out so that the scriptlet queries them itself.
+func InstancePlacementResources(ctx context.Context, s *state.State, candidateMembers []db.NodeInfo, workers int) map[string]*api.Resources {
+	memberResources := make(map[string]*api.Resources, len(candidateMembers))
+	if workers <= 0 {
+		return memberResources
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+
+	for _, member := range candidateMembers {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return memberResources
+		}
+
+		wg.Add(1)
+		go func(member db.NodeInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			ctx, cancel := context.WithTimeout(ctx, instancePlacementResourcesTimeout)
+			defer cancel()
+
+			// Run the query separately so that an unresponsive member doesn't hold a worker.
+			type reply struct {
+				resources *api.Resources
+				err       error
+			}
+
+			replies := make(chan reply, 1)
+			go func() {
+				var r reply
+
+				if member.Name == s.ServerName {
+					r.resources, r.err = resources.GetResources()
+				} else {
+					var client incus.InstanceServer
+
+					client, r.err = cluster.Connect(member.Address, s.Endpoints.NetworkCert(), s.ServerCert(), nil, true)
+					if r.err == nil {
+						r.resources, r.err = client.GetServerResources()
+					}
+				}
+
+				replies <- r
+			}()
+
+			var r reply
+			select {
+			case r = <-replies:
+			case <-ctx.Done():
+				r.err = ctx.Err()
+			}
+
+			if r.err != nil {
+				logger.Warn("Failed getting cluster member resources", logger.Ctx{"member": member.Name, "err": r.err})
+				return
+			}
+
+			mu.Lock()
+			memberResources[member.Name] = r.resources
+			mu.Unlock()
+		}(member)
+	}
+
+	wg.Wait()
+
+	return memberResources
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/google/uuid"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7"
 	"golang.org/x/sync/errgroup"
@@ -979,6 +980,91 @@ func (b *backend) CreateInstanceFromBackup(srcBackup backup.Info, srcData io.Rea
 	return postHook, revertHook, nil
 }
 
+// RestoreInstanceFromBackup replaces the data of an existing instance with the one stored in a backup.
+// Only non-optimized backups can be restored this way and the instance's snapshots are left untouched.
+func (b *backend) RestoreInstanceFromBackup(inst instance.Instance, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "backup": srcBackup.Name})
+	l.Debug("RestoreInstanceFromBackup started")
+	defer l.Debug("RestoreInstanceFromBackup finished")
+
+	if srcBackup.OptimizedStorage != nil && *srcBackup.OptimizedStorage {
+		return fmt.Errorf("Optimized backups can't be restored onto an existing instance")
+	}
+
+	instanceType, err := instancetype.New(string(srcBackup.Type))
+	if err != nil {
+		return err
+	}
+
+	if inst.Type() != instanceType {
+		return fmt.Errorf("Instance types must match")
+	}
+
+	// Target instance must not be running.
+	if inst.IsRunning() {
+		return fmt.Errorf("Instance must not be running to restore")
+	}
+
+	// Check we can convert the instance to the volume type needed.
+	volType, err := InstanceTypeToVolumeType(inst.Type())
+	if err != nil {
+		return err
+	}
+
+	contentType := InstanceContentType(inst)
+
+	// Load storage volume from database.
+	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
+	if err != nil {
+		return err
+	}
+
+	// Generate the effective root device volume for instance.
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	vol := b.GetVolume(volType, contentType, volStorageName, dbVol.Config)
+	err = b.applyInstanceRootDiskOverrides(inst, &vol)
+	if err != nil {
+		return err
+	}
+
+	// The volume is wiped before the backup is unpacked, so take a temporary snapshot of it to be able to
+	// roll back to its current content should the unpack fail.
+	snapVolName := drivers.GetSnapshotVolumeName(volStorageName, fmt.Sprintf("restore-%s", uuid.New().String()))
+	snapVol := b.GetVolume(volType, contentType, snapVolName, vol.Config())
+
+	err = b.driver.CreateVolumeSnapshot(snapVol, op)
+	if err != nil {
+		return fmt.Errorf("Failed creating temporary snapshot: %w", err)
+	}
+
+	defer func() {
+		err := b.driver.DeleteVolumeSnapshot(snapVol, op)
+		if err != nil {
+			l.Warn("Failed deleting temporary snapshot", logger.Ctx{"snapshot": snapVolName, "err": err})
+		}
+	}()
+
+	err = drivers.UnpackBackupVolume(b.driver, vol, srcData, op)
+	if err != nil {
+		_, snapName, _ := api.GetParentAndSnapshotName(snapVolName)
+
+		restoreErr := b.driver.RestoreVolume(vol, snapName, op)
+		if restoreErr != nil {
+			return fmt.Errorf("%w (rolling back to the previous content also failed: %v)", err, restoreErr)
+		}
+
+		return err
+	}
+
+	// The backup file of the volume now comes from the backup, regenerate it.
+	err = b.UpdateInstanceBackupFile(inst, true, op)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CreateInstanceFromCopy copies an instance volume and optionally its snapshots to new volume(s).
 func (b *backend) CreateInstanceFromCopy(inst instance.Instance, src instance.Instance, snapshots bool, allowInconsistent bool, op *operations.Operation) error {
 	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "src": src.Name(), "snapshots": snapshots})
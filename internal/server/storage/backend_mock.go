@@ -116,6 +116,10 @@ func (b *mockBackend) CreateInstanceFromBackup(srcBackup backup.Info, srcData io
 	return nil, nil, nil
 }
 
+func (b *mockBackend) RestoreInstanceFromBackup(inst instance.Instance, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) error {
+	return nil
+}
+
 func (b *mockBackend) CreateInstanceFromCopy(inst instance.Instance, src instance.Instance, snapshots bool, allowInconsistent bool, op *operations.Operation) error {
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/lxc/incus/v6/internal/migration"
 	"github.com/lxc/incus/v6/internal/revert"
 	"github.com/lxc/incus/v6/internal/server/backup"
+	"github.com/lxc/incus/v6/internal/server/metrics"
 	localMigration "github.com/lxc/incus/v6/internal/server/migration"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
@@ -248,6 +249,11 @@ func (d *common) Config() map[string]string {
 	return confCopy
 }
 
+// GetMetrics returns the driver specific metrics of the pool.
+func (d *common) GetMetrics() (*metrics.MetricSet, error) {
+	return nil, ErrNotSupported
+}
+
 // ApplyPatch looks for a suitable patch and runs it.
 func (d *common) ApplyPatch(name string) error {
 	if d.patches == nil {
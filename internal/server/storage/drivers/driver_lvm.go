@@ -9,11 +9,13 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/revert"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/metrics"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/api"
@@ -29,6 +31,47 @@ const lvmVgPoolMarker = "incus_pool" // Indicator tag used to mark volume groups
 var lvmLoaded bool
 var lvmVersion string
 
+// lvmFeatureSet records the features supported by the installed version of LVM.
+type lvmFeatureSet struct {
+	mu             sync.Mutex
+	activationSkip bool // Whether volumes can be skipped during auto activation (--setactivationskip).
+	extentsFree    bool // Whether volumes can be sized from the free extents of the volume group (100%FREE).
+}
+
+// lvmFeatures is shared by all the LVM pools as they use the same LVM tools.
+var lvmFeatures lvmFeatureSet
+
+// detect records the features supported by the LVM version.
+// Features are considered unsupported if the version can't be parsed.
+func (f *lvmFeatureSet) detect(d *lvm, lvmVersion string) {
+	ver20299, err := d.lvmVersionIsAtLeast(lvmVersion, "2.02.99")
+	if err != nil {
+		d.logger.Warn("Failed parsing LVM version, assuming no optional features are supported", logger.Ctx{"version": lvmVersion, "err": err})
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.activationSkip = ver20299
+	f.extentsFree = ver20299
+}
+
+// hasActivationSkip returns whether volumes can be skipped during auto activation.
+func (f *lvmFeatureSet) hasActivationSkip() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.activationSkip
+}
+
+// hasExtentsFree returns whether volumes can be sized from the free extents of the volume group.
+func (f *lvmFeatureSet) hasExtentsFree() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.extentsFree
+}
+
 type lvm struct {
 	common
 
@@ -89,6 +132,9 @@ func (d *lvm) load() error {
 		}
 	}
 
+	// Detect the features supported by this version once rather than on every use.
+	lvmFeatures.detect(d, lvmVersion)
+
 	lvmLoaded = true
 	return nil
 }
@@ -309,11 +355,20 @@ func (d *lvm) Create() error {
 		return fmt.Errorf("No name for volume group detected")
 	}
 
-	// Used to track the result of checking whether the thin pool exists during the existing volume group empty
-	// checks to avoid having to do it twice.
+	// Used to track whether the thin pool already exists in the volume group, in which case it is adopted
+	// rather than created.
 	thinPoolExists := false
 
 	if vgExists {
+		// Check whether the volume group already has the thin pool. This fails if a logical volume of
+		// that name exists but isn't a thin pool.
+		if d.usesThinpool() {
+			thinPoolExists, err = d.thinpoolExists(d.config["lvm.vg_name"], d.thinpoolName())
+			if err != nil {
+				return fmt.Errorf("Failed to determine whether thin pool %q exists in volume group %q: %w", d.thinpoolName(), d.config["lvm.vg_name"], err)
+			}
+		}
+
 		// Check that the volume group is empty. Otherwise we will refuse to use it.
 		// The LV count returned includes both normal volumes and thin volumes.
 		lvCount, err := d.countLogicalVolumes(d.config["lvm.vg_name"])
@@ -321,24 +376,9 @@ func (d *lvm) Create() error {
 			return fmt.Errorf("Failed to determine whether the volume group %q is empty: %w", d.config["lvm.vg_name"], err)
 		}
 
-		empty := false
-		if lvCount > 0 {
-			if d.usesThinpool() {
-				// Always check if the thin pool exists as we may need to create it later.
-				thinPoolExists, err = d.thinpoolExists(d.config["lvm.vg_name"], d.thinpoolName())
-				if err != nil {
-					return fmt.Errorf("Failed to determine whether thinpool %q exists in volume group %q: %w", d.config["lvm.vg_name"], d.thinpoolName(), err)
-				}
-
-				// If the single volume is the storage pool's thin pool LV then we still consider
-				// this an empty volume group.
-				if thinPoolExists && lvCount == 1 {
-					empty = true
-				}
-			}
-		} else {
-			empty = true
-		}
+		// If the single volume is the storage pool's thin pool LV then we still consider
+		// this an empty volume group.
+		empty := lvCount == 0 || (thinPoolExists && lvCount == 1)
 
 		// Skip the in use checks if the force reuse option is enabled. This allows a storage pool to be
 		// backed by an existing non-empty volume group. Note: This option should be used with care, as Incus
@@ -394,7 +434,7 @@ func (d *lvm) Create() error {
 				}
 			}
 
-			err = d.createDefaultThinPool(d.Info().Version, d.thinpoolName(), thinpoolSizeBytes)
+			err = d.createDefaultThinPool(d.thinpoolName(), thinpoolSizeBytes)
 			if err != nil {
 				return err
 			}
@@ -404,8 +444,25 @@ func (d *lvm) Create() error {
 			revert.Add(func() {
 				_ = d.removeLogicalVolume(d.lvmDevPath(d.config["lvm.vg_name"], "", "", d.thinpoolName()))
 			})
-		} else if d.config["size"] != "" {
-			return fmt.Errorf("Cannot specify size when using an existing thin pool")
+		} else {
+			// The existing thin pool is adopted as is.
+			if d.config["size"] != "" {
+				return fmt.Errorf("Cannot specify size when using an existing thin pool")
+			}
+
+			if d.config["lvm.thinpool_metadata_size"] != "" {
+				return fmt.Errorf("Cannot specify lvm.thinpool_metadata_size when using an existing thin pool")
+			}
+
+			d.logger.Debug("Using existing thin pool", logger.Ctx{"vg_name": d.config["lvm.vg_name"], "thinpool_name": d.thinpoolName()})
+		}
+
+		// Only change the thin pool zeroing if requested, leaving LVM's default otherwise.
+		if d.config["lvm.thinpool_zero"] != "" {
+			err = d.thinPoolSetZeroing(util.IsTrue(d.config["lvm.thinpool_zero"]))
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -545,10 +602,13 @@ func (d *lvm) Validate(config map[string]string) error {
 		"lvm.vg_name": validate.IsAny,
 	}
 
-	if !d.clustered {
+	if d.clustered {
+		rules["lvm.activation_mode"] = validate.Optional(validate.IsOneOf(lvmActivationModes...))
+	} else {
 		rules["size"] = validate.Optional(validate.IsSize)
 		rules["lvm.thinpool_name"] = validate.IsAny
 		rules["lvm.thinpool_metadata_size"] = validate.Optional(validate.IsSize)
+		rules["lvm.thinpool_zero"] = validate.Optional(validate.IsBool)
 		rules["lvm.use_thinpool"] = validate.Optional(validate.IsBool)
 		rules["lvm.vg.force_reuse"] = validate.Optional(validate.IsBool)
 	}
@@ -558,6 +618,12 @@ func (d *lvm) Validate(config map[string]string) error {
 		return err
 	}
 
+	thinpool := !d.clustered && util.IsTrueOrEmpty(config["lvm.use_thinpool"])
+	err = lvmValidateRAID(config["volume.lvm.raid.level"], config["volume.lvm.raid.mirrors"], config["volume.lvm.stripes"], thinpool)
+	if err != nil {
+		return fmt.Errorf("Invalid volume configuration: %w", err)
+	}
+
 	if util.IsFalse(config["lvm.use_thinpool"]) {
 		if config["lvm.thinpool_name"] != "" {
 			return fmt.Errorf("The key lvm.use_thinpool cannot be set to false when lvm.thinpool_name is set")
@@ -566,6 +632,10 @@ func (d *lvm) Validate(config map[string]string) error {
 		if config["lvm.thinpool_metadata_size"] != "" {
 			return fmt.Errorf("The key lvm.use_thinpool cannot be set to false when lvm.thinpool_metadata_size is set")
 		}
+
+		if config["lvm.thinpool_zero"] != "" {
+			return fmt.Errorf("The key lvm.use_thinpool cannot be set to false when lvm.thinpool_zero is set")
+		}
 	}
 
 	return nil
@@ -594,12 +664,10 @@ func (d *lvm) Update(changedConfig map[string]string) error {
 	}
 
 	if changedConfig["lvm.vg_name"] != "" {
-		_, err := subprocess.TryRunCommand("vgrename", d.config["lvm.vg_name"], changedConfig["lvm.vg_name"])
+		err := d.renameVolumeGroup(changedConfig["lvm.vg_name"])
 		if err != nil {
-			return fmt.Errorf("Error renaming LVM volume group from %q to %q: %w", d.config["lvm.vg_name"], changedConfig["lvm.vg_name"], err)
+			return err
 		}
-
-		d.logger.Debug("Volume group renamed", logger.Ctx{"vg_name": d.config["lvm.vg_name"], "new_vg_name": changedConfig["lvm.vg_name"]})
 	}
 
 	if changedConfig["lvm.thinpool_name"] != "" {
@@ -611,6 +679,15 @@ func (d *lvm) Update(changedConfig map[string]string) error {
 		d.logger.Debug("Thin pool volume renamed", logger.Ctx{"vg_name": d.config["lvm.vg_name"], "thinpool": d.thinpoolName(), "new_thinpool": changedConfig["lvm.thinpool_name"]})
 	}
 
+	// Unsetting the zeroing leaves the thin pool as it is, like on creation.
+	zero, changed := changedConfig["lvm.thinpool_zero"]
+	if changed && zero != "" && d.usesThinpool() {
+		err := d.thinPoolSetZeroing(util.IsTrue(zero))
+		if err != nil {
+			return err
+		}
+	}
+
 	size, ok := changedConfig["size"]
 	if ok {
 		// Figure out loop path
@@ -746,6 +823,28 @@ func (d *lvm) Unmount() (bool, error) {
 	return false, nil
 }
 
+// GetMetrics returns the usage metrics of the thinpool.
+func (d *lvm) GetMetrics() (*metrics.MetricSet, error) {
+	// Without a thinpool, the volume group usage is already reported through the pool resources.
+	if !d.usesThinpool() {
+		return nil, ErrNotSupported
+	}
+
+	volDevPath := d.lvmDevPath(d.config["lvm.vg_name"], "", "", d.thinpoolName())
+	totalSize, dataPerc, metaPerc, err := d.thinPoolVolumeStats(volDevPath)
+	if err != nil {
+		return nil, err
+	}
+
+	out := metrics.NewMetricSet(map[string]string{"pool": d.name})
+	out.AddSamples(metrics.LVMThinpoolDataPercent, metrics.Sample{Value: dataPerc})
+	out.AddSamples(metrics.LVMThinpoolMetadataPercent, metrics.Sample{Value: metaPerc})
+	out.AddSamples(metrics.LVMThinpoolSizeBytes, metrics.Sample{Value: float64(totalSize)})
+	out.AddSamples(metrics.LVMThinpoolUsedBytes, metrics.Sample{Value: float64(totalSize) * (dataPerc / 100)})
+
+	return out, nil
+}
+
 // GetResources returns utilisation and space info about the pool.
 func (d *lvm) GetResources() (*api.ResourcesStoragePool, error) {
 	res := api.ResourcesStoragePool{}
@@ -761,6 +860,14 @@ func (d *lvm) GetResources() (*api.ResourcesStoragePool, error) {
 
 		res.Space.Total = totalSize
 		res.Space.Used = usedSize
+
+		// The ratio is only informational, so don't fail reporting the pool usage over it.
+		ratio, err := d.thinPoolOverProvisioningRatio(d.config["lvm.vg_name"], d.thinpoolName())
+		if err != nil {
+			d.logger.Warn("Failed getting thin pool over-provisioning ratio", logger.Ctx{"err": err})
+		} else {
+			res.Space.OverProvisioningRatio = ratio
+		}
 	} else {
 		// If thinpools are not in use, calculate used space in volume group.
 		args := []string{
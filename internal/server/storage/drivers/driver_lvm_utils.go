@@ -11,6 +11,9 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sys/unix"
+
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/revert"
@@ -22,8 +25,15 @@ import (
 	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
 )
 
+// lvmActivationModes are the lvchange activation modes which can be used with lvm.activation_mode.
+var lvmActivationModes = []string{"y", "ey", "sy"}
+
+// lvmSnapshotCopyConcurrency is the maximum number of snapshots created at the same time when copying a volume.
+const lvmSnapshotCopyConcurrency = 4
+
 // lvmBlockVolSuffix suffix used for block content type volumes.
 const lvmBlockVolSuffix = ".block"
 
@@ -36,6 +46,9 @@ const lvmSnapshotSeparator = "-"
 // lvmEscapedHyphen used to escape hyphens in volume names to avoid conflicts with lvmSnapshotSeparator.
 const lvmEscapedHyphen = "--"
 
+// lvmRAIDLevels are the RAID levels supported for logical volumes.
+var lvmRAIDLevels = []string{"raid1", "raid4", "raid5", "raid6", "raid10"}
+
 // lvmThinpoolDefaultName is the default name for the thinpool volume.
 const lvmThinpoolDefaultName = "IncusThinPool"
 
@@ -100,6 +113,46 @@ func (d *lvm) isLVMNotFoundExitError(err error) bool {
 	return false
 }
 
+// isLVMBusyError checks whether the supplied error is an error from an LVM command caused by the
+// logical volume being transiently in use (for example held by udev or a concurrent scan).
+func (d *lvm) isLVMBusyError(err error) bool {
+	runErr, ok := err.(subprocess.RunError)
+	if !ok {
+		return false
+	}
+
+	stdErr := strings.ToLower(runErr.StdErr().String())
+
+	return strings.Contains(stdErr, "in use") || strings.Contains(stdErr, "busy")
+}
+
+// runLVMCommand runs an LVM command, retrying it a few times on failure like subprocess.TryRunCommand.
+// Failures caused by the logical volume being busy are retried sooner, with a short backoff.
+func (d *lvm) runLVMCommand(name string, args ...string) (string, error) {
+	var err error
+	var output string
+
+	busyWait := 100 * time.Millisecond
+	for i := 0; i < 20; i++ {
+		output, err = subprocess.RunCommand(name, args...)
+		if err == nil {
+			break
+		}
+
+		if d.isLVMBusyError(err) {
+			d.logger.Debug("LVM logical volume busy, retrying", logger.Ctx{"cmd": name, "attempt": i, "err": err})
+			time.Sleep(busyWait)
+			busyWait = min(busyWait*2, 2*time.Second)
+			continue
+		}
+
+		d.logger.Debug("LVM command failed, retrying", logger.Ctx{"cmd": name, "attempt": i, "err": err})
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return output, err
+}
+
 // pysicalVolumeExists checks if an LVM Physical Volume exists.
 func (d *lvm) pysicalVolumeExists(pvName string) (bool, error) {
 	_, err := subprocess.RunCommand("pvs", "--noheadings", "-o", "pv_name", pvName)
@@ -146,6 +199,103 @@ func (d *lvm) volumeGroupExtentSize(vgName string) (int64, error) {
 	return strconv.ParseInt(output, 10, 64)
 }
 
+// volumeGroupFreeExtents gets the number of free physical extents in the volume group.
+func (d *lvm) volumeGroupFreeExtents(vgName string) (int64, error) {
+	output, err := subprocess.RunCommand("vgs", "--noheadings", "-o", "vg_free_count", vgName)
+	if err != nil {
+		if d.isLVMNotFoundExitError(err) {
+			return -1, api.StatusErrorf(http.StatusNotFound, "LVM volume group not found")
+		}
+
+		return -1, err
+	}
+
+	output = strings.TrimSpace(output)
+	return strconv.ParseInt(output, 10, 64)
+}
+
+// extentsForSize returns the number of extents of the given size needed to hold a logical volume of the given
+// size, as LVM rounds the size up to a whole number of extents.
+func extentsForSize(sizeBytes int64, extentSize int64) int64 {
+	return (sizeBytes + extentSize - 1) / extentSize
+}
+
+// checkVolumeGroupFreeSpace checks that the volume group has enough free extents for a new logical volume
+// of the given size, so that a clear error can be returned rather than the one from lvcreate.
+func (d *lvm) checkVolumeGroupFreeSpace(vgName string, sizeBytes int64) error {
+	extentSize, err := d.volumeGroupExtentSize(vgName)
+	if err != nil {
+		return fmt.Errorf("Error getting LVM volume group extent size: %w", err)
+	}
+
+	freeExtents, err := d.volumeGroupFreeExtents(vgName)
+	if err != nil {
+		return fmt.Errorf("Error getting LVM volume group free extents: %w", err)
+	}
+
+	neededExtents := extentsForSize(sizeBytes, extentSize)
+	if neededExtents > freeExtents {
+		return fmt.Errorf("Insufficient free space in volume group %q, %s available, %s requested", vgName, units.GetByteSizeStringIEC(freeExtents*extentSize, 2), units.GetByteSizeStringIEC(neededExtents*extentSize, 2))
+	}
+
+	return nil
+}
+
+// openLogicalVolumes returns the names of the logical volumes of a volume group which are currently open
+// (mounted or otherwise in use).
+func (d *lvm) openLogicalVolumes(vgName string) ([]string, error) {
+	output, err := subprocess.RunCommand("lvs", "--noheadings", "--separator", ",", "-o", "lv_name,lv_attr", vgName)
+	if err != nil {
+		if d.isLVMNotFoundExitError(err) {
+			return nil, api.StatusErrorf(http.StatusNotFound, "LVM volume group not found")
+		}
+
+		return nil, fmt.Errorf("Error listing logical volumes in LVM volume group %q: %w", vgName, err)
+	}
+
+	openVolumes := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := util.SplitNTrimSpace(line, ",", 2, true)
+		if len(fields) != 2 {
+			continue
+		}
+
+		// The sixth attribute is "o" when the device is open.
+		attrs := fields[1]
+		if len(attrs) >= 6 && attrs[5] == 'o' {
+			openVolumes = append(openVolumes, fields[0])
+		}
+	}
+
+	return openVolumes, nil
+}
+
+// renameVolumeGroup renames the pool's volume group.
+// As the device paths of the logical volumes change, this requires none of them to be in use.
+func (d *lvm) renameVolumeGroup(newVgName string) error {
+	if d.clustered {
+		return fmt.Errorf("Renaming a shared LVM volume group isn't supported")
+	}
+
+	openVolumes, err := d.openLogicalVolumes(d.config["lvm.vg_name"])
+	if err != nil {
+		return err
+	}
+
+	if len(openVolumes) > 0 {
+		return fmt.Errorf("Cannot rename LVM volume group %q while logical volumes are in use: %s", d.config["lvm.vg_name"], strings.Join(openVolumes, ", "))
+	}
+
+	_, err = subprocess.TryRunCommand("vgrename", d.config["lvm.vg_name"], newVgName)
+	if err != nil {
+		return fmt.Errorf("Error renaming LVM volume group from %q to %q: %w", d.config["lvm.vg_name"], newVgName, err)
+	}
+
+	d.logger.Debug("Volume group renamed", logger.Ctx{"vg_name": d.config["lvm.vg_name"], "new_vg_name": newVgName})
+
+	return nil
+}
+
 // countLogicalVolumes gets the count of volumes (both normal and thin) in a volume group.
 func (d *lvm) countLogicalVolumes(vgName string) (int, error) {
 	output, err := subprocess.RunCommand("vgs", "--noheadings", "-o", "lv_count", vgName)
@@ -215,12 +365,7 @@ func (d *lvm) logicalVolumeExists(volDevPath string) (bool, error) {
 // in the volume group.
 // If pool lvm.thinpool_metadata_size setting >0 will manually set metadata size for the thinpool, otherwise LVM
 // will pick an appropriate size.
-func (d *lvm) createDefaultThinPool(lvmVersion, thinPoolName string, thinpoolSizeBytes int64) error {
-	isRecent, err := d.lvmVersionIsAtLeast(lvmVersion, "2.02.99")
-	if err != nil {
-		return fmt.Errorf("Error checking LVM version: %w", err)
-	}
-
+func (d *lvm) createDefaultThinPool(thinPoolName string, thinpoolSizeBytes int64) error {
 	lvmThinPool := fmt.Sprintf("%s/%s", d.config["lvm.vg_name"], thinPoolName)
 
 	args := []string{
@@ -240,7 +385,7 @@ func (d *lvm) createDefaultThinPool(lvmVersion, thinPoolName string, thinpoolSiz
 
 	if thinpoolSizeBytes > 0 {
 		args = append(args, "--size", fmt.Sprintf("%db", thinpoolSizeBytes))
-	} else if isRecent {
+	} else if lvmFeatures.hasExtentsFree() {
 		args = append(args, "--extents", "100%FREE")
 	} else {
 		args = append(args, "--size", "1G")
@@ -267,7 +412,7 @@ func (d *lvm) createDefaultThinPool(lvmVersion, thinPoolName string, thinpoolSiz
 		return fmt.Errorf("Error creating LVM thin pool named %q: %w", thinPoolName, err)
 	}
 
-	if !isRecent && thinpoolSizeBytes <= 0 {
+	if !lvmFeatures.hasExtentsFree() && thinpoolSizeBytes <= 0 {
 		// Grow it to the maximum VG size (two step process required by old LVM).
 		_, err = subprocess.TryRunCommand("lvextend", "--alloc", "anywhere", "-l", "100%FREE", lvmThinPool)
 		if err != nil {
@@ -278,6 +423,26 @@ func (d *lvm) createDefaultThinPool(lvmVersion, thinPoolName string, thinpoolSiz
 	return nil
 }
 
+// thinPoolSetZeroing controls whether the thin pool zeroes newly provisioned blocks.
+// Disabling zeroing speeds up writes to thin volumes but may expose stale data from previously deleted volumes.
+func (d *lvm) thinPoolSetZeroing(zero bool) error {
+	lvmThinPool := fmt.Sprintf("%s/%s", d.config["lvm.vg_name"], d.thinpoolName())
+
+	zeroArg := "n"
+	if zero {
+		zeroArg = "y"
+	}
+
+	_, err := subprocess.TryRunCommand("lvchange", "--zero", zeroArg, lvmThinPool)
+	if err != nil {
+		return fmt.Errorf("Error setting zeroing on LVM thin pool named %q: %w", d.thinpoolName(), err)
+	}
+
+	d.logger.Debug("Thin pool zeroing changed", logger.Ctx{"vg_name": d.config["lvm.vg_name"], "thinpool_name": d.thinpoolName(), "zero": zero})
+
+	return nil
+}
+
 // lvmVersionIsAtLeast checks whether the installed version of LVM is at least the specific version.
 func (d *lvm) lvmVersionIsAtLeast(sTypeVersion string, versionString string) (bool, error) {
 	lvmVersionString := strings.Split(sTypeVersion, "/")[0]
@@ -322,6 +487,39 @@ func (d *lvm) roundedSizeBytesString(size string) (int64, error) {
 	return sizeBytes, nil
 }
 
+// lvmValidateRAID checks that the RAID configuration of logical volumes is consistent.
+// RAID levels can't be used inside of thin pools, mirrors only apply to RAID1 and RAID10 and the stripes
+// of RAID1 volumes can't be set.
+func lvmValidateRAID(level string, mirrors string, stripes string, thinpool bool) error {
+	if level == "" {
+		if mirrors != "" {
+			return fmt.Errorf("lvm.raid.mirrors requires lvm.raid.level to be set")
+		}
+
+		return nil
+	}
+
+	if thinpool {
+		return fmt.Errorf("lvm.raid.level cannot be used with thin pool volumes as RAID isn't supported inside of thin pools")
+	}
+
+	if mirrors != "" {
+		if level != "raid1" && level != "raid10" {
+			return fmt.Errorf("lvm.raid.mirrors can only be used with the raid1 and raid10 RAID levels")
+		}
+
+		if mirrors == "0" {
+			return fmt.Errorf("lvm.raid.mirrors must be at least 1")
+		}
+	}
+
+	if level == "raid1" && stripes != "" {
+		return fmt.Errorf("lvm.stripes cannot be used with the raid1 RAID level")
+	}
+
+	return nil
+}
+
 // createLogicalVolume creates a logical volume.
 func (d *lvm) createLogicalVolume(vgName, thinPoolName string, vol Volume, makeThinLv bool) error {
 	var err error
@@ -340,6 +538,10 @@ func (d *lvm) createLogicalVolume(vgName, thinPoolName string, vol Volume, makeT
 	}
 
 	if makeThinLv {
+		if vol.ExpandedConfig("lvm.raid.level") != "" {
+			return fmt.Errorf("RAID logical volumes can't be created inside of a thin pool")
+		}
+
 		targetVg := fmt.Sprintf("%s/%s", vgName, thinPoolName)
 		args = append(args,
 			"--thin",
@@ -352,6 +554,25 @@ func (d *lvm) createLogicalVolume(vgName, thinPoolName string, vol Volume, makeT
 			vgName,
 		)
 
+		// RAID is only available for normal logical volumes.
+		raidLevel := vol.ExpandedConfig("lvm.raid.level")
+
+		if raidLevel != "" {
+			args = append(args, "--type", raidLevel)
+
+			mirrors := vol.ExpandedConfig("lvm.raid.mirrors")
+			if mirrors != "" {
+				args = append(args, "--mirrors", mirrors)
+			}
+		} else {
+			// Thick volumes need their whole size to be available in the volume group. The space needed
+			// by RAID volumes depends on their layout, so leave it to lvcreate to check it.
+			err = d.checkVolumeGroupFreeSpace(vgName, lvSizeBytes)
+			if err != nil {
+				return err
+			}
+		}
+
 		// As we are creating a normal logical volume we can apply stripes settings if specified.
 		stripes := vol.ExpandedConfig("lvm.stripes")
 		if stripes != "" {
@@ -369,6 +590,11 @@ func (d *lvm) createLogicalVolume(vgName, thinPoolName string, vol Volume, makeT
 		}
 	}
 
+	// Make sure discards issued to the thin volume free space in the thin pool.
+	if makeThinLv && util.IsTrue(vol.ExpandedConfig("lvm.discard")) {
+		d.ensureThinPoolDiscards(vgName, thinPoolName)
+	}
+
 	_, err = subprocess.TryRunCommand("lvcreate", args...)
 	if err != nil {
 		return fmt.Errorf("Error creating LVM logical volume %q: %w", lvFullName, err)
@@ -383,12 +609,7 @@ func (d *lvm) createLogicalVolume(vgName, thinPoolName string, vol Volume, makeT
 		}
 	}
 
-	isRecent, err := d.lvmVersionIsAtLeast(lvmVersion, "2.02.99")
-	if err != nil {
-		return fmt.Errorf("Error checking LVM version: %w", err)
-	}
-
-	if isRecent {
+	if lvmFeatures.hasActivationSkip() {
 		// Disable auto activation of volume on LVM versions that support it.
 		// Must be done after volume create so that zeroing and signature wiping can take place.
 		_, err := subprocess.RunCommand("lvchange", "--setactivationskip", "y", volDevPath)
@@ -404,16 +625,11 @@ func (d *lvm) createLogicalVolume(vgName, thinPoolName string, vol Volume, makeT
 // createLogicalVolumeSnapshot creates a snapshot of a logical volume.
 func (d *lvm) createLogicalVolumeSnapshot(vgName string, srcVol Volume, snapVol Volume, readonly bool, makeThinLv bool) (string, error) {
 	srcVolDevPath := d.lvmDevPath(vgName, srcVol.volType, srcVol.contentType, srcVol.name)
-	isRecent, err := d.lvmVersionIsAtLeast(lvmVersion, "2.02.99")
-	if err != nil {
-		return "", fmt.Errorf("Error checking LVM version: %w", err)
-	}
-
 	snapLvName := d.lvmFullVolumeName(snapVol.volType, snapVol.contentType, snapVol.name)
 	logCtx := logger.Ctx{"vg_name": vgName, "lv_name": snapLvName, "src_dev": srcVolDevPath, "thin": makeThinLv}
 	args := []string{"-n", snapLvName, "-s", srcVolDevPath}
 
-	if isRecent {
+	if lvmFeatures.hasActivationSkip() {
 		args = append(args, "--setactivationskip", "y")
 	}
 
@@ -433,7 +649,7 @@ func (d *lvm) createLogicalVolumeSnapshot(vgName string, srcVol Volume, snapVol
 	revert := revert.New()
 	defer revert.Fail()
 
-	_, err = subprocess.TryRunCommand("lvcreate", args...)
+	_, err := subprocess.TryRunCommand("lvcreate", args...)
 	if err != nil {
 		return "", err
 	}
@@ -452,7 +668,7 @@ func (d *lvm) createLogicalVolumeSnapshot(vgName string, srcVol Volume, snapVol
 
 // removeLogicalVolume removes a logical volume.
 func (d *lvm) removeLogicalVolume(volDevPath string) error {
-	_, err := subprocess.TryRunCommand("lvremove", "-f", volDevPath)
+	_, err := d.runLVMCommand("lvremove", "-f", volDevPath)
 	if err != nil {
 		return err
 	}
@@ -532,6 +748,8 @@ func (d *lvm) copyThinpoolVolume(vol, srcVol Volume, srcSnapshots []Volume, refr
 			return err
 		}
 
+		// Check that none of the snapshots exist before creating any of them.
+		newSnapVols := make([]Volume, 0, len(srcSnapshots))
 		for _, srcSnapshot := range srcSnapshots {
 			_, snapName, _ := api.GetParentAndSnapshotName(srcSnapshot.name)
 			newFullSnapName := GetSnapshotVolumeName(vol.name, snapName)
@@ -546,26 +764,74 @@ func (d *lvm) copyThinpoolVolume(vol, srcVol Volume, srcSnapshots []Volume, refr
 				return fmt.Errorf("LVM snapshot volume already exists %q", newSnapVol.name)
 			}
 
-			newSnapVolPath := newSnapVol.MountPath()
-			err = newSnapVol.EnsureMountPath()
-			if err != nil {
-				return err
-			}
+			newSnapVols = append(newSnapVols, newSnapVol)
+		}
 
-			revert.Add(func() { _ = os.RemoveAll(newSnapVolPath) })
+		// Snapshots are independent from each other so create them concurrently. LVM serializes the
+		// metadata changes of a volume group so there's little point in running many at once.
+		// Each routine only records its own progress so no locking is needed.
+		created := make([]bool, len(newSnapVols))
+		mountPathCreated := make([]bool, len(newSnapVols))
+
+		// On clustered pools, each change goes through lvmlockd's volume group lock which concurrent
+		// changes would contend on, so create the snapshots one at a time there.
+		concurrency := lvmSnapshotCopyConcurrency
+		if d.clustered {
+			concurrency = 1
+		}
 
-			// We do not modify the original snapshot so as to avoid damaging if it is corrupted for
-			// some reason. If the filesystem needs to have a unique UUID generated in order to mount
-			// this will be done at restore time to be safe.
-			_, err = d.createLogicalVolumeSnapshot(d.config["lvm.vg_name"], srcSnapshot, newSnapVol, true, d.usesThinpool())
-			if err != nil {
-				return fmt.Errorf("Error creating LVM logical volume snapshot: %w", err)
-			}
+		g := errgroup.Group{}
+		g.SetLimit(concurrency)
 
-			revert.Add(func() {
-				_ = d.removeLogicalVolume(d.lvmDevPath(d.config["lvm.vg_name"], newSnapVol.volType, newSnapVol.contentType, newSnapVol.name))
+		for i, srcSnapshot := range srcSnapshots {
+			i := i
+			srcSnapshot := srcSnapshot
+			newSnapVol := newSnapVols[i]
+
+			g.Go(func() error {
+				err := newSnapVol.EnsureMountPath()
+				if err != nil {
+					return err
+				}
+
+				mountPathCreated[i] = true
+
+				// We do not modify the original snapshot so as to avoid damaging if it is corrupted for
+				// some reason. If the filesystem needs to have a unique UUID generated in order to mount
+				// this will be done at restore time to be safe.
+				_, err = d.createLogicalVolumeSnapshot(d.config["lvm.vg_name"], srcSnapshot, newSnapVol, true, d.usesThinpool())
+				if err != nil {
+					return fmt.Errorf("Error creating LVM logical volume snapshot: %w", err)
+				}
+
+				created[i] = true
+
+				return nil
 			})
 		}
+
+		err = g.Wait()
+
+		// Register the cleanup of all the snapshots which were created, as others may have completed after
+		// one of them failed.
+		for i := range newSnapVols {
+			newSnapVol := newSnapVols[i]
+
+			if mountPathCreated[i] {
+				newSnapVolPath := newSnapVol.MountPath()
+				revert.Add(func() { _ = os.RemoveAll(newSnapVolPath) })
+			}
+
+			if created[i] {
+				revert.Add(func() {
+					_ = d.removeLogicalVolume(d.lvmDevPath(d.config["lvm.vg_name"], newSnapVol.volType, newSnapVol.contentType, newSnapVol.name))
+				})
+			}
+		}
+
+		if err != nil {
+			return err
+		}
 	}
 
 	// Handle copying the main volume.
@@ -678,41 +944,149 @@ func (d *lvm) logicalVolumeSize(volDevPath string) (int64, error) {
 	return strconv.ParseInt(output, 10, 64)
 }
 
-func (d *lvm) thinPoolVolumeUsage(volDevPath string) (uint64, uint64, error) {
+// thinPoolOverProvisioningRatio returns the ratio of the sum of the virtual sizes of the thin volumes in a thin
+// pool to the physical size of the thin pool. A ratio above 1 means the thin pool is oversubscribed.
+func (d *lvm) thinPoolOverProvisioningRatio(vgName string, poolName string) (float64, error) {
+	args := []string{
+		vgName,
+		"--noheadings",
+		"--units", "b",
+		"--nosuffix",
+		"--separator", ",",
+		"-o", "lv_name,lv_size,pool_lv",
+	}
+
+	out, err := subprocess.RunCommand("lvs", args...)
+	if err != nil {
+		return 0, fmt.Errorf("Error listing logical volumes in LVM volume group %q: %w", vgName, err)
+	}
+
+	return parseThinPoolOverProvisioningRatio(out, poolName)
+}
+
+// parseThinPoolOverProvisioningRatio computes the over-provisioning ratio of the thin pool from the output of
+// lvs listing the name, size and pool of each logical volume of its volume group.
+func parseThinPoolOverProvisioningRatio(out string, poolName string) (float64, error) {
+	var poolSize int64
+	var virtualSize int64
+
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		parts := util.SplitNTrimSpace(line, ",", -1, true)
+		if len(parts) < 3 || (parts[0] != poolName && parts[2] != poolName) {
+			continue
+		}
+
+		size, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Failed parsing logical volume size (%q): %w", parts[1], err)
+		}
+
+		if parts[0] == poolName {
+			poolSize = size
+		} else {
+			virtualSize += size
+		}
+	}
+
+	if poolSize <= 0 {
+		return 0, fmt.Errorf("Invalid size for LVM thin pool %q", poolName)
+	}
+
+	return float64(virtualSize) / float64(poolSize), nil
+}
+
+// snapshotVolumeUsage returns how much of the copy-on-write space of a non-thin snapshot volume has been used,
+// both as a percentage and in bytes. A snapshot which runs out of copy-on-write space becomes invalid.
+func (d *lvm) snapshotVolumeUsage(volDevPath string) (float64, uint64, error) {
 	args := []string{
 		volDevPath,
 		"--noheadings",
 		"--units", "b",
 		"--nosuffix",
 		"--separator", ",",
-		"-o", "lv_size,data_percent,metadata_percent",
+		"-o", "lv_size,snap_percent",
 	}
 
 	out, err := subprocess.RunCommand("lvs", args...)
 	if err != nil {
+		if d.isLVMNotFoundExitError(err) {
+			return 0, 0, api.StatusErrorf(http.StatusNotFound, "LVM volume not found")
+		}
+
 		return 0, 0, err
 	}
 
 	parts := util.SplitNTrimSpace(out, ",", -1, true)
-	if len(parts) < 3 {
+	if len(parts) < 2 {
 		return 0, 0, fmt.Errorf("Unexpected output from lvs command")
 	}
 
-	total, err := strconv.ParseUint(parts[0], 10, 64)
+	// For snapshots, the volume size is the size of the copy-on-write space.
+	cowSize, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Failed parsing snapshot volume size (%q): %w", parts[0], err)
+	}
+
+	// Used percentage is not available if the snapshot isn't activated.
+	if parts[1] == "" {
+		return 0, 0, ErrNotSupported
+	}
+
+	snapPerc, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Failed parsing snapshot volume used percentage (%q): %w", parts[1], err)
+	}
+
+	usedSize := uint64(float64(cowSize) * (snapPerc / 100))
+
+	return snapPerc, usedSize, nil
+}
+
+func (d *lvm) thinPoolVolumeUsage(volDevPath string) (uint64, uint64, error) {
+	totalSize, dataPerc, metaPerc, err := d.thinPoolVolumeStats(volDevPath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	usedSize := uint64(float64(totalSize) * ((dataPerc + metaPerc) / 100))
+
+	return totalSize, usedSize, nil
+}
+
+// thinPoolVolumeStats returns the size of a thin volume along with its data and meta data used percentages.
+func (d *lvm) thinPoolVolumeStats(volDevPath string) (uint64, float64, float64, error) {
+	args := []string{
+		volDevPath,
+		"--noheadings",
+		"--units", "b",
+		"--nosuffix",
+		"--separator", ",",
+		"-o", "lv_size,data_percent,metadata_percent",
+	}
+
+	out, err := subprocess.RunCommand("lvs", args...)
 	if err != nil {
-		return 0, 0, fmt.Errorf("Failed parsing thin volume total size (%q): %w", parts[0], err)
+		return 0, 0, 0, err
+	}
+
+	parts := util.SplitNTrimSpace(out, ",", -1, true)
+	if len(parts) < 3 {
+		return 0, 0, 0, fmt.Errorf("Unexpected output from lvs command")
 	}
 
-	totalSize := total
+	totalSize, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("Failed parsing thin volume total size (%q): %w", parts[0], err)
+	}
 
 	// Used percentage is not available if thin volume isn't activated.
 	if parts[1] == "" {
-		return 0, 0, ErrNotSupported
+		return 0, 0, 0, ErrNotSupported
 	}
 
 	dataPerc, err := strconv.ParseFloat(parts[1], 64)
 	if err != nil {
-		return 0, 0, fmt.Errorf("Failed parsing thin volume used percentage (%q): %w", parts[1], err)
+		return 0, 0, 0, fmt.Errorf("Failed parsing thin volume used percentage (%q): %w", parts[1], err)
 	}
 
 	metaPerc := float64(0)
@@ -721,13 +1095,11 @@ func (d *lvm) thinPoolVolumeUsage(volDevPath string) (uint64, uint64, error) {
 	if parts[2] != "" {
 		metaPerc, err = strconv.ParseFloat(parts[2], 64)
 		if err != nil {
-			return 0, 0, fmt.Errorf("Failed parsing thin pool meta used percentage (%q): %w", parts[2], err)
+			return 0, 0, 0, fmt.Errorf("Failed parsing thin pool meta used percentage (%q): %w", parts[2], err)
 		}
 	}
 
-	usedSize := uint64(float64(total) * ((dataPerc + metaPerc) / 100))
-
-	return totalSize, usedSize, nil
+	return totalSize, dataPerc, metaPerc, nil
 }
 
 // parseLogicalVolumeSnapshot parses a raw logical volume name (from lvs command) and checks whether it is a
@@ -765,6 +1137,16 @@ func (d *lvm) parseLogicalVolumeSnapshot(parent Volume, lvmVolName string) strin
 	return ""
 }
 
+// activationMode returns the lvchange activation mode to use for the pool's volumes.
+// On clustered pools, lvmlockd activates volumes exclusively unless lvm.activation_mode says otherwise.
+func (d *lvm) activationMode() string {
+	if d.clustered && d.config["lvm.activation_mode"] != "" {
+		return d.config["lvm.activation_mode"]
+	}
+
+	return "y"
+}
+
 // activateVolume activates an LVM logical volume if not already present. Returns true if activated, false if not.
 func (d *lvm) activateVolume(vol Volume) (bool, error) {
 	var volDevPath string
@@ -778,19 +1160,117 @@ func (d *lvm) activateVolume(vol Volume) (bool, error) {
 	}
 
 	if !util.PathExists(volDevPath) {
-		_, err := subprocess.RunCommand("lvchange", "--activate", "y", "--ignoreactivationskip", volDevPath)
+		_, err := subprocess.RunCommand("lvchange", "--activate", d.activationMode(), "--ignoreactivationskip", volDevPath)
 		if err != nil {
 			return false, fmt.Errorf("Failed to activate LVM logical volume %q: %w", volDevPath, err)
 		}
 
 		d.logger.Debug("Activated logical volume", logger.Ctx{"volName": vol.Name(), "dev": volDevPath})
 
+		if util.IsTrue(vol.ExpandedConfig("lvm.discard")) {
+			if d.usesThinpool() {
+				d.ensureThinPoolDiscards(d.config["lvm.vg_name"], d.thinpoolName())
+			}
+
+			d.checkDiscardSupport(volDevPath)
+		}
+
+		readAhead := vol.ExpandedConfig("lvm.readahead")
+		if readAhead != "" {
+			err = d.setReadAhead(volDevPath, readAhead)
+			if err != nil {
+				return true, err
+			}
+		}
+
 		return true, nil
 	}
 
 	return false, nil
 }
 
+// ensureThinPoolDiscards makes sure that the thin pool processes the discards issued to its thin volumes.
+// Failures are only logged as volumes remain usable without discard support.
+func (d *lvm) ensureThinPoolDiscards(vgName string, thinPoolName string) {
+	poolDevPath := d.lvmDevPath(vgName, "", "", thinPoolName)
+	l := d.logger.AddContext(logger.Ctx{"thinpool": poolDevPath})
+
+	output, err := subprocess.RunCommand("lvs", "--noheadings", "-o", "discards", poolDevPath)
+	if err != nil {
+		l.Warn("Failed getting the discard mode of the thin pool, space freed in volumes may not be reclaimed", logger.Ctx{"err": err})
+		return
+	}
+
+	// Thin pools either ignore discards or process them, optionally passing them down to the physical volumes.
+	if strings.TrimSpace(output) != "ignore" {
+		return
+	}
+
+	_, err = subprocess.RunCommand("lvchange", "--discards", "passdown", poolDevPath)
+	if err != nil {
+		l.Warn("Failed enabling discards on the thin pool, space freed in volumes won't be reclaimed", logger.Ctx{"err": err})
+		return
+	}
+
+	l.Debug("Enabled discards on the thin pool")
+}
+
+// checkDiscardSupport logs a warning if the kernel doesn't support discards on the logical volume.
+func (d *lvm) checkDiscardSupport(volDevPath string) {
+	var stat unix.Stat_t
+
+	err := unix.Stat(volDevPath, &stat)
+	if err != nil {
+		d.logger.Warn("Failed checking discard support of logical volume", logger.Ctx{"dev": volDevPath, "err": err})
+		return
+	}
+
+	maxBytesPath := fmt.Sprintf("/sys/dev/block/%d:%d/queue/discard_max_bytes", unix.Major(uint64(stat.Rdev)), unix.Minor(uint64(stat.Rdev)))
+	content, err := os.ReadFile(maxBytesPath)
+	if err != nil {
+		d.logger.Warn("Failed checking discard support of logical volume", logger.Ctx{"dev": volDevPath, "err": err})
+		return
+	}
+
+	if strings.TrimSpace(string(content)) == "0" {
+		d.logger.Warn("Logical volume doesn't support discards, space freed in it won't be reclaimed", logger.Ctx{"dev": volDevPath})
+	}
+}
+
+// validateReadAhead checks that the value is a valid read-ahead setting, either "auto", "none" or a size.
+func validateReadAhead(value string) error {
+	if value == "auto" || value == "none" {
+		return nil
+	}
+
+	return validate.IsSize(value)
+}
+
+// setReadAhead sets the read-ahead of an active logical volume.
+// The value is either "auto", "none" or a size which gets converted to 512 bytes sectors.
+func (d *lvm) setReadAhead(volDevPath string, value string) error {
+	readAhead := value
+	if value == "" {
+		readAhead = "auto"
+	} else if value != "auto" && value != "none" {
+		sizeBytes, err := units.ParseByteSizeString(value)
+		if err != nil {
+			return err
+		}
+
+		readAhead = strconv.FormatInt(sizeBytes/512, 10)
+	}
+
+	_, err := subprocess.RunCommand("lvchange", "--readahead", readAhead, volDevPath)
+	if err != nil {
+		return fmt.Errorf("Failed to set read-ahead on LVM logical volume %q: %w", volDevPath, err)
+	}
+
+	d.logger.Debug("Set logical volume read-ahead", logger.Ctx{"dev": volDevPath, "readAhead": readAhead})
+
+	return nil
+}
+
 // deactivateVolume deactivates an LVM logical volume if present. Returns true if deactivated, false if not.
 func (d *lvm) deactivateVolume(vol Volume) (bool, error) {
 	var volDevPath string
@@ -813,18 +1293,8 @@ func (d *lvm) deactivateVolume(vol Volume) (bool, error) {
 	}
 
 	if util.PathExists(volDevPath) {
-		// Keep trying to deactivate a few times in case the device is still being flushed.
-		var err error
-		for i := 0; i < 20; i++ {
-			_, err = subprocess.RunCommand("lvchange", "--activate", "n", "--ignoreactivationskip", volDevPath)
-			if err == nil {
-				break
-			}
-
-			logger.Debug("Failed to deactivate LVM logical volume", logger.Ctx{"path": volDevPath, "attempt": i, "err": err})
-			time.Sleep(500 * time.Millisecond)
-		}
-
+		// Retry while the device is busy, in case it's still being flushed or held by udev.
+		_, err := d.runLVMCommand("lvchange", "--activate", "n", "--ignoreactivationskip", volDevPath)
 		if err != nil {
 			return false, fmt.Errorf("Failed to deactivate LVM logical volume %q: %w", volDevPath, err)
 		}
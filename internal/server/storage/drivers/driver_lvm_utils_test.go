@@ -2,6 +2,9 @@ package drivers
 
 import (
 	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Example_lvm_parseLogicalVolumeName() {
@@ -66,3 +69,109 @@ func Example_lvm_parseLogicalVolumeName() {
 	// custom_proj_testvol--with--hyphens.block: Unrecognised
 	// custom_proj_testvol--with--hyphens.block-snap1--with--hyphens.block: snap1-with-hyphens.block
 }
+
+func Test_lvm_parseThinPoolOverProvisioningRatio(t *testing.T) {
+	tests := []struct {
+		name  string
+		out   string
+		ratio float64
+		err   bool
+	}{
+		{
+			name:  "Empty thin pool",
+			out:   "  IncusThinPool,1073741824,\n",
+			ratio: 0,
+		},
+		{
+			name:  "Under-provisioned",
+			out:   "  IncusThinPool,1073741824,\n  containers_c1,536870912,IncusThinPool\n",
+			ratio: 0.5,
+		},
+		{
+			name:  "Over-provisioned",
+			out:   "  IncusThinPool,1073741824,\n  containers_c1,1073741824,IncusThinPool\n  custom_default_vol1,2147483648,IncusThinPool\n",
+			ratio: 3,
+		},
+		{
+			name:  "Other volumes ignored",
+			out:   "  IncusThinPool,1073741824,\n  containers_c1,1073741824,IncusThinPool\n  OtherPool,1073741824,\n  other,4294967296,OtherPool\n  plain,4294967296,\n",
+			ratio: 1,
+		},
+		{
+			name: "Missing thin pool",
+			out:  "  containers_c1,1073741824,IncusThinPool\n",
+			err:  true,
+		},
+		{
+			name: "Invalid size",
+			out:  "  IncusThinPool,1073741824,\n  containers_c1,foo,IncusThinPool\n",
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		ratio, err := parseThinPoolOverProvisioningRatio(test.out, "IncusThinPool")
+		if test.err {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.ratio, ratio, test.name)
+	}
+}
+
+func Test_lvm_extentsForSize(t *testing.T) {
+	extentSize := int64(4 * 1024 * 1024)
+
+	tests := []struct {
+		sizeBytes int64
+		extents   int64
+	}{
+		{sizeBytes: 0, extents: 0},
+		{sizeBytes: 1, extents: 1},
+		{sizeBytes: extentSize - 1, extents: 1},
+		{sizeBytes: extentSize, extents: 1},
+		{sizeBytes: extentSize + 1, extents: 2},
+		{sizeBytes: 10 * extentSize, extents: 10},
+		{sizeBytes: 10*extentSize + 512, extents: 11},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.extents, extentsForSize(test.sizeBytes, extentSize), fmt.Sprintf("%d bytes", test.sizeBytes))
+	}
+}
+
+func Test_lvm_lvmValidateRAID(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    string
+		mirrors  string
+		stripes  string
+		thinpool bool
+		valid    bool
+	}{
+		{name: "No RAID", valid: true},
+		{name: "No RAID on thin pool", thinpool: true, valid: true},
+		{name: "Stripes without RAID", stripes: "2", valid: true},
+		{name: "Mirrors without RAID", mirrors: "1", valid: false},
+		{name: "RAID on thin pool", level: "raid1", thinpool: true, valid: false},
+		{name: "RAID1", level: "raid1", valid: true},
+		{name: "RAID1 with mirrors", level: "raid1", mirrors: "2", valid: true},
+		{name: "RAID1 with no mirror", level: "raid1", mirrors: "0", valid: false},
+		{name: "RAID1 with stripes", level: "raid1", stripes: "2", valid: false},
+		{name: "RAID10 with mirrors and stripes", level: "raid10", mirrors: "1", stripes: "2", valid: true},
+		{name: "RAID5 with stripes", level: "raid5", stripes: "3", valid: true},
+		{name: "RAID5 with mirrors", level: "raid5", mirrors: "1", valid: false},
+		{name: "RAID6 with mirrors", level: "raid6", mirrors: "1", valid: false},
+	}
+
+	for _, test := range tests {
+		err := lvmValidateRAID(test.level, test.mirrors, test.stripes, test.thinpool)
+		if test.valid {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.Error(t, err, test.name)
+		}
+	}
+}
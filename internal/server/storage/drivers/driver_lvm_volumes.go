@@ -175,12 +175,18 @@ func (d *lvm) CreateVolumeFromMigration(vol Volume, conn io.ReadWriteCloser, vol
 				return err
 			}
 
+			// Volumes are normally locked as host-exclusive unless the pool uses another activation mode.
+			activationMode := d.activationMode()
+			if activationMode == "y" {
+				activationMode = "ey"
+			}
+
 			go func(volDevPath string) {
-				// Attempt to re-lock as host-exclusive as soon as possible.
+				// Attempt to re-lock using the pool's activation mode as soon as possible.
 				// This will only happen once the migration on the source system is fully over.
 				// Re-try every 10s until success as we can't predict how long a migration may take (from a few seconds to hours).
 				for {
-					_, err := subprocess.RunCommand("lvchange", "--activate", "ey", "--ignoreactivationskip", volDevPath)
+					_, err := subprocess.RunCommand("lvchange", "--activate", activationMode, "--ignoreactivationskip", volDevPath)
 					if err == nil {
 						break
 					}
@@ -285,8 +291,9 @@ func (d *lvm) HasVolume(vol Volume) (bool, error) {
 func (d *lvm) FillVolumeConfig(vol Volume) error {
 	// Copy volume.* configuration options from pool.
 	// Exclude "block.filesystem" and "block.mount_options" as they depend on volume type (handled below).
-	// Exclude "lvm.stripes", "lvm.stripes.size" as they only work on non-thin storage pools (handled below).
-	err := d.fillVolumeConfig(&vol, "block.filesystem", "block.mount_options", "lvm.stripes", "lvm.stripes.size")
+	// Exclude "lvm.stripes", "lvm.stripes.size", "lvm.raid.level" and "lvm.raid.mirrors" as they only work on
+	// non-thin storage pools (handled below).
+	err := d.fillVolumeConfig(&vol, "block.filesystem", "block.mount_options", "lvm.stripes", "lvm.stripes.size", "lvm.raid.level", "lvm.raid.mirrors")
 	if err != nil {
 		return err
 	}
@@ -326,6 +333,14 @@ func (d *lvm) FillVolumeConfig(vol Volume) error {
 		if vol.config["lvm.stripes.size"] == "" {
 			vol.config["lvm.stripes.size"] = d.config["lvm.stripes.size"]
 		}
+
+		if vol.config["lvm.raid.level"] == "" {
+			vol.config["lvm.raid.level"] = d.config["volume.lvm.raid.level"]
+		}
+
+		if vol.config["lvm.raid.mirrors"] == "" {
+			vol.config["lvm.raid.mirrors"] = d.config["volume.lvm.raid.mirrors"]
+		}
 	}
 
 	return nil
@@ -336,6 +351,10 @@ func (d *lvm) commonVolumeRules() map[string]func(value string) error {
 	return map[string]func(value string) error{
 		"block.mount_options": validate.IsAny,
 		"block.filesystem":    validate.Optional(validate.IsOneOf(blockBackedAllowedFilesystems...)),
+		"lvm.discard":         validate.Optional(validate.IsBool),
+		"lvm.raid.level":      validate.Optional(validate.IsOneOf(lvmRAIDLevels...)),
+		"lvm.raid.mirrors":    validate.Optional(validate.IsUint32),
+		"lvm.readahead":       validate.Optional(validateReadAhead),
 		"lvm.stripes":         validate.Optional(validate.IsUint32),
 		"lvm.stripes.size":    validate.Optional(validate.IsSize),
 	}
@@ -356,6 +375,11 @@ func (d *lvm) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 		return fmt.Errorf("lvm.stripes.size cannot be used with thin pool volumes")
 	}
 
+	err = lvmValidateRAID(vol.config["lvm.raid.level"], vol.config["lvm.raid.mirrors"], vol.config["lvm.stripes"], d.usesThinpool())
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -374,6 +398,33 @@ func (d *lvm) UpdateVolume(vol Volume, changedConfig map[string]string) error {
 		return fmt.Errorf("lvm.stripes cannot be changed")
 	}
 
+	_, changed = changedConfig["lvm.raid.level"]
+	if changed {
+		return fmt.Errorf("lvm.raid.level cannot be changed")
+	}
+
+	_, changed = changedConfig["lvm.raid.mirrors"]
+	if changed {
+		return fmt.Errorf("lvm.raid.mirrors cannot be changed")
+	}
+
+	readAhead, changed := changedConfig["lvm.readahead"]
+	if changed {
+		// Unsetting the key falls back to the pool's default.
+		if readAhead == "" {
+			readAhead = vol.poolConfig["volume.lvm.readahead"]
+		}
+
+		// Apply the new read-ahead right away if the volume is currently active.
+		volDevPath := d.lvmDevPath(d.config["lvm.vg_name"], vol.volType, vol.contentType, vol.name)
+		if util.PathExists(volDevPath) {
+			err := d.setReadAhead(volDevPath, readAhead)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	_, changed = changedConfig["lvm.stripes.size"]
 	if changed {
 		return fmt.Errorf("lvm.stripes.size cannot be changed")
@@ -384,9 +435,19 @@ func (d *lvm) UpdateVolume(vol Volume, changedConfig map[string]string) error {
 
 // GetVolumeUsage returns the disk space used by the volume (this is not currently supported).
 func (d *lvm) GetVolumeUsage(vol Volume) (int64, error) {
-	// Snapshot usage not supported for LVM.
 	if vol.IsSnapshot() {
-		return -1, ErrNotSupported
+		// Snapshot usage is only supported for non-thin snapshots, where it's the copy-on-write space in use.
+		if d.usesThinpool() {
+			return -1, ErrNotSupported
+		}
+
+		volDevPath := d.lvmDevPath(d.config["lvm.vg_name"], vol.volType, vol.contentType, vol.name)
+		_, usedSize, err := d.snapshotVolumeUsage(volDevPath)
+		if err != nil {
+			return -1, err
+		}
+
+		return int64(usedSize), nil
 	}
 
 	// For non-snapshot filesystem volumes, we only return usage when the volume is mounted.
@@ -656,152 +656,153 @@ func genericVFSBackupVolume(d Driver, vol Volume, tarWriter *instancewriter.Inst
 	return nil
 }
 
-// genericVFSBackupUnpack unpacks a non-optimized backup tarball through a storage driver.
-// Returns a post hook function that should be called once the database entries for the restored backup have been
-// created and a revert function that can be used to undo the actions this function performs should something
-// subsequently fail. For VolumeTypeCustom volumes, a nil post hook is returned as it is expected that the DB
-// record be created before the volume is unpacked due to differences in the archive format that allows this.
-func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []string, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
-	// Define function to unpack a volume from a backup tarball file.
-	unpackVolume := func(r io.ReadSeeker, tarArgs []string, unpacker []string, srcPrefix string, mountPath string) error {
-		volTypeName := "container"
-		if vol.IsVMBlock() {
-			volTypeName = "virtual machine"
-		} else if vol.volType == VolumeTypeCustom {
-			volTypeName = "custom"
+// genericVFSUnpackVolume unpacks the volume stored under srcPrefix in a non-optimized backup tarball into the
+// volume mounted at mountPath, replacing its existing content.
+func genericVFSUnpackVolume(d Driver, vol Volume, r io.ReadSeeker, tarArgs []string, unpacker []string, srcPrefix string, mountPath string, op *operations.Operation) error {
+	volTypeName := "container"
+	if vol.IsVMBlock() {
+		volTypeName = "virtual machine"
+	} else if vol.volType == VolumeTypeCustom {
+		volTypeName = "custom"
+	}
+
+	// Clear the volume ready for unpack.
+	err := wipeDirectory(mountPath)
+	if err != nil {
+		return fmt.Errorf("Error clearing volume before unpack: %w", err)
+	}
+
+	// Unpack the filesystem parts of the volume (for containers and custom filesystem volumes that is
+	// the respective root filesystem data or volume itself, and for VMs that is the config volume).
+	// Custom block volumes do not have a filesystem component to their volumes.
+	if !vol.IsCustomBlock() {
+		// Prepare tar arguments.
+		srcParts := strings.Split(srcPrefix, string(os.PathSeparator))
+		args := append(tarArgs, []string{
+			"-",
+			"--xattrs-include=*",
+			"--restrict",
+			"--force-local",
+			"--numeric-owner",
+			"-C", mountPath,
+		}...)
+
+		if vol.Type() == VolumeTypeCustom {
+			// If the volume type is custom, then we need to ensure that we restore the top level
+			// directory's ownership from the backup. We cannot use --strip-components flag because it
+			// removes the top level directory from the unpack list. Instead we use the --transform
+			// flag to remove the prefix path and transform it into the "." current unpack directory.
+			args = append(args, fmt.Sprintf("--transform=s/^%s/./", strings.ReplaceAll(srcPrefix, "/", `\/`)))
+		} else {
+			// For instance volumes, the user created files are stored in the rootfs sub-directory
+			// and so strip-components flag works fine.
+			args = append(args, fmt.Sprintf("--strip-components=%d", len(srcParts)))
 		}
 
-		// Clear the volume ready for unpack.
-		err := wipeDirectory(mountPath)
+		// Directory to unpack comes after other options.
+		args = append(args, srcPrefix)
+
+		// Extract filesystem volume.
+		d.Logger().Debug(fmt.Sprintf("Unpacking %s filesystem volume", volTypeName), logger.Ctx{"source": srcPrefix, "target": mountPath, "args": fmt.Sprintf("%+v", args)})
+		_, err := r.Seek(0, io.SeekStart)
 		if err != nil {
-			return fmt.Errorf("Error clearing volume before unpack: %w", err)
-		}
-
-		// Unpack the filesystem parts of the volume (for containers and custom filesystem volumes that is
-		// the respective root filesystem data or volume itself, and for VMs that is the config volume).
-		// Custom block volumes do not have a filesystem component to their volumes.
-		if !vol.IsCustomBlock() {
-			// Prepare tar arguments.
-			srcParts := strings.Split(srcPrefix, string(os.PathSeparator))
-			args := append(tarArgs, []string{
-				"-",
-				"--xattrs-include=*",
-				"--restrict",
-				"--force-local",
-				"--numeric-owner",
-				"-C", mountPath,
-			}...)
-
-			if vol.Type() == VolumeTypeCustom {
-				// If the volume type is custom, then we need to ensure that we restore the top level
-				// directory's ownership from the backup. We cannot use --strip-components flag because it
-				// removes the top level directory from the unpack list. Instead we use the --transform
-				// flag to remove the prefix path and transform it into the "." current unpack directory.
-				args = append(args, fmt.Sprintf("--transform=s/^%s/./", strings.ReplaceAll(srcPrefix, "/", `\/`)))
-			} else {
-				// For instance volumes, the user created files are stored in the rootfs sub-directory
-				// and so strip-components flag works fine.
-				args = append(args, fmt.Sprintf("--strip-components=%d", len(srcParts)))
-			}
+			return err
+		}
 
-			// Directory to unpack comes after other options.
-			args = append(args, srcPrefix)
+		f, err := os.OpenFile(mountPath, os.O_RDONLY, 0)
+		if err != nil {
+			return fmt.Errorf("Error opening directory: %w", err)
+		}
 
-			// Extract filesystem volume.
-			d.Logger().Debug(fmt.Sprintf("Unpacking %s filesystem volume", volTypeName), logger.Ctx{"source": srcPrefix, "target": mountPath, "args": fmt.Sprintf("%+v", args)})
-			_, err := srcData.Seek(0, io.SeekStart)
-			if err != nil {
-				return err
-			}
+		defer func() { _ = f.Close() }()
 
-			f, err := os.OpenFile(mountPath, os.O_RDONLY, 0)
-			if err != nil {
-				return fmt.Errorf("Error opening directory: %w", err)
-			}
+		allowedCmds := []string{}
+		if len(unpacker) > 0 {
+			allowedCmds = append(allowedCmds, unpacker[0])
+		}
 
-			defer func() { _ = f.Close() }()
+		err = archive.ExtractWithFds("tar", args, allowedCmds, io.NopCloser(r), f)
+		if err != nil {
+			return fmt.Errorf("Error starting unpack: %w", err)
+		}
+	}
 
-			allowedCmds := []string{}
-			if len(unpacker) > 0 {
-				allowedCmds = append(allowedCmds, unpacker[0])
-			}
+	// Extract block file to block volume.
+	if vol.contentType == ContentTypeBlock {
+		targetPath, err := d.GetVolumeDiskPath(vol)
+		if err != nil {
+			return err
+		}
 
-			err = archive.ExtractWithFds("tar", args, allowedCmds, io.NopCloser(r), f)
-			if err != nil {
-				return fmt.Errorf("Error starting unpack: %w", err)
-			}
+		srcFile := fmt.Sprintf("%s.%s", srcPrefix, genericVolumeBlockExtension)
+
+		tr, cancelFunc, err := archive.CompressedTarReader(context.Background(), r, unpacker, mountPath)
+		if err != nil {
+			return err
 		}
 
-		// Extract block file to block volume.
-		if vol.contentType == ContentTypeBlock {
-			targetPath, err := d.GetVolumeDiskPath(vol)
-			if err != nil {
-				return err
-			}
+		defer cancelFunc()
 
-			srcFile := fmt.Sprintf("%s.%s", srcPrefix, genericVolumeBlockExtension)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break // End of archive.
+			}
 
-			tr, cancelFunc, err := archive.CompressedTarReader(context.Background(), r, unpacker, mountPath)
 			if err != nil {
 				return err
 			}
 
-			defer cancelFunc()
-
-			for {
-				hdr, err := tr.Next()
-				if err == io.EOF {
-					break // End of archive.
-				}
+			if hdr.Name == srcFile {
+				var allowUnsafeResize bool
 
+				// Open block file (use O_CREATE to support drivers that use image files).
+				to, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0644)
 				if err != nil {
-					return err
+					return fmt.Errorf("Error opening file for writing %q: %w", targetPath, err)
 				}
 
-				if hdr.Name == srcFile {
-					var allowUnsafeResize bool
-
-					// Open block file (use O_CREATE to support drivers that use image files).
-					to, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0644)
-					if err != nil {
-						return fmt.Errorf("Error opening file for writing %q: %w", targetPath, err)
-					}
-
-					defer func() { _ = to.Close() }()
-
-					// Restore original size of volume from raw block backup file size.
-					d.Logger().Debug("Setting volume size from source", logger.Ctx{"source": srcFile, "target": targetPath, "size": hdr.Size})
+				defer func() { _ = to.Close() }()
 
-					// Allow potentially destructive resize of volume as we are going to be
-					// overwriting it entirely anyway. This allows shrinking of block volumes.
-					allowUnsafeResize = true
-					err = d.SetVolumeQuota(vol, fmt.Sprintf("%d", hdr.Size), allowUnsafeResize, op)
-					if err != nil {
-						return err
-					}
+				// Restore original size of volume from raw block backup file size.
+				d.Logger().Debug("Setting volume size from source", logger.Ctx{"source": srcFile, "target": targetPath, "size": hdr.Size})
 
-					logMsg := "Unpacking virtual machine block volume"
-					if vol.volType == VolumeTypeCustom {
-						logMsg = "Unpacking custom block volume"
-					}
+				// Allow potentially destructive resize of volume as we are going to be
+				// overwriting it entirely anyway. This allows shrinking of block volumes.
+				allowUnsafeResize = true
+				err = d.SetVolumeQuota(vol, fmt.Sprintf("%d", hdr.Size), allowUnsafeResize, op)
+				if err != nil {
+					return err
+				}
 
-					d.Logger().Debug(logMsg, logger.Ctx{"source": srcFile, "target": targetPath})
-					_, err = io.Copy(to, tr)
-					if err != nil {
-						return err
-					}
+				logMsg := "Unpacking virtual machine block volume"
+				if vol.volType == VolumeTypeCustom {
+					logMsg = "Unpacking custom block volume"
+				}
 
-					cancelFunc()
-					return to.Close()
+				d.Logger().Debug(logMsg, logger.Ctx{"source": srcFile, "target": targetPath})
+				_, err = io.Copy(to, tr)
+				if err != nil {
+					return err
 				}
-			}
 
-			return fmt.Errorf("Could not find %q", srcFile)
+				cancelFunc()
+				return to.Close()
+			}
 		}
 
-		return nil
+		return fmt.Errorf("Could not find %q", srcFile)
 	}
 
+	return nil
+}
+
+// genericVFSBackupUnpack unpacks a non-optimized backup tarball through a storage driver.
+// Returns a post hook function that should be called once the database entries for the restored backup have been
+// created and a revert function that can be used to undo the actions this function performs should something
+// subsequently fail. For VolumeTypeCustom volumes, a nil post hook is returned as it is expected that the DB
+// record be created before the volume is unpacked due to differences in the archive format that allows this.
+func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []string, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -851,7 +852,7 @@ func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []str
 	for _, snapName := range snapshots {
 		err = vol.MountTask(func(mountPath string, op *operations.Operation) error {
 			backupSnapshotPrefix := fmt.Sprintf("%s/%s", backupSnapshotsPrefix, snapName)
-			return unpackVolume(srcData, tarArgs, unpacker, backupSnapshotPrefix, mountPath)
+			return genericVFSUnpackVolume(d, vol, srcData, tarArgs, unpacker, backupSnapshotPrefix, mountPath, op)
 		}, op)
 		if err != nil {
 			return nil, nil, err
@@ -886,7 +887,7 @@ func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []str
 	}
 
 	mountPath := vol.MountPath()
-	err = unpackVolume(srcData, tarArgs, unpacker, backupPrefix, mountPath)
+	err = genericVFSUnpackVolume(d, vol, srcData, tarArgs, unpacker, backupPrefix, mountPath, op)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -925,6 +926,38 @@ func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []str
 	return postHook, cleanup, nil
 }
 
+// UnpackBackupVolume replaces the content of an existing instance volume with the one stored in a non-optimized
+// backup tarball. The snapshots of the volume are left untouched.
+func UnpackBackupVolume(d Driver, vol Volume, srcData io.ReadSeeker, op *operations.Operation) error {
+	if vol.volType == VolumeTypeCustom {
+		return fmt.Errorf("Unpacking backups into existing custom volumes isn't supported")
+	}
+
+	_, err := srcData.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	tarArgs, _, unpacker, err := archive.DetectCompressionFile(srcData)
+	if err != nil {
+		return err
+	}
+
+	backupPrefix := "backup/container"
+	if vol.IsVMBlock() {
+		backupPrefix = "backup/virtual-machine"
+	}
+
+	return vol.MountTask(func(mountPath string, op *operations.Operation) error {
+		err := genericVFSUnpackVolume(d, vol, srcData, tarArgs, unpacker, backupPrefix, mountPath, op)
+		if err != nil {
+			return err
+		}
+
+		return vol.EnsureMountPath()
+	}, op)
+}
+
 // genericVFSCopyVolume copies a volume and its snapshots using a non-optimized method.
 // initVolume is run against the main volume (not the snapshots) and is often used for quota initialization.
 func genericVFSCopyVolume(d Driver, initVolume func(vol Volume) (revert.Hook, error), vol Volume, srcVol Volume, srcSnapshots []Volume, refresh bool, allowInconsistent bool, op *operations.Operation) error {
@@ -7,6 +7,7 @@ import (
 	"github.com/lxc/incus/v6/internal/instancewriter"
 	"github.com/lxc/incus/v6/internal/revert"
 	"github.com/lxc/incus/v6/internal/server/backup"
+	"github.com/lxc/incus/v6/internal/server/metrics"
 	"github.com/lxc/incus/v6/internal/server/migration"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -46,6 +47,7 @@ type Driver interface {
 	// Unmount unmounts a storage pool if needed, returns true if unmounted, false if was not mounted.
 	Unmount() (bool, error)
 	GetResources() (*api.ResourcesStoragePool, error)
+	GetMetrics() (*metrics.MetricSet, error)
 	Validate(config map[string]string) error
 	Update(changedConfig map[string]string) error
 	ApplyPatch(name string) error
@@ -147,6 +147,24 @@ func (t TransferManager) UploadAllFiles(bucketName string, srcData io.ReadSeeker
 	return nil
 }
 
+// UploadFile uploads a single file to the bucket under the given object name.
+func (t TransferManager) UploadFile(bucketName string, objectName string, src io.Reader, size int64) error {
+	logger.Debugf("Uploading %s to bucket %s", objectName, bucketName)
+	logger.Debugf("Endpoint: %s", t.getEndpoint())
+
+	minioClient, err := t.getMinioClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = minioClient.PutObject(context.TODO(), bucketName, objectName, src, size, minio.PutObjectOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (t TransferManager) getMinioClient() (*minio.Client, error) {
 	bucketLookup := minio.BucketLookupPath
 	creds := credentials.NewStaticV4(t.accessKey, t.secretKey, "")
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"regexp"
 
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -21,7 +22,7 @@ func JoinTokenDecode(input string) (*api.ClusterMemberJoinToken, error) {
 		return nil, err
 	}
 
-	if j.ServerName == "" {
+	if j.ServerName == "" && j.ServerNamePattern == "" {
 		return nil, fmt.Errorf("No server name in join token")
 	}
 
@@ -39,3 +40,14 @@ func JoinTokenDecode(input string) (*api.ClusterMemberJoinToken, error) {
 
 	return &j, nil
 }
+
+// JoinTokenServerNameMatch checks whether the server name matches the join token's server name pattern.
+// The pattern is a regular expression which must match the whole name.
+func JoinTokenServerNameMatch(pattern string, serverName string) (bool, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return false, fmt.Errorf("Invalid server name pattern %q: %w", pattern, err)
+	}
+
+	return re.MatchString(serverName), nil
+}
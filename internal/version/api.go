@@ -408,6 +408,60 @@ var APIExtensions = []string{
 	"network_integrations",
 	"instance_memory_swap_bytes",
 	"network_bridge_external_create",
+	"network_load_balancer_health_check",
+	"network_load_balancer_state",
+	"logging_collector",
+	"cluster_evacuate_failure_domain",
+	"cluster_evacuate_skip",
+	"cluster_evacuate_unplaceable",
+	"cluster_evacuate_bandwidth_limit",
+	"cluster_quorum",
+	"cluster_evacuate_stopped",
+	"storage_lvm_thinpool_zero",
+	"storage_lvm_readahead",
+	"instance_exec_extra_fds",
+	"instance_exec_signal",
+	"instances_architecture_filter",
+	"cluster_spread_failure_domains",
+	"instances_autostart_parallelism",
+	"instances_autostart_network_timeout",
+	"images_post_download_hook",
+	"instances_rebuild_reset_identity",
+	"certificate_all_projects",
+	"snapshots_schedule_jitter",
+	"snapshots_schedule_timezone",
+	"snapshots_hooks",
+	"instance_snapshot_promote",
+	"cluster_evacuate_workers",
+	"cluster_member_update_preview",
+	"cluster_group_config",
+	"clustering_evacuation_dry_run",
+	"clustering_evacuation_parallel",
+	"cluster_member_resources",
+	"clustering_join_token_pattern",
+	"clustering_drain",
+	"metrics_storage_pool_lvm",
+	"clustering_join_member_config_errors",
+	"clustering_evacuation_targets",
+	"instance_file_xattrs",
+	"cluster_failback",
+	"instance_file_sparse",
+	"clustering_scheduler_priority",
+	"instance_backup_verify",
+	"backups_retention",
+	"event_lifecycle_cluster_member_online",
+	"clustering_members_bulk_update",
+	"backups_target",
+	"backup_placement",
+	"storage_lvm_raid",
+	"instance_backup_partial_restore",
+	"storage_lvm_thinpool_overprovisioning",
+	"storage_lvm_discard",
+	"console_read_only",
+	"console_record",
+	"network_zone_record_healthcheck",
+	"network_zone_reverse_records",
+	"storage_lvm_activation_mode",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
@@ -84,6 +84,13 @@ type Certificate struct {
 	// Read only: true
 	// Example: fd200419b271f1dc2a5591b693cc5774b7f234e1ff8c6b78ad703b6888fe2b69
 	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+
+	// Whether the certificate has access to all projects, regardless of its projects list
+	// Read only: true
+	// Example: false
+	//
+	// API extension: certificate_all_projects
+	AllProjects bool `json:"all_projects" yaml:"all_projects"`
 }
 
 // Writable converts a full Certificate struct into a CertificatePut struct (filters read-only fields).
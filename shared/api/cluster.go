@@ -25,6 +25,30 @@ type Cluster struct {
 	//
 	// API extension: clustering_join
 	MemberConfig []ClusterMemberConfigKey `json:"member_config" yaml:"member_config"`
+
+	// Health of the cluster database quorum (only set when clustered)
+	//
+	// API extension: cluster_quorum
+	Quorum *ClusterQuorum `json:"quorum,omitempty" yaml:"quorum,omitempty"`
+}
+
+// ClusterQuorum represents the health of the cluster database quorum.
+//
+// swagger:model
+//
+// API extension: cluster_quorum.
+type ClusterQuorum struct {
+	// Number of database voters
+	// Example: 3
+	Voters int `json:"voters" yaml:"voters"`
+
+	// Number of offline database voters
+	// Example: 1
+	OfflineVoters int `json:"offline_voters" yaml:"offline_voters"`
+
+	// Whether enough voters are online for the database to have quorum
+	// Example: true
+	Healthy bool `json:"healthy" yaml:"healthy"`
 }
 
 // ClusterMemberConfigKey represents a single config key that a new member of
@@ -97,6 +121,12 @@ type ClusterMembersPost struct {
 	// The name of the new cluster member
 	// Example: server02
 	ServerName string `json:"server_name" yaml:"server_name"`
+
+	// Regular expression the name of the new cluster member must match (instead of an exact name)
+	// Example: web-[0-9]+
+	//
+	// API extension: clustering_join_token_pattern
+	ServerNamePattern string `json:"server_name_pattern" yaml:"server_name_pattern"`
 }
 
 // ClusterMemberJoinToken represents the fields contained within an encoded cluster member join token.
@@ -109,6 +139,12 @@ type ClusterMemberJoinToken struct {
 	// Example: server02
 	ServerName string `json:"server_name" yaml:"server_name"`
 
+	// Regular expression the name of the new cluster member must match (instead of an exact name)
+	// Example: web-[0-9]+
+	//
+	// API extension: clustering_join_token_pattern
+	ServerNamePattern string `json:"server_name_pattern,omitempty" yaml:"server_name_pattern,omitempty"`
+
 	// The fingerprint of the network certificate
 	// Example: 57bb0ff4340b5bb28517e062023101adf788c37846dc8b619eb2c3cb4ef29436
 	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
@@ -180,6 +216,44 @@ type ClusterMember struct {
 	//
 	// API extension: clustering_architecture
 	Architecture string `json:"architecture" yaml:"architecture"`
+
+	// Whether new instances are prevented from being placed on the member
+	// Example: false
+	//
+	// API extension: clustering_drain
+	Cordoned bool `json:"cordoned" yaml:"cordoned"`
+
+	// Summary of the member's resources and load (only set when requested)
+	//
+	// API extension: cluster_member_resources
+	Resources *ClusterMemberResources `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// ClusterMemberResources represents a summary of the resources and load of a cluster member
+//
+// swagger:model
+//
+// API extension: cluster_member_resources.
+type ClusterMemberResources struct {
+	// Total number of CPU threads
+	// Example: 16
+	CPUTotal uint64 `json:"cpu_total" yaml:"cpu_total"`
+
+	// Used CPU, as the load average over the last minute
+	// Example: 2.5
+	CPUUsed float64 `json:"cpu_used" yaml:"cpu_used"`
+
+	// Total memory (in bytes)
+	// Example: 68719476736
+	MemoryTotal uint64 `json:"memory_total" yaml:"memory_total"`
+
+	// Used memory (in bytes)
+	// Example: 34359738368
+	MemoryUsed uint64 `json:"memory_used" yaml:"memory_used"`
+
+	// Number of instances located on the member
+	// Example: 12
+	Instances int `json:"instances" yaml:"instances"`
 }
 
 // Writable converts a full Profile struct into a ProfilePut struct (filters read-only fields).
@@ -224,6 +298,49 @@ type ClusterMemberPut struct {
 	Groups []string `json:"groups" yaml:"groups"`
 }
 
+// ClusterMemberPutPreview represents the changes an update of a cluster member would make
+//
+// swagger:model
+//
+// API extension: cluster_member_update_preview.
+type ClusterMemberPutPreview struct {
+	// Configuration keys that would be added or changed, with their new value
+	// Example: {"scheduler.instance": "manual"}
+	ConfigSet map[string]string `json:"config_set" yaml:"config_set"`
+
+	// Configuration keys that would be removed
+	// Example: ["user.rack"]
+	ConfigUnset []string `json:"config_unset" yaml:"config_unset"`
+
+	// Roles that would be added to the cluster member
+	// Example: ["event-hub"]
+	RolesAdded []string `json:"roles_added" yaml:"roles_added"`
+
+	// Roles that would be removed from the cluster member
+	// Example: ["ovn-chassis"]
+	RolesRemoved []string `json:"roles_removed" yaml:"roles_removed"`
+
+	// Cluster groups the member would be added to
+	// Example: ["group2"]
+	GroupsAdded []string `json:"groups_added" yaml:"groups_added"`
+
+	// Cluster groups the member would be removed from
+	// Example: ["default"]
+	GroupsRemoved []string `json:"groups_removed" yaml:"groups_removed"`
+
+	// Whether the description would change
+	// Example: false
+	DescriptionChanged bool `json:"description_changed" yaml:"description_changed"`
+
+	// Whether the failure domain would change
+	// Example: true
+	FailureDomainChanged bool `json:"failure_domain_changed" yaml:"failure_domain_changed"`
+
+	// Whether the role change would be sent to all cluster members through a heartbeat
+	// Example: true
+	HeartbeatNotification bool `json:"heartbeat_notification" yaml:"heartbeat_notification"`
+}
+
 // ClusterCertificatePut represents the certificate and key pair for all cluster members
 //
 // swagger:model
@@ -245,7 +362,7 @@ type ClusterCertificatePut struct {
 //
 // API extension: clustering_evacuation.
 type ClusterMemberStatePost struct {
-	// The action to be performed. Valid actions are "evacuate" and "restore".
+	// The action to be performed. Valid actions are "evacuate", "restore", "drain" and "uncordon".
 	// Example: evacuate
 	Action string `json:"action" yaml:"action"`
 
@@ -254,6 +371,30 @@ type ClusterMemberStatePost struct {
 	//
 	// API extension: clustering_evacuate_mode
 	Mode string `json:"mode" yaml:"mode"`
+
+	// Only report where the instances would be moved to, without evacuating anything
+	// Example: true
+	//
+	// API extension: clustering_evacuation_dry_run
+	DryRun bool `json:"dry-run" yaml:"dry-run"`
+
+	// Maximum number of instances evacuated at once (defaults to 1)
+	// Example: 4
+	//
+	// API extension: clustering_evacuation_parallel
+	Parallel int `json:"parallel" yaml:"parallel"`
+
+	// Stop evacuating further instances as soon as one fails
+	// Example: true
+	//
+	// API extension: clustering_evacuation_parallel
+	StopOnError bool `json:"stop-on-error" yaml:"stop-on-error"`
+
+	// Cluster members the instances can be moved to (defaults to automatic placement)
+	// Example: ["server02"]
+	//
+	// API extension: clustering_evacuation_targets
+	Targets []string `json:"targets" yaml:"targets"`
 }
 
 // ClusterGroupsPost represents the fields available for a new cluster group.
@@ -303,6 +444,12 @@ type ClusterGroupPut struct {
 	// List of members in this group
 	// Example: ["server01", "server02"]
 	Members []string `json:"members" yaml:"members"`
+
+	// Default configuration for the members of this group
+	// Example: {"scheduler.instance": "group"}
+	//
+	// API extension: cluster_group_config
+	Config map[string]string `json:"config" yaml:"config"`
 }
 
 // Writable converts a full ClusterGroup struct into a ClusterGroupPut struct (filters read-only fields).
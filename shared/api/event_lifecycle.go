@@ -13,6 +13,8 @@ const (
 	EventLifecycleClusterGroupRenamed               = "cluster-group-renamed"
 	EventLifecycleClusterGroupUpdated               = "cluster-group-updated"
 	EventLifecycleClusterMemberAdded                = "cluster-member-added"
+	EventLifecycleClusterMemberOffline              = "cluster-member-offline"
+	EventLifecycleClusterMemberOnline               = "cluster-member-online"
 	EventLifecycleClusterMemberRemoved              = "cluster-member-removed"
 	EventLifecycleClusterMemberRenamed              = "cluster-member-renamed"
 	EventLifecycleClusterMemberUpdated              = "cluster-member-updated"
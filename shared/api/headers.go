@@ -1,10 +1,12 @@
 package api
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // ParseFileHeaders extracts the file ownership, type, mode and operation type from HTTP headers.
@@ -53,3 +55,26 @@ func ParseFileHeaders(headers http.Header) (int64, int64, int, string, string) {
 
 	return uid, gid, mode, fileType, writeMode
 }
+
+// ParseFileXattrHeaders extracts the extended attributes from HTTP headers.
+// Each attribute is passed in its own X-Incus-xattr header, as its name followed by "=" and its base64 encoded value.
+func ParseFileXattrHeaders(headers http.Header) (map[string]string, error) {
+	values := headers.Values("X-Incus-xattr")
+
+	xattrs := make(map[string]string, len(values))
+	for _, value := range values {
+		name, encodedValue, ok := strings.Cut(value, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("Invalid extended attribute header %q", value)
+		}
+
+		decodedValue, err := base64.StdEncoding.DecodeString(encodedValue)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for extended attribute %q: %w", name, err)
+		}
+
+		xattrs[name] = string(decodedValue)
+	}
+
+	return xattrs, nil
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func ExampleParseFileXattrHeaders() {
+	headers := http.Header{}
+	headers.Add("X-Incus-xattr", "user.comment=aGVsbG8=")
+	headers.Add("X-Incus-xattr", "user.empty=")
+
+	xattrs, err := ParseFileXattrHeaders(headers)
+	fmt.Println(xattrs, err)
+
+	headers.Add("X-Incus-xattr", "user.invalid")
+
+	_, err = ParseFileXattrHeaders(headers)
+	fmt.Println(err)
+
+	// Output: map[user.comment:hello user.empty:] <nil>
+	// Invalid extended attribute header "user.invalid"
+}
@@ -195,6 +195,12 @@ type InstancePut struct {
 type InstanceRebuildPost struct {
 	// Rebuild source
 	Source InstanceSource `json:"source" yaml:"source"`
+
+	// Whether to give the instance a new identity (UUID and MAC addresses) instead of preserving it
+	// Example: false
+	//
+	// API extension: instances_rebuild_reset_identity
+	ResetIdentity bool `json:"reset_identity" yaml:"reset_identity"`
 }
 
 // Instance represents an instance.
@@ -70,3 +70,55 @@ type InstanceBackupPost struct {
 	// Example: backup1
 	Name string `json:"name" yaml:"name"`
 }
+
+// InstanceBackupVerification represents the result of an instance backup verification.
+//
+// swagger:model
+//
+// API extension: instance_backup_verify.
+type InstanceBackupVerification struct {
+	// Whether the backup could be fully read and all its files match the backup manifest
+	// Example: true
+	Valid bool `json:"valid" yaml:"valid"`
+
+	// Problem preventing the backup from being fully verified
+	// Example: Backup has no manifest
+	Error string `json:"error" yaml:"error"`
+
+	// Verification result of each file of the backup
+	Files []InstanceBackupVerificationFile `json:"files" yaml:"files"`
+}
+
+// InstanceBackupVerificationFile represents the verification result of a file in an instance backup.
+//
+// swagger:model
+//
+// API extension: instance_backup_verify.
+type InstanceBackupVerificationFile struct {
+	// Path of the file in the backup
+	// Example: backup/container/rootfs/etc/hostname
+	Path string `json:"path" yaml:"path"`
+
+	// Verification status (one of "ok", "mismatch", "missing" or "unexpected")
+	// Example: ok
+	Status string `json:"status" yaml:"status"`
+
+	// SHA256 checksum recorded in the backup manifest
+	// Example: 5bd0b0ec3da0f27bbcdba2e8c43c0b8a2e4c3a0f3b0ca7d0c4c2c6f0f0d1a2b3
+	Expected string `json:"expected,omitempty" yaml:"expected,omitempty"`
+
+	// SHA256 checksum of the file as read from the backup
+	// Example: 5bd0b0ec3da0f27bbcdba2e8c43c0b8a2e4c3a0f3b0ca7d0c4c2c6f0f0d1a2b3
+	Actual string `json:"actual,omitempty" yaml:"actual,omitempty"`
+}
+
+// InstanceBackupRestorePost represents the fields available when restoring a backup onto its instance.
+//
+// swagger:model
+//
+// API extension: instance_backup_partial_restore.
+type InstanceBackupRestorePost struct {
+	// What to restore from the backup (one of "full" (default), "config" or "data")
+	// Example: config
+	Mode string `json:"mode" yaml:"mode"`
+}
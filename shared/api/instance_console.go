@@ -27,4 +27,10 @@ type InstanceConsolePost struct {
 	//
 	// API extension: console_vga_type
 	Type string `json:"type" yaml:"type"`
+
+	// Whether to only watch the console, dropping any input (console type only)
+	// Example: false
+	//
+	// API extension: console_read_only
+	ReadOnly bool `json:"read_only" yaml:"read_only"`
 }
@@ -53,4 +53,10 @@ type InstanceExecPost struct {
 	// Current working directory for the command
 	// Example: /home/foo/
 	Cwd string `json:"cwd" yaml:"cwd"`
+
+	// Number of additional file descriptors (starting at 3) the command can write to (requires non-interactive)
+	// Example: 1
+	//
+	// API extension: instance_exec_extra_fds
+	ExtraFDs int `json:"extra_fds" yaml:"extra_fds"`
 }
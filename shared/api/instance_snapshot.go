@@ -45,6 +45,12 @@ type InstanceSnapshotPost struct {
 	// Whether to perform a live migration (requires migration)
 	// Example: false
 	Live bool `json:"live,omitempty" yaml:"live,omitempty"`
+
+	// Whether to promote the snapshot to a new standalone instance (named after Name)
+	// Example: false
+	//
+	// API extension: instance_snapshot_promote
+	Promote bool `json:"promote,omitempty" yaml:"promote,omitempty"`
 }
 
 // InstanceSnapshotPut represents the modifiable fields of an instance snapshot.
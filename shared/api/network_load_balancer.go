@@ -157,3 +157,46 @@ func (f *NetworkLoadBalancer) Etag() []any {
 func (f *NetworkLoadBalancer) Writable() NetworkLoadBalancerPut {
 	return f.NetworkLoadBalancerPut
 }
+
+// NetworkLoadBalancerState is used for showing current state of a load balancer
+//
+// swagger:model
+//
+// API extension: network_load_balancer_state.
+type NetworkLoadBalancerState struct {
+	// Health of the load balancer backends, indexed by backend name
+	BackendHealth map[string]NetworkLoadBalancerStateBackendHealth `json:"backend_health" yaml:"backend_health"`
+}
+
+// NetworkLoadBalancerStateBackendHealth represents the health of a load balancer backend
+//
+// swagger:model
+//
+// API extension: network_load_balancer_state.
+type NetworkLoadBalancerStateBackendHealth struct {
+	// Target address of the backend
+	// Example: 198.51.100.2
+	Address string `json:"address" yaml:"address"`
+
+	// Health of the backend's target ports
+	Ports []NetworkLoadBalancerStateBackendHealthPort `json:"ports" yaml:"ports"`
+}
+
+// NetworkLoadBalancerStateBackendHealthPort represents the health of a load balancer backend's target port
+//
+// swagger:model
+//
+// API extension: network_load_balancer_state.
+type NetworkLoadBalancerStateBackendHealthPort struct {
+	// Protocol of the target port (either tcp or udp)
+	// Example: tcp
+	Protocol string `json:"protocol" yaml:"protocol"`
+
+	// Target port
+	// Example: 80
+	Port int `json:"port" yaml:"port"`
+
+	// Health status of the port (online, offline, error or unknown)
+	// Example: online
+	Status string `json:"status" yaml:"status"`
+}
@@ -157,12 +157,16 @@ func (op *Operation) ToClusterJoinToken() (*ClusterMemberJoinToken, error) {
 		return nil, err
 	}
 
+	// Tokens created for a name pattern rather than an exact name.
+	serverNamePattern, _ := op.Metadata["serverNamePattern"].(string)
+
 	joinToken := ClusterMemberJoinToken{
-		ServerName:  serverName,
-		Secret:      secret,
-		Fingerprint: fingerprint,
-		Addresses:   make([]string, 0, len(addresses)),
-		ExpiresAt:   expiresAt,
+		ServerName:        serverName,
+		ServerNamePattern: serverNamePattern,
+		Secret:            secret,
+		Fingerprint:       fingerprint,
+		Addresses:         make([]string, 0, len(addresses)),
+		ExpiresAt:         expiresAt,
 	}
 
 	for i, address := range addresses {
@@ -790,6 +790,12 @@ type ResourcesStoragePoolSpace struct {
 	// Total disk space (bytes)
 	// Example: 420100937728
 	Total uint64 `json:"total" yaml:"total"`
+
+	// Ratio of the space provisioned to volumes to the total disk space (only set for thin provisioned pools)
+	// Example: 1.5
+	//
+	// API extension: storage_lvm_thinpool_overprovisioning
+	OverProvisioningRatio float64 `json:"over_provisioning_ratio,omitempty" yaml:"over_provisioning_ratio,omitempty"`
 }
 
 // ResourcesStoragePoolInodes represents the inodes available to a given storage pool
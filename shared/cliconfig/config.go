@@ -21,6 +21,10 @@ type Config struct {
 	// communication with the named daemon
 	Remotes map[string]Remote `yaml:"remotes"`
 
+	// RemoteGroups defines a map of group names to the names of the
+	// remotes in the group
+	RemoteGroups map[string][]string `yaml:"remote-groups,omitempty"`
+
 	// Command line aliases for `incus`
 	Aliases map[string]string `yaml:"aliases"`
 
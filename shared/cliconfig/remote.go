@@ -1,37 +1,152 @@
 package cliconfig
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"runtime"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"golang.org/x/crypto/ssh"
 
 	"github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/shared/api"
+	localtls "github.com/lxc/incus/v6/shared/tls"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
 // Remote holds details for communication with a remote daemon.
 type Remote struct {
-	Addr      string `yaml:"addr"`
-	AuthType  string `yaml:"auth_type,omitempty"`
-	KeepAlive int    `yaml:"keepalive,omitempty"`
-	Project   string `yaml:"project,omitempty"`
-	Protocol  string `yaml:"protocol,omitempty"`
-	Public    bool   `yaml:"public"`
-	Global    bool   `yaml:"-"`
-	Static    bool   `yaml:"-"`
+	Addr            string `yaml:"addr"`
+	AuthType        string `yaml:"auth_type,omitempty"`
+	CertFingerprint string `yaml:"cert_fingerprint,omitempty"`
+	KeepAlive       int    `yaml:"keepalive,omitempty"`
+	Project         string `yaml:"project,omitempty"`
+	Protocol        string `yaml:"protocol,omitempty"`
+	Proxy           string `yaml:"proxy,omitempty"`
+	Public          bool   `yaml:"public"`
+	Retries         int    `yaml:"retries,omitempty"`
+	Global          bool   `yaml:"-"`
+	Static          bool   `yaml:"-"`
+}
+
+// VerifyCertFingerprint checks that the certificate matches the pinned fingerprint, if any.
+func (r *Remote) VerifyCertFingerprint(cert *x509.Certificate) error {
+	if r.CertFingerprint == "" {
+		return nil
+	}
+
+	pinned := strings.ToLower(strings.ReplaceAll(r.CertFingerprint, ":", ""))
+	if localtls.CertFingerprint(cert) != pinned {
+		return fmt.Errorf("Server certificate fingerprint %q doesn't match the pinned fingerprint %q", localtls.CertFingerprint(cert), pinned)
+	}
+
+	return nil
+}
+
+// pinnedTransport wraps a transport to only allow connections to servers matching the pinned fingerprint.
+type pinnedTransport struct {
+	transport *http.Transport
+}
+
+func (t *pinnedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req)
+}
+
+func (t *pinnedTransport) Transport() *http.Transport {
+	return t.transport
+}
+
+// retryTransport wraps a transport to retry requests failing because of transient errors.
+type retryTransport struct {
+	next      http.RoundTripper
+	transport *http.Transport
+	retries   int
+}
+
+// retryBackoffMax is the maximum delay between two attempts.
+const retryBackoffMax = 10 * time.Second
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := 500 * time.Millisecond
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			// The request body was consumed by the previous attempt.
+			if req.Body != nil && req.Body != http.NoBody {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+
+				req = req.Clone(req.Context())
+				req.Body = body
+			}
+
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(backoff):
+			}
+
+			backoff = min(backoff*2, retryBackoffMax)
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.retries || !retryableRequest(req, resp, err) {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+	}
+}
+
+func (t *retryTransport) Transport() *http.Transport {
+	return t.transport
+}
+
+// retryableRequest returns whether a failed request can safely be sent again.
+// Requests which couldn't reach the server are always retried, while other failures are only retried for
+// requests which don't change anything on the server.
+func retryableRequest(req *http.Request, resp *http.Response, err error) bool {
+	// The body can't be sent again.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+	}
+
+	if !slices.Contains([]string{http.MethodGet, http.MethodHead, http.MethodOptions}, req.Method) {
+		return false
+	}
+
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	return slices.Contains([]int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}, resp.StatusCode)
 }
 
 // ParseRemote splits remote and object.
@@ -262,6 +377,52 @@ func (c *Config) getConnectionArgs(name string) (*incus.ConnectionArgs, error) {
 		return &args, nil
 	}
 
+	// Per-remote proxy
+	if remote.Proxy != "" {
+		proxyURL, err := url.Parse(remote.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid proxy for remote %q: %w", name, err)
+		}
+
+		if !slices.Contains([]string{"http", "https", "socks5", "socks5h"}, proxyURL.Scheme) {
+			return nil, fmt.Errorf("Unsupported proxy scheme %q for remote %q", proxyURL.Scheme, name)
+		}
+
+		args.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	// Server certificate fingerprint pinning
+	if remote.CertFingerprint != "" {
+		args.TransportWrapper = func(t *http.Transport) incus.HTTPTransporter {
+			t.TLSClientConfig.VerifyConnection = func(state tls.ConnectionState) error {
+				if len(state.PeerCertificates) == 0 {
+					return fmt.Errorf("No server certificate presented")
+				}
+
+				return remote.VerifyCertFingerprint(state.PeerCertificates[0])
+			}
+
+			return &pinnedTransport{transport: t}
+		}
+	}
+
+	// Retry policy
+	if remote.Retries < 0 {
+		return nil, fmt.Errorf("Invalid number of retries for remote %q", name)
+	}
+
+	if remote.Retries > 0 {
+		wrapper := args.TransportWrapper
+		args.TransportWrapper = func(t *http.Transport) incus.HTTPTransporter {
+			var next http.RoundTripper = t
+			if wrapper != nil {
+				next = wrapper(t)
+			}
+
+			return &retryTransport{next: next, transport: t, retries: remote.Retries}
+		}
+	}
+
 	// Server certificate
 	if util.PathExists(c.ServerCertPath(name)) {
 		content, err := os.ReadFile(c.ServerCertPath(name))
@@ -0,0 +1,88 @@
+package cliconfig
+
+import (
+	"fmt"
+	"slices"
+)
+
+// GetRemoteGroup returns the names of the remotes in the group.
+func (c *Config) GetRemoteGroup(name string) ([]string, error) {
+	remotes, ok := c.RemoteGroups[name]
+	if !ok {
+		return nil, fmt.Errorf("The remote group %q doesn't exist", name)
+	}
+
+	for _, remote := range remotes {
+		_, ok := c.Remotes[remote]
+		if !ok {
+			return nil, fmt.Errorf("The remote %q of group %q doesn't exist", remote, name)
+		}
+	}
+
+	return slices.Clone(remotes), nil
+}
+
+// SetRemoteGroup creates or replaces the group with the given remotes.
+func (c *Config) SetRemoteGroup(name string, remotes []string) error {
+	if name == "" {
+		return fmt.Errorf("A remote group name is required")
+	}
+
+	// Don't allow groups which could be confused with a remote.
+	_, ok := c.Remotes[name]
+	if ok {
+		return fmt.Errorf("A remote named %q already exists", name)
+	}
+
+	members := []string{}
+	for _, remote := range remotes {
+		_, ok := c.Remotes[remote]
+		if !ok {
+			return fmt.Errorf("The remote %q doesn't exist", remote)
+		}
+
+		if !slices.Contains(members, remote) {
+			members = append(members, remote)
+		}
+	}
+
+	if c.RemoteGroups == nil {
+		c.RemoteGroups = map[string][]string{}
+	}
+
+	c.RemoteGroups[name] = members
+
+	return nil
+}
+
+// DeleteRemoteGroup removes the group. The remotes themselves are left untouched.
+func (c *Config) DeleteRemoteGroup(name string) error {
+	_, ok := c.RemoteGroups[name]
+	if !ok {
+		return fmt.Errorf("The remote group %q doesn't exist", name)
+	}
+
+	delete(c.RemoteGroups, name)
+
+	return nil
+}
+
+// RenameRemoteInGroups updates the groups following the rename of a remote.
+func (c *Config) RenameRemoteInGroups(oldName string, newName string) {
+	for _, remotes := range c.RemoteGroups {
+		for i, remote := range remotes {
+			if remote == oldName {
+				remotes[i] = newName
+			}
+		}
+	}
+}
+
+// RemoveRemoteFromGroups removes a remote from all the groups it's part of.
+func (c *Config) RemoveRemoteFromGroups(name string) {
+	for group, remotes := range c.RemoteGroups {
+		c.RemoteGroups[group] = slices.DeleteFunc(remotes, func(remote string) bool {
+			return remote == name
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package cliconfig
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRetryableRequest(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+	newRequest := func(method string, body io.Reader) *http.Request {
+		req, err := http.NewRequest(method, "https://localhost:8443/1.0", body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return req
+	}
+
+	noGetBody := newRequest(http.MethodGet, strings.NewReader("foo"))
+	noGetBody.GetBody = nil
+
+	tests := []struct {
+		name      string
+		req       *http.Request
+		status    int
+		err       error
+		retryable bool
+	}{
+		{"POST with dial error", newRequest(http.MethodPost, strings.NewReader("foo")), 0, dialErr, true},
+		{"POST with read error", newRequest(http.MethodPost, strings.NewReader("foo")), 0, readErr, false},
+		{"POST with 503", newRequest(http.MethodPost, strings.NewReader("foo")), http.StatusServiceUnavailable, nil, false},
+		{"GET with read error", newRequest(http.MethodGet, nil), 0, readErr, true},
+		{"GET with 502", newRequest(http.MethodGet, nil), http.StatusBadGateway, nil, true},
+		{"GET with 503", newRequest(http.MethodGet, nil), http.StatusServiceUnavailable, nil, true},
+		{"GET with 504", newRequest(http.MethodGet, nil), http.StatusGatewayTimeout, nil, true},
+		{"GET with 500", newRequest(http.MethodGet, nil), http.StatusInternalServerError, nil, false},
+		{"GET with 200", newRequest(http.MethodGet, nil), http.StatusOK, nil, false},
+		{"GET with cancelled context", newRequest(http.MethodGet, nil), 0, context.Canceled, false},
+		{"GET with expired context", newRequest(http.MethodGet, nil), 0, context.DeadlineExceeded, false},
+		{"Body without GetBody", noGetBody, 0, dialErr, false},
+	}
+
+	for _, test := range tests {
+		var resp *http.Response
+		if test.err == nil {
+			resp = &http.Response{StatusCode: test.status}
+		}
+
+		retryable := retryableRequest(test.req, resp, test.err)
+		if retryable != test.retryable {
+			t.Errorf("%s: expected retryable to be %v, got %v", test.name, test.retryable, retryable)
+		}
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransportRoundTrip(t *testing.T) {
+	bodies := []string{}
+
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		bodies = append(bodies, string(body))
+
+		// Only let the last attempt reach the server.
+		if len(bodies) < 3 {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+		}
+
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "https://localhost:8443/1.0/instances", strings.NewReader("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := &retryTransport{next: next, retries: 2}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if len(bodies) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(bodies))
+	}
+
+	for i, body := range bodies {
+		if body != "foo" {
+			t.Errorf("Attempt %d: expected body %q, got %q", i, "foo", body)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// collectorRecord represents a log record as sent to a collector.
+type collectorRecord struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Level     string            `json:"level"`
+	Message   string            `json:"message"`
+	Context   map[string]string `json:"context,omitempty"`
+}
+
+// CollectorHook is a logrus hook forwarding log records to an external HTTP collector in batches.
+//
+// Records are queued without ever blocking the caller. When the collector is unreachable, pending
+// records are kept up to the queue size and the oldest ones are dropped past that point.
+type CollectorHook struct {
+	url       string
+	levels    []logrus.Level
+	client    *http.Client
+	batchSize int
+	batchWait time.Duration
+	timeout   time.Duration
+	retryWait time.Duration
+
+	records chan collectorRecord
+	quit    chan struct{}
+	once    sync.Once
+	wg      sync.WaitGroup
+}
+
+// NewCollectorHook returns a hook forwarding the records of the given levels to the collector URL.
+// The records are sent as a JSON array in the body of a POST request.
+func NewCollectorHook(url string, levels []logrus.Level) *CollectorHook {
+	h := &CollectorHook{
+		url:       url,
+		levels:    levels,
+		client:    &http.Client{},
+		batchSize: 100,
+		batchWait: time.Second,
+		timeout:   10 * time.Second,
+		retryWait: 10 * time.Second,
+		records:   make(chan collectorRecord, 1000),
+		quit:      make(chan struct{}),
+	}
+
+	h.wg.Add(1)
+	go h.run()
+
+	return h
+}
+
+// Levels returns the log levels forwarded to the collector.
+func (h *CollectorHook) Levels() []logrus.Level {
+	return h.levels
+}
+
+// Fire queues the entry to be sent to the collector.
+func (h *CollectorHook) Fire(entry *logrus.Entry) error {
+	record := collectorRecord{
+		Timestamp: entry.Time,
+		Level:     entry.Level.String(),
+		Message:   entry.Message,
+	}
+
+	if len(entry.Data) > 0 {
+		record.Context = make(map[string]string, len(entry.Data))
+		for k, v := range entry.Data {
+			record.Context[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	// Never block logging on the collector, drop the record if the queue is full.
+	select {
+	case h.records <- record:
+	default:
+	}
+
+	return nil
+}
+
+// Stop sends any pending records and stops the hook.
+func (h *CollectorHook) Stop() {
+	h.once.Do(func() {
+		close(h.quit)
+	})
+
+	h.wg.Wait()
+}
+
+func (h *CollectorHook) run() {
+	defer h.wg.Done()
+
+	pending := make([]collectorRecord, 0, h.batchSize)
+
+	ticker := time.NewTicker(h.batchWait)
+	defer ticker.Stop()
+
+	// Delay new attempts after a failure to avoid stalling on an unreachable collector.
+	var retryAt time.Time
+
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+
+		if time.Now().After(retryAt) {
+			err := h.send(pending)
+			if err == nil {
+				pending = pending[:0]
+				return
+			}
+
+			retryAt = time.Now().Add(h.retryWait)
+		}
+
+		// Keep the records for the next attempt, dropping the oldest ones past the queue size.
+		if len(pending) > cap(h.records) {
+			pending = pending[len(pending)-cap(h.records):]
+		}
+	}
+
+	for {
+		select {
+		case <-h.quit:
+			// Pick up anything still queued before sending the last batch.
+			for len(h.records) > 0 {
+				pending = append(pending, <-h.records)
+			}
+
+			flush()
+			return
+
+		case record := <-h.records:
+			pending = append(pending, record)
+			if len(pending) >= h.batchSize {
+				flush()
+			}
+
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (h *CollectorHook) send(records []collectorRecord) error {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("Unexpected collector response: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorCtx returns a logging context describing the error along with its unwrap chain.
+//
+// The full message is kept in the "err" field while each wrapped layer gets its own "err.N" field, from the
+// outermost (0) to the innermost, with the messages of the layers it wraps stripped from it. This keeps the
+// structure of deep error chains queryable rather than flattened into a single string.
+func ErrorCtx(err error) Ctx {
+	if err == nil {
+		return Ctx{}
+	}
+
+	ctx := Ctx{"err": err.Error()}
+
+	for i := 0; err != nil; i++ {
+		inner := errors.Unwrap(err)
+
+		msg := err.Error()
+		if inner != nil {
+			// Only strip the wrapped message when it's at the end, as is the case with "%w" wrapping.
+			msg = strings.TrimSuffix(strings.TrimSuffix(msg, inner.Error()), ": ")
+			if msg == "" {
+				msg = err.Error()
+			}
+		}
+
+		ctx[fmt.Sprintf("err.%d", i)] = msg
+		err = inner
+	}
+
+	return ctx
+}
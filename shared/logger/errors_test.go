@@ -0,0 +1,42 @@
+package logger_test
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+func ExampleErrorCtx() {
+	errNetwork := errors.New("dial tcp 10.0.0.2:8443: connect: connection refused")
+	errDB := fmt.Errorf("Failed querying database: %w", errNetwork)
+	errJoin := fmt.Errorf("Failed joining cluster: %w", errDB)
+
+	for _, err := range []error{errJoin, errNetwork, nil} {
+		ctx := logger.ErrorCtx(err)
+
+		keys := make([]string, 0, len(ctx))
+		for k := range ctx {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			fmt.Printf("%s=%q\n", k, ctx[k])
+		}
+
+		fmt.Println("--")
+	}
+
+	// Output: err="Failed joining cluster: Failed querying database: dial tcp 10.0.0.2:8443: connect: connection refused"
+	// err.0="Failed joining cluster"
+	// err.1="Failed querying database"
+	// err.2="dial tcp 10.0.0.2:8443: connect: connection refused"
+	// --
+	// err="dial tcp 10.0.0.2:8443: connect: connection refused"
+	// err.0="dial tcp 10.0.0.2:8443: connect: connection refused"
+	// --
+	// --
+}
@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// levelMu protects the current log level and any pending revert of a raised level.
+var levelMu sync.Mutex
+
+// baseLevel is the level configured at initialization, which raised levels revert to.
+var baseLevel = logrus.WarnLevel
+
+// currentLevel is the most verbose level currently forwarded to the sinks.
+var currentLevel = logrus.WarnLevel
+
+// levelTimer reverts a raised level once it expires.
+var levelTimer *time.Timer
+
+// levelEnabled returns whether records of the given level should currently be written to the sinks.
+func levelEnabled(level logrus.Level) bool {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	return level <= currentLevel
+}
+
+// setBaseLevel sets the level configured at initialization, cancelling any raised level.
+func setBaseLevel(level logrus.Level) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	if levelTimer != nil {
+		levelTimer.Stop()
+		levelTimer = nil
+	}
+
+	baseLevel = level
+	currentLevel = level
+}
+
+// RaiseLevel temporarily raises the verbosity of the logs to the given level ("info" or "debug").
+// The configured level is automatically restored after the duration, raising the level again
+// before that replaces the previous level and duration.
+func RaiseLevel(level string, duration time.Duration) error {
+	var newLevel logrus.Level
+
+	switch level {
+	case "info":
+		newLevel = logrus.InfoLevel
+	case "debug":
+		newLevel = logrus.DebugLevel
+	default:
+		return fmt.Errorf("Invalid log level %q", level)
+	}
+
+	if duration <= 0 {
+		return fmt.Errorf("The duration must be positive")
+	}
+
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	if levelTimer != nil {
+		levelTimer.Stop()
+	}
+
+	// Never lower the verbosity below the configured level.
+	currentLevel = max(baseLevel, newLevel)
+
+	var timer *time.Timer
+	timer = time.AfterFunc(duration, func() {
+		levelMu.Lock()
+		defer levelMu.Unlock()
+
+		// Ignore a timer which was replaced in the meantime.
+		if levelTimer != timer {
+			return
+		}
+
+		levelTimer = nil
+		currentLevel = baseLevel
+	})
+
+	levelTimer = timer
+
+	return nil
+}
+
+// ResetLevel immediately restores the configured log level, cancelling any raised level.
+func ResetLevel() {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	if levelTimer != nil {
+		levelTimer.Stop()
+		levelTimer = nil
+	}
+
+	currentLevel = baseLevel
+}
@@ -3,23 +3,45 @@ package logger
 import (
 	"io"
 	"os"
+	"sync"
 
 	"github.com/sirupsen/logrus"
-	lWriter "github.com/sirupsen/logrus/hooks/writer"
 
 	"github.com/lxc/incus/v6/shared/termios"
 )
 
+// target is the logrus logger backing Log, kept to manage hooks at runtime.
+var target *logrus.Logger
+
+// hooksMu serializes runtime changes to the hooks of target.
+var hooksMu sync.Mutex
+
 // Setup a basic empty logger on init.
 func init() {
 	logger := logrus.New()
 	logger.SetOutput(io.Discard)
 
+	target = logger
 	Log = newWrapper(logger)
 }
 
 // InitLogger intializes a full logging instance.
 func InitLogger(filepath string, syslogName string, verbose bool, debug bool, hook logrus.Hook) error {
+	sinks := []Sink{{Type: SinkStderr, Format: SinkFormatText}}
+
+	if filepath != "" {
+		sinks = append(sinks, Sink{Type: SinkFile, Target: filepath, Format: SinkFormatText})
+	}
+
+	if syslogName != "" {
+		sinks = append(sinks, Sink{Type: SinkSyslog, Target: syslogName})
+	}
+
+	return InitLoggerSinks(sinks, verbose, debug, hook)
+}
+
+// InitLoggerSinks intializes a full logging instance writing to all the provided sinks.
+func InitLoggerSinks(sinks []Sink, verbose bool, debug bool, hook logrus.Hook) error {
 	logger := logrus.New()
 	logger.Level = logrus.DebugLevel
 	logger.SetOutput(io.Discard)
@@ -27,34 +49,19 @@ func InitLogger(filepath string, syslogName string, verbose bool, debug bool, ho
 	// Setup the formatter.
 	logger.Formatter = &logrus.TextFormatter{PadLevelText: true, FullTimestamp: true, ForceColors: termios.IsTerminal(int(os.Stderr.Fd()))}
 
-	// Setup log level.
-	levels := []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel, logrus.WarnLevel}
+	// Setup log level, the sinks filter records on it so it can be raised at runtime.
+	level := logrus.WarnLevel
 	if debug {
-		levels = append(levels, logrus.InfoLevel, logrus.DebugLevel)
+		level = logrus.DebugLevel
 	} else if verbose {
-		levels = append(levels, logrus.InfoLevel)
+		level = logrus.InfoLevel
 	}
 
-	// Setup writers.
-	writers := []io.Writer{os.Stderr}
+	setBaseLevel(level)
 
-	if filepath != "" {
-		f, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
-		if err != nil {
-			return err
-		}
-
-		writers = append(writers, f)
-	}
-
-	logger.AddHook(&lWriter.Hook{
-		Writer:    io.MultiWriter(writers...),
-		LogLevels: levels,
-	})
-
-	// Setup syslog.
-	if syslogName != "" {
-		err := setupSyslog(logger, syslogName)
+	// Setup sinks.
+	for _, sink := range sinks {
+		err := setupSink(logger, sink)
 		if err != nil {
 			return err
 		}
@@ -66,7 +73,36 @@ func InitLogger(filepath string, syslogName string, verbose bool, debug bool, ho
 	}
 
 	// Set the logger.
+	hooksMu.Lock()
+	target = logger
+	hooksMu.Unlock()
+
 	Log = newWrapper(logger)
 
 	return nil
 }
+
+// AddHook adds a hook to the current logger.
+func AddHook(hook logrus.Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	target.AddHook(hook)
+}
+
+// RemoveHook removes a hook previously added to the current logger.
+func RemoveHook(hook logrus.Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	hooks := make(logrus.LevelHooks)
+	for level, levelHooks := range target.Hooks {
+		for _, levelHook := range levelHooks {
+			if levelHook != hook {
+				hooks[level] = append(hooks[level], levelHook)
+			}
+		}
+	}
+
+	target.ReplaceHooks(hooks)
+}
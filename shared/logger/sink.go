@@ -0,0 +1,212 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/lxc/incus/v6/shared/termios"
+)
+
+// Sink types.
+const (
+	SinkStderr = "stderr"
+	SinkFile   = "file"
+	SinkSyslog = "syslog"
+)
+
+// Sink formats.
+const (
+	SinkFormatText   = "text"
+	SinkFormatJSON   = "json"
+	SinkFormatLogfmt = "logfmt"
+)
+
+// Sink timezones.
+const (
+	SinkTimezoneLocal = "local"
+	SinkTimezoneUTC   = "utc"
+)
+
+// sinkTimestamps maps the supported timestamp formats to their time layout.
+var sinkTimestamps = map[string]string{
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+}
+
+// Sink represents a destination log records are written to.
+type Sink struct {
+	Type      string // One of "stderr", "file" or "syslog".
+	Target    string // Path for "file" sinks, program name for "syslog" sinks.
+	Format    string // One of "text" (default), "json" or "logfmt".
+	Timestamp string // Either "rfc3339" (default) or "rfc3339nano".
+	Timezone  string // Either "local" (default) or "utc".
+}
+
+// ParseSink parses a sink definition of the form "TYPE[:TARGET][,OPTION=VALUE...]".
+// The supported options are "format", "timestamp" and "timezone".
+func ParseSink(value string) (*Sink, error) {
+	fields := strings.Split(value, ",")
+
+	sinkType, target, _ := strings.Cut(fields[0], ":")
+	sink := Sink{
+		Type:   sinkType,
+		Target: target,
+		Format: SinkFormatText,
+	}
+
+	for _, field := range fields[1:] {
+		key, value, _ := strings.Cut(field, "=")
+
+		switch key {
+		case "format":
+			sink.Format = value
+		case "timestamp":
+			sink.Timestamp = value
+		case "timezone":
+			sink.Timezone = value
+		default:
+			return nil, fmt.Errorf("Invalid log sink option %q", field)
+		}
+	}
+
+	err := validateSink(sink)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sink, nil
+}
+
+// validateSink checks that the sink definition is valid.
+func validateSink(sink Sink) error {
+	switch sink.Type {
+	case SinkStderr:
+		if sink.Target != "" {
+			return fmt.Errorf("The %q log sink doesn't take a target", sink.Type)
+		}
+
+	case SinkFile, SinkSyslog:
+		if sink.Target == "" {
+			return fmt.Errorf("The %q log sink requires a target", sink.Type)
+		}
+
+	default:
+		return fmt.Errorf("Invalid log sink type %q", sink.Type)
+	}
+
+	if sink.Format != "" && sink.Format != SinkFormatText && sink.Format != SinkFormatJSON && sink.Format != SinkFormatLogfmt {
+		return fmt.Errorf("Invalid log sink format %q", sink.Format)
+	}
+
+	_, found := sinkTimestamps[sink.Timestamp]
+	if sink.Timestamp != "" && !found {
+		return fmt.Errorf("Invalid log sink timestamp format %q", sink.Timestamp)
+	}
+
+	if sink.Timezone != "" && sink.Timezone != SinkTimezoneLocal && sink.Timezone != SinkTimezoneUTC {
+		return fmt.Errorf("Invalid log sink timezone %q", sink.Timezone)
+	}
+
+	return nil
+}
+
+// utcFormatter formats log records with their timestamp converted to UTC.
+type utcFormatter struct {
+	formatter logrus.Formatter
+}
+
+func (f *utcFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	// Work on a copy as the entry is shared with the other hooks.
+	utcEntry := *entry
+	utcEntry.Time = entry.Time.UTC()
+
+	return f.formatter.Format(&utcEntry)
+}
+
+// sinkFormatter returns the formatter matching the sink's format, timestamp format and timezone.
+func sinkFormatter(sink Sink) logrus.Formatter {
+	var formatter logrus.Formatter
+
+	timestampFormat := sinkTimestamps[sink.Timestamp]
+
+	switch sink.Format {
+	case SinkFormatJSON:
+		formatter = &logrus.JSONFormatter{TimestampFormat: timestampFormat}
+	case SinkFormatLogfmt:
+		// Without colors or padding, the text formatter emits plain key=value pairs.
+		formatter = &logrus.TextFormatter{DisableColors: true, FullTimestamp: true, QuoteEmptyFields: true, TimestampFormat: timestampFormat}
+	default:
+		formatter = &logrus.TextFormatter{PadLevelText: true, FullTimestamp: true, TimestampFormat: timestampFormat, ForceColors: sink.Type == SinkStderr && termios.IsTerminal(int(os.Stderr.Fd()))}
+	}
+
+	if sink.Timezone == SinkTimezoneUTC {
+		formatter = &utcFormatter{formatter: formatter}
+	}
+
+	return formatter
+}
+
+// sinkLevels are the levels sinks are hooked on, records are then filtered on the current log level.
+var sinkLevels = []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel, logrus.WarnLevel, logrus.InfoLevel, logrus.DebugLevel}
+
+// sinkHook writes log records to a sink's writer using the sink's own formatter.
+type sinkHook struct {
+	writer    io.Writer
+	formatter logrus.Formatter
+}
+
+// Fire writes the entry to the sink.
+// Errors are ignored so that a failing sink doesn't prevent the others from being written to.
+func (h *sinkHook) Fire(entry *logrus.Entry) error {
+	if !levelEnabled(entry.Level) {
+		return nil
+	}
+
+	line, err := h.formatter.Format(entry)
+	if err == nil {
+		_, _ = h.writer.Write(line)
+	}
+
+	return nil
+}
+
+func (h *sinkHook) Levels() []logrus.Level {
+	return sinkLevels
+}
+
+// setupSink attaches the sink to the logger, only forwarding records of the current log level.
+func setupSink(logger *logrus.Logger, sink Sink) error {
+	err := validateSink(sink)
+	if err != nil {
+		return err
+	}
+
+	if sink.Type == SinkSyslog {
+		return setupSyslog(logger, sink.Target, sinkFormatter(sink))
+	}
+
+	hook := &sinkHook{
+		formatter: sinkFormatter(sink),
+	}
+
+	switch sink.Type {
+	case SinkStderr:
+		hook.writer = os.Stderr
+	case SinkFile:
+		f, err := os.OpenFile(sink.Target, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return err
+		}
+
+		hook.writer = f
+	}
+
+	logger.AddHook(hook)
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package logger_test
+
+import (
+	"fmt"
+
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+func ExampleParseSink() {
+	tests := []string{
+		"stderr",
+		"stderr,format=json",
+		"stderr,format=logfmt",
+		"file:/var/log/incus/incusd.log",
+		"file:/var/log/incus/incusd.json,format=json",
+		"syslog:incus",
+		"syslog:incus,timestamp=rfc3339nano,timezone=utc",
+		"stderr:foo",            // stderr doesn't take a target
+		"file",                  // missing target
+		"file:/tmp/log,foo=bar", // invalid option
+		"stderr,format=xml",     // invalid format
+		"stderr,timestamp=unix", // invalid timestamp format
+		"stderr,timezone=CET",   // invalid timezone
+		"invalid",
+	}
+
+	for _, v := range tests {
+		sink, err := logger.ParseSink(v)
+		if err != nil {
+			fmt.Printf("%s, %v\n", v, err)
+			continue
+		}
+
+		fmt.Printf("%s, %+v\n", v, *sink)
+	}
+
+	// Output: stderr, {Type:stderr Target: Format:text Timestamp: Timezone:}
+	// stderr,format=json, {Type:stderr Target: Format:json Timestamp: Timezone:}
+	// stderr,format=logfmt, {Type:stderr Target: Format:logfmt Timestamp: Timezone:}
+	// file:/var/log/incus/incusd.log, {Type:file Target:/var/log/incus/incusd.log Format:text Timestamp: Timezone:}
+	// file:/var/log/incus/incusd.json,format=json, {Type:file Target:/var/log/incus/incusd.json Format:json Timestamp: Timezone:}
+	// syslog:incus, {Type:syslog Target:incus Format:text Timestamp: Timezone:}
+	// syslog:incus,timestamp=rfc3339nano,timezone=utc, {Type:syslog Target:incus Format:text Timestamp:rfc3339nano Timezone:utc}
+	// stderr:foo, The "stderr" log sink doesn't take a target
+	// file, The "file" log sink requires a target
+	// file:/tmp/log,foo=bar, Invalid log sink option "foo=bar"
+	// stderr,format=xml, Invalid log sink format "xml"
+	// stderr,timestamp=unix, Invalid log sink timestamp format "unix"
+	// stderr,timezone=CET, Invalid log sink timezone "CET"
+	// invalid, Invalid log sink type "invalid"
+}
@@ -6,15 +6,33 @@ import (
 	"log/syslog"
 
 	"github.com/sirupsen/logrus"
-	lSyslog "github.com/sirupsen/logrus/hooks/syslog"
 )
 
 type syslogHandler struct {
-	handler logrus.Hook
+	writer    *syslog.Writer
+	formatter logrus.Formatter
 }
 
+// Fire sends the entry to syslog.
+// Errors are ignored so that a failing syslog doesn't prevent the other sinks from being written to.
 func (h syslogHandler) Fire(entry *logrus.Entry) error {
-	return h.handler.Fire(entry)
+	line, err := h.formatter.Format(entry)
+	if err != nil {
+		return nil
+	}
+
+	switch entry.Level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		_ = h.writer.Crit(string(line))
+	case logrus.ErrorLevel:
+		_ = h.writer.Err(string(line))
+	case logrus.WarnLevel:
+		_ = h.writer.Warning(string(line))
+	case logrus.InfoLevel:
+		_ = h.writer.Info(string(line))
+	}
+
+	return nil
 }
 
 func (h syslogHandler) Levels() []logrus.Level {
@@ -27,12 +45,12 @@ func (h syslogHandler) Levels() []logrus.Level {
 	}
 }
 
-func setupSyslog(logger *logrus.Logger, syslogName string) error {
-	syslogHook, err := lSyslog.NewSyslogHook("", "", syslog.LOG_INFO, syslogName)
+func setupSyslog(logger *logrus.Logger, syslogName string, formatter logrus.Formatter) error {
+	writer, err := syslog.New(syslog.LOG_INFO, syslogName)
 	if err != nil {
 		return err
 	}
 
-	logger.AddHook(syslogHandler{syslogHook})
+	logger.AddHook(syslogHandler{writer: writer, formatter: formatter})
 	return nil
 }
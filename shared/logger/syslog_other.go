@@ -8,6 +8,6 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func setupSyslog(logger *logrus.Logger, syslogName string) error {
+func setupSyslog(logger *logrus.Logger, syslogName string, formatter logrus.Formatter) error {
 	return fmt.Errorf("Syslog logging isn't supported on this platform")
 }
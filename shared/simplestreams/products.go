@@ -8,6 +8,7 @@ import (
 
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 // Products represents the base of download.json.
@@ -61,6 +62,33 @@ type ProductVersionItem struct {
 	DeltaBase                 string `json:"delta_base,omitempty"`
 }
 
+// itemFingerprint returns the fingerprint of the image made of the given metadata and root items.
+// The root item is nil for unified images.
+func itemFingerprint(meta *ProductVersionItem, root *ProductVersionItem) string {
+	if root == nil {
+		return meta.HashSha256
+	}
+
+	switch root.FileType {
+	case "root.tar.xz":
+		if meta.CombinedSha256RootXz != "" {
+			return meta.CombinedSha256RootXz
+		}
+
+		return meta.CombinedSha256
+	case "squashfs":
+		return meta.CombinedSha256SquashFs
+	case "disk-kvm.img":
+		return meta.CombinedSha256DiskKvmImg
+	case "disk1.img":
+		return meta.CombinedSha256DiskImg
+	case "uefi1.img":
+		return meta.CombinedSha256DiskUefiImg
+	}
+
+	return ""
+}
+
 // ToAPI converts the products data into a list of API images and associated downloadable files.
 func (s *Products) ToAPI() ([]api.Image, map[string][][]string) {
 	downloads := map[string][][]string{}
@@ -105,26 +133,7 @@ func (s *Products) ToAPI() ([]api.Image, map[string][][]string) {
 				}
 
 				// Figure out the fingerprint
-				fingerprint := ""
-				if root != nil {
-					if root.FileType == "root.tar.xz" {
-						if meta.CombinedSha256RootXz != "" {
-							fingerprint = meta.CombinedSha256RootXz
-						} else {
-							fingerprint = meta.CombinedSha256
-						}
-					} else if root.FileType == "squashfs" {
-						fingerprint = meta.CombinedSha256SquashFs
-					} else if root.FileType == "disk-kvm.img" {
-						fingerprint = meta.CombinedSha256DiskKvmImg
-					} else if root.FileType == "disk1.img" {
-						fingerprint = meta.CombinedSha256DiskImg
-					} else if root.FileType == "uefi1.img" {
-						fingerprint = meta.CombinedSha256DiskUefiImg
-					}
-				} else {
-					fingerprint = meta.HashSha256
-				}
+				fingerprint := itemFingerprint(meta, root)
 
 				if fingerprint == "" {
 					return fmt.Errorf("No image fingerprint found")
@@ -189,6 +198,16 @@ func (s *Products) ToAPI() ([]api.Image, map[string][][]string) {
 					image.Properties["type"] = "tar.gz"
 				}
 
+				// Only surface secure boot support on virtual machine images, using a consistent value.
+				secureBoot := image.Properties["requirements.secureboot"]
+				if image.Type == "virtual-machine" && util.IsTrue(secureBoot) {
+					image.Properties["requirements.secureboot"] = "true"
+				} else if image.Type == "virtual-machine" && util.IsFalse(secureBoot) {
+					image.Properties["requirements.secureboot"] = "false"
+				} else {
+					delete(image.Properties, "requirements.secureboot")
+				}
+
 				// Clear unset properties
 				for k, v := range image.Properties {
 					if v == "" {
@@ -0,0 +1,148 @@
+package simplestreams
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v6/shared/osarch"
+)
+
+// Validate checks the structure of the products data and returns all the problems found.
+// Unlike ToAPI which silently skips invalid entries, this reports every one of them.
+func (s *Products) Validate() []error {
+	problems := []error{}
+
+	productNames := make([]string, 0, len(s.Products))
+	for name := range s.Products {
+		productNames = append(productNames, name)
+	}
+
+	sort.Strings(productNames)
+
+	for _, productName := range productNames {
+		product := s.Products[productName]
+
+		addProblem := func(format string, args ...any) {
+			problems = append(problems, fmt.Errorf("Product %q: %s", productName, fmt.Sprintf(format, args...)))
+		}
+
+		_, err := osarch.ArchitectureId(product.Architecture)
+		if err != nil {
+			addProblem("Unsupported architecture %q", product.Architecture)
+		}
+
+		if product.SupportedEOL != "" {
+			_, err := time.Parse("2006-01-02", product.SupportedEOL)
+			if err != nil {
+				addProblem("Invalid support EOL date %q", product.SupportedEOL)
+			}
+		}
+
+		if len(product.Versions) == 0 {
+			addProblem("No versions")
+		}
+
+		versionNames := make([]string, 0, len(product.Versions))
+		for name := range product.Versions {
+			versionNames = append(versionNames, name)
+		}
+
+		sort.Strings(versionNames)
+
+		for _, versionName := range versionNames {
+			version := product.Versions[versionName]
+
+			// The version name is used as the image creation date.
+			if len(versionName) < 8 {
+				addProblem("Version %q: Name doesn't start with a date", versionName)
+			} else {
+				_, err := time.Parse("20060102", versionName[0:8])
+				if err != nil {
+					addProblem("Version %q: Invalid date %q", versionName, versionName[0:8])
+				}
+			}
+
+			itemNames := make([]string, 0, len(version.Items))
+			for name := range version.Items {
+				itemNames = append(itemNames, name)
+			}
+
+			sort.Strings(itemNames)
+
+			hasImage := false
+			for _, itemName := range itemNames {
+				item := version.Items[itemName]
+
+				addItemProblem := func(format string, args ...any) {
+					addProblem("Version %q: Item %q: %s", versionName, itemName, fmt.Sprintf(format, args...))
+				}
+
+				if item.Path == "" {
+					addItemProblem("Missing path")
+				}
+
+				if item.HashSha256 == "" {
+					addItemProblem("Missing sha256 hash")
+				}
+
+				if item.FileType == "" {
+					addItemProblem("Missing file type")
+				}
+
+				switch item.FileType {
+				case "incus_combined.tar.gz":
+					hasImage = true
+
+				case "incus.tar.xz":
+					// Check that every image this metadata can be combined with has a fingerprint.
+					for _, rootName := range itemNames {
+						root := version.Items[rootName]
+						if !slices.Contains([]string{"disk1.img", "disk-kvm.img", "uefi1.img", "root.tar.xz", "squashfs"}, root.FileType) {
+							continue
+						}
+
+						hasImage = true
+
+						if itemFingerprint(&item, &root) == "" {
+							addItemProblem("Missing combined fingerprint for %q", root.FileType)
+						}
+					}
+				}
+
+				if strings.HasSuffix(item.FileType, ".vcdiff") {
+					if item.DeltaBase == "" {
+						addItemProblem("Missing delta base")
+						continue
+					}
+
+					base, ok := product.Versions[item.DeltaBase]
+					if !ok {
+						addItemProblem("Delta base %q doesn't exist", item.DeltaBase)
+						continue
+					}
+
+					baseFingerprint := ""
+					for _, baseItem := range base.Items {
+						if baseItem.FileType == "incus.tar.xz" {
+							baseFingerprint = baseItem.CombinedSha256SquashFs
+							break
+						}
+					}
+
+					if baseFingerprint == "" {
+						addItemProblem("Delta base %q has no squashfs fingerprint", item.DeltaBase)
+					}
+				}
+			}
+
+			if !hasImage {
+				addProblem("Version %q: No usable image", versionName)
+			}
+		}
+	}
+
+	return problems
+}
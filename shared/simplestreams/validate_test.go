@@ -0,0 +1,38 @@
+package simplestreams
+
+import (
+	"fmt"
+)
+
+func ExampleProducts_Validate() {
+	products := Products{
+		Products: map[string]Product{
+			"debian:12:amd64:default": {
+				Architecture: "amd64",
+				SupportedEOL: "2028-06",
+				Versions: map[string]ProductVersion{
+					"20240101_00:00": {
+						Items: map[string]ProductVersionItem{
+							"incus.tar.xz":  {FileType: "incus.tar.xz", Path: "images/meta.tar.xz", HashSha256: "aaaa", CombinedSha256SquashFs: "bbbb"},
+							"root.squashfs": {FileType: "squashfs", Path: "images/root.squashfs", HashSha256: "cccc"},
+							"root.tar.xz":   {FileType: "root.tar.xz", HashSha256: "dddd"},
+							"delta":         {FileType: "squashfs.vcdiff", Path: "images/delta", HashSha256: "eeee", DeltaBase: "20231201_00:00"},
+						},
+					},
+					"latest": {},
+				},
+			},
+		},
+	}
+
+	for _, problem := range products.Validate() {
+		fmt.Println(problem)
+	}
+
+	// Output: Product "debian:12:amd64:default": Invalid support EOL date "2028-06"
+	// Product "debian:12:amd64:default": Version "20240101_00:00": Item "delta": Delta base "20231201_00:00" doesn't exist
+	// Product "debian:12:amd64:default": Version "20240101_00:00": Item "incus.tar.xz": Missing combined fingerprint for "root.tar.xz"
+	// Product "debian:12:amd64:default": Version "20240101_00:00": Item "root.tar.xz": Missing path
+	// Product "debian:12:amd64:default": Version "latest": Name doesn't start with a date
+	// Product "debian:12:amd64:default": Version "latest": No usable image
+}
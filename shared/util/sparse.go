@@ -0,0 +1,136 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// sparseBlockSize is the granularity at which holes are detected.
+const sparseBlockSize = 4096
+
+// sparseSegmentSize is the maximum amount of data held in a single segment.
+const sparseSegmentSize = 1024 * 1024
+
+// sparseReadBufferSize is how much of the source is read at once, rather than a block at a time.
+const sparseReadBufferSize = 1024 * 1024
+
+// SparseFile represents a destination file for a sparse stream.
+type SparseFile interface {
+	io.WriterAt
+	Truncate(size int64) error
+}
+
+type sparseReader struct {
+	reader  io.Reader
+	block   []byte
+	offset  int64
+	start   int64
+	data    []byte
+	pending bytes.Buffer
+	done    bool
+}
+
+// NewSparseReader returns a reader encoding the content of the given reader as a sparse stream.
+//
+// The stream is a sequence of segments, each made of its offset and length as big endian 64-bit
+// integers followed by its data. Blocks only made of zeros are left out and the stream ends with
+// an empty segment whose offset is the total size.
+func NewSparseReader(reader io.Reader) io.Reader {
+	return &sparseReader{
+		reader: bufio.NewReaderSize(reader, sparseReadBufferSize),
+		block:  make([]byte, sparseBlockSize),
+	}
+}
+
+// flush queues the current segment.
+func (r *sparseReader) flush() {
+	if len(r.data) == 0 {
+		return
+	}
+
+	_ = binary.Write(&r.pending, binary.BigEndian, []uint64{uint64(r.start), uint64(len(r.data))})
+	r.pending.Write(r.data)
+	r.data = r.data[:0]
+}
+
+func (r *sparseReader) Read(p []byte) (int, error) {
+	for r.pending.Len() == 0 && !r.done {
+		n, err := io.ReadFull(r.reader, r.block)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, err
+		}
+
+		if n > 0 {
+			if bytes.Count(r.block[:n], []byte{0}) == n {
+				// Leave holes out of the stream.
+				r.flush()
+			} else {
+				if len(r.data) == 0 {
+					r.start = r.offset
+				}
+
+				r.data = append(r.data, r.block[:n]...)
+				if len(r.data) >= sparseSegmentSize {
+					r.flush()
+				}
+			}
+
+			r.offset += int64(n)
+		}
+
+		if err != nil {
+			// Mark the end of the stream with the total size.
+			r.flush()
+			_ = binary.Write(&r.pending, binary.BigEndian, []uint64{uint64(r.offset), 0})
+			r.done = true
+		}
+	}
+
+	if r.pending.Len() == 0 {
+		return 0, io.EOF
+	}
+
+	return r.pending.Read(p)
+}
+
+// SparseCopy writes a sparse stream to the destination file, leaving holes where no data was sent.
+// It returns the total size of the file.
+func SparseCopy(dst SparseFile, src io.Reader) (int64, error) {
+	header := make([]uint64, 2)
+
+	for {
+		err := binary.Read(src, binary.BigEndian, header)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return -1, fmt.Errorf("Failed reading sparse segment: %w", err)
+		}
+
+		offset := int64(header[0])
+		length := int64(header[1])
+		if offset < 0 || length < 0 {
+			return -1, fmt.Errorf("Invalid sparse segment at offset %d", header[0])
+		}
+
+		// The last segment only holds the total size.
+		if length == 0 {
+			err = dst.Truncate(offset)
+			if err != nil {
+				return -1, err
+			}
+
+			return offset, nil
+		}
+
+		_, err = io.CopyN(io.NewOffsetWriter(dst, offset), src, length)
+		if err != nil {
+			return -1, fmt.Errorf("Failed writing sparse segment: %w", err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSparseCopy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{"empty", []byte{}},
+		{"data", bytes.Repeat([]byte("incus"), 3000)},
+		{"leading hole", append(make([]byte, 3*sparseBlockSize), []byte("data")...)},
+		{"trailing hole", append([]byte("data"), make([]byte, 3*sparseBlockSize)...)},
+		{"holes", append(append(bytes.Repeat([]byte{1}, sparseBlockSize+10), make([]byte, 5*sparseBlockSize)...), bytes.Repeat([]byte{2}, 10)...)},
+		{"large", bytes.Repeat([]byte{1}, 2*sparseSegmentSize+10)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream, err := io.ReadAll(NewSparseReader(bytes.NewReader(test.content)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := os.Create(filepath.Join(t.TempDir(), "file"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer func() { _ = f.Close() }()
+
+			size, err := SparseCopy(f, bytes.NewReader(stream))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if size != int64(len(test.content)) {
+				t.Fatalf("Expected size %d, got %d", len(test.content), size)
+			}
+
+			content, err := os.ReadFile(f.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(content, test.content) {
+				t.Fatal("Copied content doesn't match")
+			}
+		})
+	}
+}
+
+func TestSparseReaderSkipsHoles(t *testing.T) {
+	content := append(append([]byte("start"), make([]byte, 100*sparseBlockSize)...), []byte("end")...)
+
+	stream, err := io.ReadAll(NewSparseReader(bytes.NewReader(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream) >= 4*sparseBlockSize {
+		t.Fatalf("Expected holes to be left out of the stream, got %d bytes", len(stream))
+	}
+}
+
+func TestSparseCopyTruncated(t *testing.T) {
+	stream, err := io.ReadAll(NewSparseReader(bytes.NewReader([]byte("data"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	_, err = SparseCopy(f, bytes.NewReader(stream[:len(stream)-1]))
+	if err == nil {
+		t.Fatal("Expected an error on a truncated stream")
+	}
+}
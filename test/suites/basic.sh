@@ -633,6 +633,11 @@ test_basic_usage() {
   incus rebuild testimage c1
   incus start c1
   ! incus exec c1 -- stat /data.txt || false
+  incus exec c1 -- touch /data.txt
+  incus stop c1
+  incus rebuild c1
+  incus start c1
+  ! incus exec c1 -- stat /data.txt || false
   incus delete c1 -f
 
   # Test a forced rebuild
@@ -647,6 +652,15 @@ test_basic_usage() {
   incus start c1
   incus delete c1 -f
 
+  # Test that rebuilding an instance preserves its identity unless asked otherwise.
+  incus init testimage c1
+  uuid="$(incus config get c1 volatile.uuid)"
+  incus rebuild testimage c1
+  [ "$(incus config get c1 volatile.uuid)" = "${uuid}" ]
+  incus rebuild testimage c1 --reset-identity
+  [ "$(incus config get c1 volatile.uuid)" != "${uuid}" ]
+  incus delete c1 -f
+
   # Test rebuilding an instance with an empty file system.
   incus init testimage c1
   incus rebuild c1 --empty
@@ -48,6 +48,12 @@ snapshots() {
     [ -d "${INCUS_DIR}/containers/foosnap1/rootfs" ]
   fi
 
+  # Promote a snapshot to a new standalone instance.
+  incus snapshot promote foo tester foosnap2
+  ! incus info foosnap2 | grep -q "Snapshots:" || false
+  ! incus snapshot promote foo tester foosnap2 || false
+  incus delete foosnap2
+
   incus snapshot delete foo snap0
   # FIXME: make this backend agnostic
   if [ "$incus_backend" = "dir" ]; then